@@ -5,20 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"reflect"
 	"sort"
+	"strconv"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+	"code.cloudfoundry.org/rep/generator"
 )
 
 //go:generate counterfeiter . AuctionCellClient
 
 type AuctionCellClient interface {
 	State(logger lager.Logger) (rep.CellState, bool, error)
+	StreamState(logger lager.Logger, done <-chan struct{}) (<-chan rep.StateEvent, error)
 	Perform(logger lager.Logger, work rep.Work) (rep.Work, error)
+	PerformDryRun(logger lager.Logger, work rep.Work) (rep.Work, error)
+	Reserve(logger lager.Logger, request rep.ReservationRequest) (rep.Reservation, error)
+	Prewarm(logger lager.Logger, request rep.PrewarmRequest) error
+	ContainerDetail(logger lager.Logger, guid string) (rep.ContainerDetail, error)
+	ContainerDiagnostics(logger lager.Logger, guid string, resultFileLines int) (rep.ContainerDiagnostics, error)
 	Reset() error
 }
 
@@ -30,65 +42,139 @@ type AuctionCellRep struct {
 	cellID                   string
 	cellIndex                int
 	repURL                   string
-	stackPathMap             rep.StackPathMap
+	stacks                   rep.StackSource
+	arbitraryRootFSes        []string
 	rootFSProviders          rep.RootFSProviders
+	preloadedVersionRanges   map[string]string
 	containerMetricsProvider rep.ContainerMetricsProvider
 	zone                     string
 	client                   executor.Client
+	containerDelegate        generator.ContainerDelegate
 	evacuationReporter       evacuation_context.EvacuationReporter
+	evictionReporter         EvictionReporter
 	placementTags            []string
 	optionalPlacementTags    []string
+	placementLabels          map[string]string
 	enableContainerProxy     bool
 	proxyMemoryAllocation    int
+	cpuWeightCapacity        int
+	pidCapacity              int
+	scoring                  rep.ScoringConfig
+	domainQuotas             rep.DomainQuotas
+	clock                    clock.Clock
+	reservationTTL           time.Duration
 	allocator                BatchContainerAllocator
+
+	// reservationsLock is held from reading the remaining resources until
+	// the capacity is reserved or allocated, so that concurrent Reserve and
+	// Perform calls cannot claim the same capacity.
+	reservationsLock sync.Mutex
+	reservations     map[string]rep.Reservation
+
+	prewarmLock           sync.Mutex
+	prewarmedRootFSes     map[string]time.Time
+	prewarmedDependencies map[string]time.Time
+	prewarmQueue          []prewarmJob
+	prewarmWorkers        int
+
+	generationLock sync.Mutex
+	generation     uint64
+	lastState      *rep.CellState
+}
+
+// Config holds what the auction cell rep needs to place work on the cell and
+// to report its state.
+type Config struct {
+	CellID                   string
+	CellIndex                int
+	RepURL                   string
+	Zone                     string
+	Stacks                   rep.StackSource
+	ContainerMetricsProvider rep.ContainerMetricsProvider
+	ArbitraryRootFSes        []string
+	RootFSProviders          rep.RootFSProviders
+	PreloadedVersionRanges   map[string]string
+	Client                   executor.Client
+	EvacuationReporter       evacuation_context.EvacuationReporter
+	EvictionReporter         EvictionReporter
+	PlacementTags            []string
+	OptionalPlacementTags    []string
+	PlacementLabels          map[string]string
+	ProxyMemoryAllocation    int
+	EnableContainerProxy     bool
+	CPUWeightCapacity        int
+	PidCapacity              int
+	Scoring                  rep.ScoringConfig
+	DomainQuotas             rep.DomainQuotas
+	Clock                    clock.Clock
+	ReservationTTL           time.Duration
+	Allocator                BatchContainerAllocator
 }
 
-func New(
-	cellID string,
-	cellIndex int,
-	repURL string,
-	preloadedStackPathMap rep.StackPathMap,
-	containerMetricsProvider rep.ContainerMetricsProvider,
-	arbitraryRootFSes []string,
-	zone string,
-	client executor.Client,
-	evacuationReporter evacuation_context.EvacuationReporter,
-	placementTags []string,
-	optionalPlacementTags []string,
-	proxyMemoryAllocation int,
-	enableContainerProxy bool,
-	allocator BatchContainerAllocator,
-) *AuctionCellRep {
+func New(config Config) *AuctionCellRep {
 	return &AuctionCellRep{
-		cellID:                   cellID,
-		cellIndex:                cellIndex,
-		repURL:                   repURL,
-		stackPathMap:             preloadedStackPathMap,
-		rootFSProviders:          rootFSProviders(preloadedStackPathMap, arbitraryRootFSes),
-		containerMetricsProvider: containerMetricsProvider,
-		zone:                     zone,
-		client:                   client,
-		evacuationReporter:       evacuationReporter,
-		placementTags:            placementTags,
-		optionalPlacementTags:    optionalPlacementTags,
-		enableContainerProxy:     enableContainerProxy,
-		proxyMemoryAllocation:    proxyMemoryAllocation,
-		allocator:                allocator,
+		cellID:                   config.CellID,
+		cellIndex:                config.CellIndex,
+		repURL:                   config.RepURL,
+		stacks:                   config.Stacks,
+		arbitraryRootFSes:        config.ArbitraryRootFSes,
+		rootFSProviders:          config.RootFSProviders,
+		preloadedVersionRanges:   config.PreloadedVersionRanges,
+		containerMetricsProvider: config.ContainerMetricsProvider,
+		zone:                     config.Zone,
+		client:                   config.Client,
+		containerDelegate:        generator.NewContainerDelegate(config.Client),
+		evacuationReporter:       config.EvacuationReporter,
+		evictionReporter:         config.EvictionReporter,
+		placementTags:            config.PlacementTags,
+		optionalPlacementTags:    config.OptionalPlacementTags,
+		placementLabels:          config.PlacementLabels,
+		enableContainerProxy:     config.EnableContainerProxy,
+		proxyMemoryAllocation:    config.ProxyMemoryAllocation,
+		cpuWeightCapacity:        config.CPUWeightCapacity,
+		pidCapacity:              config.PidCapacity,
+		scoring:                  config.Scoring,
+		domainQuotas:             config.DomainQuotas,
+		clock:                    config.Clock,
+		reservationTTL:           config.ReservationTTL,
+		allocator:                config.Allocator,
+		reservations:             map[string]rep.Reservation{},
+		prewarmedRootFSes:        map[string]time.Time{},
+		prewarmedDependencies:    map[string]time.Time{},
+		generation:               uint64(config.Clock.Now().UnixNano()),
 	}
 }
 
-func rootFSProviders(preloaded rep.StackPathMap, arbitrary []string) rep.RootFSProviders {
+// rootFSProviders advertises the arbitrary schemes and the preloaded stacks,
+// then the configured providers, which take precedence for their scheme. The
+// preloaded stacks are advertised as a versioned set when version ranges are
+// configured, a stack without a range matching any version.
+func rootFSProviders(preloaded rep.StackPathMap, arbitrary []string, configured rep.RootFSProviders, versionRanges map[string]string) rep.RootFSProviders {
 	rootFSProviders := rep.RootFSProviders{}
 	for _, scheme := range arbitrary {
 		rootFSProviders[scheme] = rep.ArbitraryRootFSProvider{}
 	}
 
-	stacks := make([]string, 0, len(preloaded))
-	for stack, _ := range preloaded {
-		stacks = append(stacks, stack)
+	var preloadedProvider rep.RootFSProvider
+	if len(versionRanges) > 0 {
+		versionedSet := make(map[string]string, len(preloaded))
+		for stack := range preloaded {
+			versionedSet[stack] = versionRanges[stack]
+		}
+		preloadedProvider = rep.VersionedSetRootFSProvider{VersionedSet: versionedSet}
+	} else {
+		stacks := make([]string, 0, len(preloaded))
+		for stack, _ := range preloaded {
+			stacks = append(stacks, stack)
+		}
+		preloadedProvider = rep.NewFixedSetRootFSProvider(stacks...)
+	}
+	rootFSProviders[models.PreloadedRootFSScheme] = preloadedProvider
+	rootFSProviders[models.PreloadedOCIRootFSScheme] = preloadedProvider
+
+	for scheme, provider := range configured {
+		rootFSProviders[scheme] = provider
 	}
-	rootFSProviders[models.PreloadedRootFSScheme] = rep.NewFixedSetRootFSProvider(stacks...)
-	rootFSProviders[models.PreloadedOCIRootFSScheme] = rep.NewFixedSetRootFSProvider(stacks...)
 
 	return rootFSProviders
 }
@@ -113,41 +199,90 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 	logger = logger.Session("auction-state")
 	logger.Info("providing")
 
+	state, err := a.cellState(logger)
+	if err != nil {
+		return rep.CellState{}, false, err
+	}
+
+	healthy := a.client.Healthy(logger)
+	if !healthy {
+		logger.Error("failed-garden-health-check", nil)
+	}
+
+	logger.Info("provided", lager.Data{
+		"available-resources": state.AvailableResources,
+		"total-resources":     state.TotalResources,
+		"num-lrps":            len(state.LRPs),
+		"zone":                state.Zone,
+		"evacuating":          state.Evacuating,
+		"generation":          state.Generation,
+	})
+
+	return state, healthy, nil
+}
+
+// cellState computes the state of the cell from the executor's containers and
+// resources, without checking the executor's health.
+func (a *AuctionCellRep) cellState(logger lager.Logger) (rep.CellState, error) {
 	containers, err := a.client.ListContainers(logger)
 	if err != nil {
 		logger.Error("failed-to-fetch-containers", err)
-		return rep.CellState{}, false, err
+		return rep.CellState{}, err
 	}
 
 	totalResources, err := a.client.TotalResources(logger)
 	if err != nil {
 		logger.Error("failed-to-get-total-resources", err)
-		return rep.CellState{}, false, err
+		return rep.CellState{}, err
 	}
 
 	availableResources, err := a.client.RemainingResources(logger)
 	if err != nil {
 		logger.Error("failed-to-get-remaining-resource", err)
-		return rep.CellState{}, false, err
+		return rep.CellState{}, err
 	}
 
 	volumeDrivers, err := a.client.VolumeDrivers(logger)
 	if err != nil {
 		logger.Error("failed-to-get-volume-drivers", err)
-		return rep.CellState{}, false, err
+		return rep.CellState{}, err
 	}
 
+	stackPathMap := a.stacks.Stacks()
 	lrps := []rep.LRP{}
 	tasks := []rep.Task{}
 	startingContainerCount := 0
+	usedCPUWeight := 0
+	usedPids := 0
+	domainUsage := map[string]rep.DomainUsage{}
+	cachedRootFSes := map[string]struct{}{}
 
 	for i := range containers {
 		container := &containers[i]
+		if container.Tags[rep.LifecycleTag] == rep.PrewarmLifecycle {
+			// prewarm containers only live until their image is fetched, so
+			// they are not advertised as LRPs or tasks. The executor counts them
+			// towards its containers until they are deleted, so Perform cannot
+			// place work in their slots and they are not advertised as free.
+			if imageFetched(container) {
+				addCachedRootFS(cachedRootFSes, rootFSURLFromPath(container.RootFSPath, stackPathMap))
+			}
+			continue
+		}
+
+		addContainerDomainUsage(domainUsage, container)
+		if imageFetched(container) {
+			addCachedRootFS(cachedRootFSes, rootFSURLFromPath(container.RootFSPath, stackPathMap))
+		}
 
 		if containerIsStarting(container) {
 			startingContainerCount++
 		}
 
+		cpuWeight := containerCPUWeight(container)
+		usedCPUWeight += int(cpuWeight)
+		usedPids += container.MaxPids
+
 		if container.Tags == nil {
 			logger.Error("failed-to-extract-container-tags", nil)
 			continue
@@ -167,9 +302,9 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 			logger.Error("cannot-unmarshal-volume-drivers", err, lager.Data{"volume-drivers": volumeDriversJSON})
 		}
 
-		resource := rep.Resource{MemoryMB: int32(container.MemoryMB), DiskMB: int32(container.DiskMB), MaxPids: int32(container.MaxPids)}
+		resource := rep.Resource{MemoryMB: int32(container.MemoryMB), DiskMB: int32(container.DiskMB), MaxPids: int32(container.MaxPids), CPUWeight: cpuWeight}
 		placementConstraint := rep.PlacementConstraint{
-			RootFs:        rootFSURLFromPath(container.RootFSPath, a.stackPathMap),
+			RootFs:        rootFSURLFromPath(container.RootFSPath, stackPathMap),
 			VolumeDrivers: volumeDrivers,
 			PlacementTags: placementTags,
 		}
@@ -200,6 +335,7 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 			}
 			lrp := rep.NewLRP(instanceKey.InstanceGuid, *key, resource, placementConstraint)
 			lrp.State = state
+			lrp.Priority = containerPriority(container)
 			lrps = append(lrps, lrp)
 		case rep.TaskLifecycle:
 			domain := container.Tags[rep.DomainTag]
@@ -210,6 +346,7 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 			task := rep.NewTask(container.Guid, domain, resource, placementConstraint)
 			task.State = state
 			task.Failed = container.RunResult.Failed
+			task.Priority = containerPriority(container)
 			tasks = append(tasks, task)
 		}
 	}
@@ -219,13 +356,25 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 		allocatedProxyMemory = a.proxyMemoryAllocation
 	}
 
+	available := a.convertResources(availableResources)
+	total := a.convertResources(totalResources)
+	if a.cpuWeightCapacity > 0 {
+		total.CPUWeight = int32(a.cpuWeightCapacity)
+		available.CPUWeight = int32(a.cpuWeightCapacity - usedCPUWeight)
+	}
+	if a.pidCapacity > 0 {
+		total.MaxPids = int32(a.pidCapacity)
+		available.MaxPids = int32(a.pidCapacity - usedPids)
+	}
+	subtractReserved(&available, a.outstandingReservations(""))
+
 	state := rep.NewCellState(
 		a.cellID,
 		a.cellIndex,
 		a.repURL,
-		a.rootFSProviders,
-		a.convertResources(availableResources),
-		a.convertResources(totalResources),
+		rootFSProviders(stackPathMap, a.arbitraryRootFSes, a.rootFSProviders, a.preloadedVersionRanges),
+		available,
+		total,
 		lrps,
 		tasks,
 		a.zone,
@@ -236,21 +385,39 @@ func (a *AuctionCellRep) State(logger lager.Logger) (rep.CellState, bool, error)
 		a.optionalPlacementTags,
 		allocatedProxyMemory,
 	)
-
-	healthy := a.client.Healthy(logger)
-	if !healthy {
-		logger.Error("failed-garden-health-check", nil)
+	state.Scoring = a.scoring
+	state.PlacementLabels = a.placementLabels
+	state.DomainQuotas = a.domainQuotas
+	state.DomainUsage = domainUsage
+	if reporter, ok := a.stacks.(rep.StackHealthReporter); ok {
+		state.StackHealth = reporter.StackHealth()
+	}
+	state.WarmRootFSes, state.WarmCachedDependencies = a.warmResources()
+	for _, rootFS := range state.WarmRootFSes {
+		addCachedRootFS(cachedRootFSes, rootFS)
 	}
+	state.CachedRootFSes = sortedRootFSes(cachedRootFSes)
 
-	logger.Info("provided", lager.Data{
-		"available-resources": state.AvailableResources,
-		"total-resources":     state.TotalResources,
-		"num-lrps":            len(state.LRPs),
-		"zone":                state.Zone,
-		"evacuating":          state.Evacuating,
-	})
+	sort.Slice(state.LRPs, func(i, j int) bool { return state.LRPs[i].InstanceGUID < state.LRPs[j].InstanceGUID })
+	sort.Slice(state.Tasks, func(i, j int) bool { return state.Tasks[i].TaskGuid < state.Tasks[j].TaskGuid })
+	state.Generation = a.stateGeneration(state)
 
-	return state, healthy, nil
+	return state, nil
+}
+
+// stateGeneration returns the generation of the state, which is increased
+// whenever the state differs from the previously computed one. Generations
+// start at the time the rep was created so that they keep increasing across
+// restarts.
+func (a *AuctionCellRep) stateGeneration(state rep.CellState) uint64 {
+	a.generationLock.Lock()
+	defer a.generationLock.Unlock()
+
+	if a.lastState == nil || !reflect.DeepEqual(*a.lastState, state) {
+		a.generation++
+		a.lastState = &state
+	}
+	return a.generation
 }
 
 func (a *AuctionCellRep) Metrics(logger lager.Logger) (*rep.ContainerMetricsCollection, error) {
@@ -318,6 +485,21 @@ func (a *AuctionCellRep) Metrics(logger lager.Logger) (*rep.ContainerMetricsColl
 	}, nil
 }
 
+// containerCPUWeight returns the CPU weight of the container. Containers that
+// have not been run yet only carry the weight requested at allocation time in
+// their tags.
+func containerCPUWeight(container *executor.Container) int32 {
+	if container.CPUWeight > 0 {
+		return int32(container.CPUWeight)
+	}
+
+	weight, err := strconv.ParseInt(container.Tags[rep.CPUWeightTag], 10, 32)
+	if err != nil || weight < 0 {
+		return 0
+	}
+	return int32(weight)
+}
+
 func containerIsStarting(container *executor.Container) bool {
 	return container.State == executor.StateReserved ||
 		container.State == executor.StateInitializing ||
@@ -325,8 +507,6 @@ func containerIsStarting(container *executor.Container) bool {
 }
 
 func (a *AuctionCellRep) Perform(logger lager.Logger, work rep.Work) (rep.Work, error) {
-	var failedWork = rep.Work{}
-
 	logger = logger.Session("auction-work", lager.Data{
 		"lrp-starts": len(work.LRPs),
 		"tasks":      len(work.Tasks),
@@ -338,42 +518,89 @@ func (a *AuctionCellRep) Perform(logger lager.Logger, work rep.Work) (rep.Work,
 		return work, ErrCellIdMismatch
 	}
 
-	remainingResources, err := a.client.RemainingResources(logger)
+	a.reservationsLock.Lock()
+	defer a.reservationsLock.Unlock()
+
+	if work.ReservationID != "" && !a.takeReservation(work.ReservationID) {
+		logger.Info("reservation-not-found", lager.Data{"reservation-id": work.ReservationID})
+	}
+
+	if a.evacuationReporter.Evacuating() {
+		return failAllWork(work, evacuatingFailure()), nil
+	}
+
+	placement, err := a.placeWork(logger, work, "")
 	if err != nil {
-		logger.Error("failed-gathering-remaining-reosurces", err)
 		return work, err
 	}
 
-	var lrpRequests []rep.LRP
-	remainingMemory := int32(remainingResources.MemoryMB)
+	failedWork := placement.failed
+	failedWork.Evictions = placement.preemption.execute(logger)
+
+	failedWork.LRPs = append(failedWork.LRPs, a.allocator.BatchLRPAllocationRequest(logger, a.enableContainerProxy, a.proxyMemoryAllocation, placement.lrps)...)
+	failedWork.Tasks = append(failedWork.Tasks, a.allocator.BatchTaskAllocationRequest(logger, placement.tasks)...)
+
+	return failedWork, nil
+}
 
-	sort.SliceStable(work.LRPs, func(i, j int) bool {
-		return work.LRPs[i].MemoryMB > work.LRPs[j].MemoryMB
+// PerformDryRun places the work as Perform would, without evicting or
+// allocating anything. It returns the work that would fail, each item
+// annotated with the reason it would fail, and the evictions Perform would
+// make to place the rest. It does not change the cell's state generation.
+func (a *AuctionCellRep) PerformDryRun(logger lager.Logger, work rep.Work) (rep.Work, error) {
+	logger = logger.Session("auction-work-dry-run", lager.Data{
+		"lrp-starts": len(work.LRPs),
+		"tasks":      len(work.Tasks),
+		"cell-id":    work.CellID,
 	})
 
-	for _, lrp := range work.LRPs {
-		requiredMemory := lrp.MemoryMB
-		if a.enableContainerProxy {
-			requiredMemory += int32(a.proxyMemoryAllocation)
-		}
-		if requiredMemory <= remainingMemory {
-			remainingMemory -= requiredMemory
-			lrpRequests = append(lrpRequests, lrp)
-		} else {
-			failedWork.LRPs = append(failedWork.LRPs, lrp)
-		}
+	if work.CellID != "" && work.CellID != a.cellID {
+		logger.Error("cell-id-mismatch", ErrCellIdMismatch)
+		return work, ErrCellIdMismatch
 	}
 
 	if a.evacuationReporter.Evacuating() {
-		return work, nil
+		return failAllWork(work, evacuatingFailure()), nil
 	}
 
-	failedWork.LRPs = append(failedWork.LRPs, a.allocator.BatchLRPAllocationRequest(logger, a.enableContainerProxy, a.proxyMemoryAllocation, lrpRequests)...)
-	failedWork.Tasks = a.allocator.BatchTaskAllocationRequest(logger, work.Tasks)
+	a.reservationsLock.Lock()
+	placement, err := a.placeWork(logger, work, work.ReservationID)
+	a.reservationsLock.Unlock()
+	if err != nil {
+		return work, err
+	}
+
+	failedWork := placement.failed
+	for _, eviction := range placement.preemption.evictions {
+		failedWork.Evictions = append(failedWork.Evictions, *eviction)
+	}
+
+	logger.Info("simulated", lager.Data{
+		"failed-lrp-starts": len(failedWork.LRPs),
+		"failed-tasks":      len(failedWork.Tasks),
+		"evictions":         len(failedWork.Evictions),
+	})
 
 	return failedWork, nil
 }
 
+func evacuatingFailure() *rep.PlacementFailure {
+	return rep.NewPlacementFailure(rep.PlacementFailureReasonEvacuating, "cell is evacuating")
+}
+
+func failAllWork(work rep.Work, failure *rep.PlacementFailure) rep.Work {
+	failedWork := rep.Work{}
+	for _, lrp := range work.LRPs {
+		lrp.PlacementFailure = failure
+		failedWork.LRPs = append(failedWork.LRPs, lrp)
+	}
+	for _, task := range work.Tasks {
+		task.PlacementFailure = failure
+		failedWork.Tasks = append(failedWork.Tasks, task)
+	}
+	return failedWork
+}
+
 func (a *AuctionCellRep) convertResources(resources executor.ExecutorResources) rep.Resources {
 	return rep.Resources{
 		MemoryMB:   int32(resources.MemoryMB),
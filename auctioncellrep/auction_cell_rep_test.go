@@ -2,8 +2,10 @@ package auctioncellrep_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/executor/containermetrics"
 	fake_client "code.cloudfoundry.org/executor/fakes"
@@ -12,6 +14,7 @@ import (
 	"code.cloudfoundry.org/rep/auctioncellrep"
 	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	"code.cloudfoundry.org/rep/stacks"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -36,8 +39,17 @@ var _ = Describe("AuctionCellRep", func() {
 		commonErr      error
 
 		placementTags, optionalPlacementTags []string
+		placementLabels                      map[string]string
+		stackMap                             *stacks.Map
+		rootFSProviders                      rep.RootFSProviders
+		versionRanges                        map[string]string
 		enableContainerProxy                 bool
 		proxyMemoryAllocation                int
+		cpuWeightCapacity                    int
+		pidCapacity                          int
+		scoring                              rep.ScoringConfig
+		domainQuotas                         rep.DomainQuotas
+		fakeClock                            *fakeclock.FakeClock
 
 		fakeContainerAllocator *fakes.FakeBatchContainerAllocator
 	)
@@ -54,26 +66,44 @@ var _ = Describe("AuctionCellRep", func() {
 		commonErr = errors.New("Failed to fetch")
 		enableContainerProxy = false
 		proxyMemoryAllocation = 12
+		cpuWeightCapacity = 0
+		pidCapacity = 0
+		scoring = rep.ScoringConfig{}
+		domainQuotas = nil
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		stackMap = stacks.NewMap(rep.StackPathMap{linuxStack: linuxPath}, nil)
+		rootFSProviders = nil
+		versionRanges = nil
 		client.HealthyReturns(true)
 	})
 
 	JustBeforeEach(func() {
-		cellRep = auctioncellrep.New(
-			cellID,
-			cellIndex,
-			repURL,
-			rep.StackPathMap{linuxStack: linuxPath},
-			fakeContainerMetricsProvider,
-			[]string{"docker"},
-			"the-zone",
-			client,
-			evacuationReporter,
-			placementTags,
-			optionalPlacementTags,
-			proxyMemoryAllocation,
-			enableContainerProxy,
-			fakeContainerAllocator,
-		)
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   stackMap,
+			ContainerMetricsProvider: fakeContainerMetricsProvider,
+			ArbitraryRootFSes:        []string{"docker"},
+			RootFSProviders:          rootFSProviders,
+			PreloadedVersionRanges:   versionRanges,
+			Client:                   client,
+			EvacuationReporter:       evacuationReporter,
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			PlacementTags:            placementTags,
+			OptionalPlacementTags:    optionalPlacementTags,
+			PlacementLabels:          placementLabels,
+			ProxyMemoryAllocation:    proxyMemoryAllocation,
+			EnableContainerProxy:     enableContainerProxy,
+			CPUWeightCapacity:        cpuWeightCapacity,
+			PidCapacity:              pidCapacity,
+			Scoring:                  scoring,
+			DomainQuotas:             domainQuotas,
+			Clock:                    fakeClock,
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                fakeContainerAllocator,
+		})
 	})
 
 	Describe("Metrics", func() {
@@ -465,6 +495,96 @@ var _ = Describe("AuctionCellRep", func() {
 			})
 		})
 
+		Context("when a cpu weight capacity is configured", func() {
+			BeforeEach(func() {
+				cpuWeightCapacity = 400
+
+				running := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				running.CPUWeight = 100
+
+				reserved := createContainer(executor.StateReserved, rep.TaskLifecycle)
+				reserved.Guid = "some-other-container-guid"
+				reserved.Tags[rep.CPUWeightTag] = "50"
+
+				client.ListContainersReturns([]executor.Container{running, reserved}, nil)
+			})
+
+			It("reports the total and remaining cpu weight", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.TotalResources.CPUWeight).To(BeEquivalentTo(400))
+				Expect(state.AvailableResources.CPUWeight).To(BeEquivalentTo(250))
+			})
+
+			It("reports the cpu weight of each container", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.LRPs).To(HaveLen(1))
+				Expect(state.LRPs[0].CPUWeight).To(BeEquivalentTo(100))
+				Expect(state.Tasks).To(HaveLen(1))
+				Expect(state.Tasks[0].CPUWeight).To(BeEquivalentTo(50))
+			})
+		})
+
+		Context("when containers have a priority", func() {
+			BeforeEach(func() {
+				lrpContainer := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				lrpContainer.Tags[rep.PriorityTag] = "10"
+				taskContainer := createContainer(executor.StateRunning, rep.TaskLifecycle)
+				taskContainer.Guid = "some-other-container-guid"
+
+				client.ListContainersReturns([]executor.Container{lrpContainer, taskContainer}, nil)
+			})
+
+			It("reports the priority of each container", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.LRPs).To(HaveLen(1))
+				Expect(state.LRPs[0].Priority).To(BeEquivalentTo(10))
+				Expect(state.Tasks).To(HaveLen(1))
+				Expect(state.Tasks[0].Priority).To(BeEquivalentTo(0))
+			})
+		})
+
+		Context("when a pid capacity is configured", func() {
+			BeforeEach(func() {
+				pidCapacity = 1024
+
+				lrpContainer := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				taskContainer := createContainer(executor.StateRunning, rep.TaskLifecycle)
+				taskContainer.Guid = "some-other-container-guid"
+				taskContainer.MaxPids = 300
+
+				client.ListContainersReturns([]executor.Container{lrpContainer, taskContainer}, nil)
+			})
+
+			It("reports the total and remaining pid budget", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.TotalResources.MaxPids).To(BeEquivalentTo(1024))
+				Expect(state.AvailableResources.MaxPids).To(BeEquivalentTo(1024 - 100 - 300))
+			})
+		})
+
+		Context("when a scoring strategy is configured", func() {
+			BeforeEach(func() {
+				scoring = rep.ScoringConfig{
+					Strategy: rep.ScoringStrategySpread,
+					Weights:  &rep.ScoringWeights{MemoryMB: 1},
+				}
+			})
+
+			It("advertises the strategy in the state", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.Scoring).To(Equal(scoring))
+			})
+		})
+
 		Context("when the cell is not healthy", func() {
 			BeforeEach(func() {
 				client.HealthyReturns(false)
@@ -535,6 +655,168 @@ var _ = Describe("AuctionCellRep", func() {
 				Expect(state.OptionalPlacementTags).To(ConsistOf(optionalPlacementTags))
 			})
 		})
+
+		Context("when the stacks are swapped", func() {
+			It("advertises the new stacks", func() {
+				stackMap.Swap(rep.StackPathMap{linuxStack: linuxPath, "cflinuxfs4": "/data/rootfs/cflinuxfs4"})
+
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.RootFSProviders).To(Equal(rep.RootFSProviders{
+					models.PreloadedRootFSScheme:    rep.NewFixedSetRootFSProvider(linuxStack, "cflinuxfs4"),
+					models.PreloadedOCIRootFSScheme: rep.NewFixedSetRootFSProvider(linuxStack, "cflinuxfs4"),
+					"docker":                        rep.ArbitraryRootFSProvider{},
+				}))
+			})
+		})
+
+		Context("when rootfs providers are configured", func() {
+			BeforeEach(func() {
+				rootFSProviders = rep.RootFSProviders{
+					"docker": rep.PatternRootFSProvider{Glob: "registry.example.com/*"},
+					"oci":    rep.ArbitraryRootFSProvider{},
+				}
+			})
+
+			It("advertises them, replacing the default provider for their scheme", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.RootFSProviders).To(Equal(rep.RootFSProviders{
+					models.PreloadedRootFSScheme:    rep.NewFixedSetRootFSProvider(linuxStack),
+					models.PreloadedOCIRootFSScheme: rep.NewFixedSetRootFSProvider(linuxStack),
+					"docker":                        rep.PatternRootFSProvider{Glob: "registry.example.com/*"},
+					"oci":                           rep.ArbitraryRootFSProvider{},
+				}))
+			})
+		})
+
+		Context("when version ranges are configured for the preloaded stacks", func() {
+			BeforeEach(func() {
+				stackMap = stacks.NewMap(rep.StackPathMap{linuxStack: linuxPath, "cflinuxfs4": "/data/rootfs/cflinuxfs4"}, nil)
+				versionRanges = map[string]string{"cflinuxfs4": ">=1.50"}
+			})
+
+			It("advertises the stacks as a versioned set", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				versionedSet := rep.VersionedSetRootFSProvider{VersionedSet: map[string]string{
+					linuxStack:   "",
+					"cflinuxfs4": ">=1.50",
+				}}
+				Expect(state.RootFSProviders[models.PreloadedRootFSScheme]).To(Equal(versionedSet))
+				Expect(state.RootFSProviders[models.PreloadedOCIRootFSScheme]).To(Equal(versionedSet))
+			})
+
+			It("places work that names a version of a stack", func() {
+				task := rep.NewTask(
+					"task-guid",
+					"domain",
+					rep.NewResource(10, 10, 0),
+					rep.NewPlacementConstraint(models.PreloadedRootFS("cflinuxfs4@1.52.0"), nil, nil),
+				)
+				client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 1024, DiskMB: 1024, Containers: 10}, nil)
+
+				failedWork, err := cellRep.PerformDryRun(logger, rep.Work{Tasks: []rep.Task{task}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.Tasks).To(BeEmpty())
+			})
+		})
+
+		Context("when the stacks are verified", func() {
+			BeforeEach(func() {
+				stackMap = stacks.NewMap(rep.StackPathMap{linuxStack: linuxPath, "cflinuxfs4": "/missing"}, func(path string) error {
+					if path == "/missing" {
+						return errors.New("no such file or directory")
+					}
+					return nil
+				})
+			})
+
+			It("reports the health of each stack and only advertises the healthy stacks", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.StackHealth).To(Equal(map[string]rep.StackHealth{
+					linuxStack:   {Path: linuxPath, Healthy: true},
+					"cflinuxfs4": {Path: "/missing", Healthy: false, Error: "no such file or directory"},
+				}))
+				Expect(state.RootFSProviders[models.PreloadedRootFSScheme]).To(Equal(rep.NewFixedSetRootFSProvider(linuxStack)))
+			})
+		})
+
+		Context("when container images are cached on the cell", func() {
+			BeforeEach(func() {
+				running := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				running.RootFSPath = "docker:///cloudfoundry/grace"
+				completed := createContainer(executor.StateCompleted, rep.TaskLifecycle)
+				completed.RootFSPath = "docker:///cloudfoundry/lattice-app"
+				initializing := createContainer(executor.StateInitializing, rep.LRPLifecycle)
+				initializing.RootFSPath = "docker:///cloudfoundry/still-pulling"
+				preloaded := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				preloaded.RootFSPath = linuxPath
+				duplicate := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				duplicate.RootFSPath = "docker:///cloudfoundry/grace"
+
+				client.ListContainersReturns([]executor.Container{running, completed, initializing, preloaded, duplicate}, nil)
+			})
+
+			It("reports the rootfses of containers whose images have been fetched", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.CachedRootFSes).To(Equal([]string{
+					"docker:///cloudfoundry/grace",
+					"docker:///cloudfoundry/lattice-app",
+				}))
+			})
+		})
+
+		Describe("generation", func() {
+			It("only increases when the state changes", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				generation := state.Generation
+				Expect(generation).NotTo(BeZero())
+
+				state, _, err = cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.Generation).To(Equal(generation))
+
+				client.ListContainersReturns([]executor.Container{createContainer(executor.StateRunning, rep.LRPLifecycle)}, nil)
+				state, _, err = cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.Generation).To(BeNumerically(">", generation))
+			})
+
+			It("does not change when containers are listed in a different order", func() {
+				first := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				second := createContainer(executor.StateRunning, rep.LRPLifecycle)
+				second.Guid = "other-container-guid"
+				second.Tags[rep.InstanceGuidTag] = "other-instance-guid"
+
+				client.ListContainersReturns([]executor.Container{first, second}, nil)
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				generation := state.Generation
+
+				client.ListContainersReturns([]executor.Container{second, first}, nil)
+				state, _, err = cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.Generation).To(Equal(generation))
+			})
+		})
+
+		Context("when placement labels have been set", func() {
+			BeforeEach(func() {
+				placementLabels = map[string]string{"tier": "edge"}
+			})
+
+			It("returns the labels as part of the state", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.PlacementLabels).To(Equal(placementLabels))
+			})
+		})
 	})
 
 	Describe("Perform", func() {
@@ -590,7 +872,7 @@ var _ = Describe("AuctionCellRep", func() {
 		})
 
 		JustBeforeEach(func() {
-			client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: remainingCellMemory}, nil)
+			client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: remainingCellMemory, DiskMB: 8192, Containers: 10}, nil)
 			lrpAuctions = []rep.LRP{lrpAuctionOne, lrpAuctionTwo, lrpAuctionThree}
 		})
 
@@ -649,14 +931,35 @@ var _ = Describe("AuctionCellRep", func() {
 				}
 			})
 
-			It("returns all work it was given", func() {
-				Expect(cellRep.Perform(logger, work)).To(Equal(work))
+			It("returns all work it was given, marked as failed because the cell is evacuating", func() {
+				failedWork, err := cellRep.Perform(logger, work)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(failedWork.LRPs).To(HaveLen(1))
+				Expect(failedWork.LRPs[0].InstanceGUID).To(Equal(work.LRPs[0].InstanceGUID))
+				Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonEvacuating))
+
+				Expect(failedWork.Tasks).To(HaveLen(1))
+				Expect(failedWork.Tasks[0].TaskGuid).To(Equal(work.Tasks[0].TaskGuid))
+				Expect(failedWork.Tasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonEvacuating))
+			})
+
+			It("does not allocate any containers", func() {
+				_, err := cellRep.Perform(logger, work)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(0))
+				Expect(fakeContainerAllocator.BatchTaskAllocationRequestCallCount()).To(Equal(0))
 			})
 		})
 
 		Context("when the cell only has enough resources to run a subset of the workloads", func() {
 			var smallestLRP, middleLRP, largestLRP rep.LRP
 
+			withInsufficientResources := func(lrp rep.LRP) rep.LRP {
+				lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: memory")
+				return lrp
+			}
+
 			BeforeEach(func() {
 				remainingCellMemory = 8192
 				largestLRP = rep.LRP{Resource: rep.Resource{MemoryMB: 6144}}
@@ -671,7 +974,7 @@ var _ = Describe("AuctionCellRep", func() {
 				})
 
 				Expect(err).NotTo(HaveOccurred())
-				Expect(failedWork.LRPs).To(ConsistOf(smallestLRP))
+				Expect(failedWork.LRPs).To(ConsistOf(withInsufficientResources(smallestLRP)))
 
 				Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(1))
 
@@ -694,7 +997,7 @@ var _ = Describe("AuctionCellRep", func() {
 					})
 
 					Expect(err).NotTo(HaveOccurred())
-					Expect(failedWork.LRPs).To(ConsistOf(smallestLRP, middleLRP))
+					Expect(failedWork.LRPs).To(ConsistOf(withInsufficientResources(smallestLRP), withInsufficientResources(middleLRP)))
 
 					Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(1))
 
@@ -706,6 +1009,56 @@ var _ = Describe("AuctionCellRep", func() {
 			})
 		})
 
+		Context("when the rootfs names a version outside the stack's range", func() {
+			var supportedLRP, unsupportedLRP rep.LRP
+			var unsupportedTask rep.Task
+
+			BeforeEach(func() {
+				versionRanges = map[string]string{linuxStack: ">=1.50, <2"}
+
+				supportedLRP = rep.NewLRP(
+					"ig-1",
+					models.NewActualLRPKey("process-guid", 0, "tests"),
+					rep.NewResource(10, 10, 10),
+					rep.NewPlacementConstraint(linuxRootFSURL+"@1.52.0", nil, nil),
+				)
+				unsupportedLRP = rep.NewLRP(
+					"ig-2",
+					models.NewActualLRPKey("process-guid", 1, "tests"),
+					rep.NewResource(10, 10, 10),
+					rep.NewPlacementConstraint(linuxRootFSURL+"@2.0.0", nil, nil),
+				)
+				unsupportedTask = rep.NewTask(
+					"the-task-guid",
+					"tests",
+					rep.NewResource(10, 10, 10),
+					rep.NewPlacementConstraint(linuxRootFSURL+"@1.50.0-rc.1", nil, nil),
+				)
+			})
+
+			It("fails the work without allocating it", func() {
+				failedWork, err := cellRep.Perform(logger, rep.Work{
+					LRPs:  []rep.LRP{supportedLRP, unsupportedLRP},
+					Tasks: []rep.Task{unsupportedTask},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(failedWork.LRPs).To(HaveLen(1))
+				Expect(failedWork.LRPs[0].InstanceGUID).To(Equal(unsupportedLRP.InstanceGUID))
+				Expect(failedWork.LRPs[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(
+					rep.PlacementFailureReasonRootFSNotFound,
+					rep.ErrPreloadedRootFSVersionUnsupported.Error(),
+				)))
+				Expect(failedWork.Tasks).To(HaveLen(1))
+				Expect(failedWork.Tasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
+
+				Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(1))
+				_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+				Expect(lrpRequests).To(ConsistOf(supportedLRP))
+				Expect(fakeContainerAllocator.BatchTaskAllocationRequestCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the workload's cell ID does not match the cell's ID", func() {
 			It("rejects the workload", func() {
 				_, err := cellRep.Perform(logger, rep.Work{
@@ -716,6 +1069,133 @@ var _ = Describe("AuctionCellRep", func() {
 			})
 		})
 	})
+
+	Describe("PerformDryRun", func() {
+		var (
+			work      rep.Work
+			failed    rep.Work
+			dryRunErr error
+
+			bigLRP, smallLRP rep.LRP
+			task             rep.Task
+		)
+
+		BeforeEach(func() {
+			client.TotalResourcesReturns(executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 10}, nil)
+			client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 512, DiskMB: 1024, Containers: 5}, nil)
+
+			bigLRP = rep.NewLRP(
+				"ig-1",
+				models.NewActualLRPKey("process-guid", 0, "domain"),
+				rep.NewResource(400, 10, 0),
+				rep.NewPlacementConstraint(linuxRootFSURL, nil, nil),
+			)
+			smallLRP = rep.NewLRP(
+				"ig-2",
+				models.NewActualLRPKey("process-guid", 1, "domain"),
+				rep.NewResource(200, 10, 0),
+				rep.NewPlacementConstraint(linuxRootFSURL, nil, nil),
+			)
+			task = rep.NewTask(
+				"task-guid",
+				"domain",
+				rep.NewResource(10, 10, 0),
+				rep.NewPlacementConstraint(models.PreloadedRootFS("not-on-cell"), nil, nil),
+			)
+
+			work = rep.Work{
+				LRPs:  []rep.LRP{smallLRP, bigLRP},
+				Tasks: []rep.Task{task},
+			}
+		})
+
+		JustBeforeEach(func() {
+			failed, dryRunErr = cellRep.PerformDryRun(logger, work)
+		})
+
+		It("never allocates containers", func() {
+			Expect(dryRunErr).NotTo(HaveOccurred())
+			Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(0))
+			Expect(fakeContainerAllocator.BatchTaskAllocationRequestCallCount()).To(Equal(0))
+			Expect(client.AllocateContainersCallCount()).To(Equal(0))
+		})
+
+		It("places the largest LRPs first and reports the ones that would not fit", func() {
+			Expect(dryRunErr).NotTo(HaveOccurred())
+			Expect(failed.LRPs).To(HaveLen(1))
+			Expect(failed.LRPs[0].InstanceGUID).To(Equal(smallLRP.InstanceGUID))
+			Expect(failed.LRPs[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(
+				rep.PlacementFailureReasonInsufficientResources,
+				"insufficient resources: memory",
+			)))
+		})
+
+		It("reports work whose rootfs cannot be resolved", func() {
+			Expect(dryRunErr).NotTo(HaveOccurred())
+			Expect(failed.Tasks).To(HaveLen(1))
+			Expect(failed.Tasks[0].TaskGuid).To(Equal(task.TaskGuid))
+			Expect(failed.Tasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
+		})
+
+		It("does not modify the requested work", func() {
+			Expect(work.LRPs).To(Equal([]rep.LRP{smallLRP, bigLRP}))
+		})
+
+		It("reads the remaining resources without computing the cell state", func() {
+			Expect(client.RemainingResourcesCallCount()).To(Equal(1))
+			Expect(client.TotalResourcesCallCount()).To(Equal(0))
+			Expect(client.ListContainersCallCount()).To(Equal(0))
+		})
+
+		Context("when envoy needs to be placed in the container", func() {
+			BeforeEach(func() {
+				enableContainerProxy = true
+				proxyMemoryAllocation = 200
+			})
+
+			It("accounts for the proxy overhead", func() {
+				Expect(dryRunErr).NotTo(HaveOccurred())
+				Expect(failed.LRPs).To(HaveLen(1))
+				Expect(failed.LRPs[0].InstanceGUID).To(Equal(bigLRP.InstanceGUID))
+			})
+		})
+
+		Context("when evacuating", func() {
+			BeforeEach(func() {
+				evacuationReporter.EvacuatingReturns(true)
+			})
+
+			It("reports all work as failing", func() {
+				Expect(dryRunErr).NotTo(HaveOccurred())
+				Expect(failed.LRPs).To(HaveLen(2))
+				Expect(failed.Tasks).To(HaveLen(1))
+				for _, lrp := range failed.LRPs {
+					Expect(lrp.PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonEvacuating))
+				}
+				Expect(failed.Tasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonEvacuating))
+			})
+		})
+
+		Context("when the workload's cell ID does not match the cell's ID", func() {
+			BeforeEach(func() {
+				work.CellID = "do-not-want-your-work"
+			})
+
+			It("rejects the workload", func() {
+				Expect(dryRunErr).To(MatchError(auctioncellrep.ErrCellIdMismatch))
+			})
+		})
+
+		Context("when the cell state cannot be fetched", func() {
+			BeforeEach(func() {
+				client.RemainingResourcesReturns(executor.ExecutorResources{}, commonErr)
+			})
+
+			It("returns the error", func() {
+				Expect(dryRunErr).To(MatchError(commonErr))
+			})
+		})
+	})
 })
 
 func createContainer(state executor.State, lifecycle string) executor.Container {
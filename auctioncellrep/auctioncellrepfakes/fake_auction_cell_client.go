@@ -10,6 +10,35 @@ import (
 )
 
 type FakeAuctionCellClient struct {
+	ContainerDetailStub        func(lager.Logger, string) (rep.ContainerDetail, error)
+	containerDetailMutex       sync.RWMutex
+	containerDetailArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	containerDetailReturns struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
+	containerDetailReturnsOnCall map[int]struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
+	ContainerDiagnosticsStub        func(lager.Logger, string, int) (rep.ContainerDiagnostics, error)
+	containerDiagnosticsMutex       sync.RWMutex
+	containerDiagnosticsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}
+	containerDiagnosticsReturns struct {
+		result1 rep.ContainerDiagnostics
+		result2 error
+	}
+	containerDiagnosticsReturnsOnCall map[int]struct {
+		result1 rep.ContainerDiagnostics
+		result2 error
+	}
 	PerformStub        func(lager.Logger, rep.Work) (rep.Work, error)
 	performMutex       sync.RWMutex
 	performArgsForCall []struct {
@@ -24,6 +53,46 @@ type FakeAuctionCellClient struct {
 		result1 rep.Work
 		result2 error
 	}
+	PerformDryRunStub        func(lager.Logger, rep.Work) (rep.Work, error)
+	performDryRunMutex       sync.RWMutex
+	performDryRunArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.Work
+	}
+	performDryRunReturns struct {
+		result1 rep.Work
+		result2 error
+	}
+	performDryRunReturnsOnCall map[int]struct {
+		result1 rep.Work
+		result2 error
+	}
+	PrewarmStub        func(lager.Logger, rep.PrewarmRequest) error
+	prewarmMutex       sync.RWMutex
+	prewarmArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.PrewarmRequest
+	}
+	prewarmReturns struct {
+		result1 error
+	}
+	prewarmReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReserveStub        func(lager.Logger, rep.ReservationRequest) (rep.Reservation, error)
+	reserveMutex       sync.RWMutex
+	reserveArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.ReservationRequest
+	}
+	reserveReturns struct {
+		result1 rep.Reservation
+		result2 error
+	}
+	reserveReturnsOnCall map[int]struct {
+		result1 rep.Reservation
+		result2 error
+	}
 	ResetStub        func() error
 	resetMutex       sync.RWMutex
 	resetArgsForCall []struct {
@@ -49,10 +118,155 @@ type FakeAuctionCellClient struct {
 		result2 bool
 		result3 error
 	}
+	StreamStateStub        func(lager.Logger, <-chan struct{}) (<-chan rep.StateEvent, error)
+	streamStateMutex       sync.RWMutex
+	streamStateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 <-chan struct{}
+	}
+	streamStateReturns struct {
+		result1 <-chan rep.StateEvent
+		result2 error
+	}
+	streamStateReturnsOnCall map[int]struct {
+		result1 <-chan rep.StateEvent
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeAuctionCellClient) ContainerDetail(arg1 lager.Logger, arg2 string) (rep.ContainerDetail, error) {
+	fake.containerDetailMutex.Lock()
+	ret, specificReturn := fake.containerDetailReturnsOnCall[len(fake.containerDetailArgsForCall)]
+	fake.containerDetailArgsForCall = append(fake.containerDetailArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ContainerDetail", []interface{}{arg1, arg2})
+	containerDetailStubCopy := fake.ContainerDetailStub
+	fake.containerDetailMutex.Unlock()
+	if containerDetailStubCopy != nil {
+		return containerDetailStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containerDetailReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuctionCellClient) ContainerDetailCallCount() int {
+	fake.containerDetailMutex.RLock()
+	defer fake.containerDetailMutex.RUnlock()
+	return len(fake.containerDetailArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) ContainerDetailCalls(stub func(lager.Logger, string) (rep.ContainerDetail, error)) {
+	fake.containerDetailMutex.Lock()
+	defer fake.containerDetailMutex.Unlock()
+	fake.ContainerDetailStub = stub
+}
+
+func (fake *FakeAuctionCellClient) ContainerDetailArgsForCall(i int) (lager.Logger, string) {
+	fake.containerDetailMutex.RLock()
+	defer fake.containerDetailMutex.RUnlock()
+	argsForCall := fake.containerDetailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuctionCellClient) ContainerDetailReturns(result1 rep.ContainerDetail, result2 error) {
+	fake.containerDetailMutex.Lock()
+	defer fake.containerDetailMutex.Unlock()
+	fake.ContainerDetailStub = nil
+	fake.containerDetailReturns = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) ContainerDetailReturnsOnCall(i int, result1 rep.ContainerDetail, result2 error) {
+	fake.containerDetailMutex.Lock()
+	defer fake.containerDetailMutex.Unlock()
+	fake.ContainerDetailStub = nil
+	if fake.containerDetailReturnsOnCall == nil {
+		fake.containerDetailReturnsOnCall = make(map[int]struct {
+			result1 rep.ContainerDetail
+			result2 error
+		})
+	}
+	fake.containerDetailReturnsOnCall[i] = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnostics(arg1 lager.Logger, arg2 string, arg3 int) (rep.ContainerDiagnostics, error) {
+	fake.containerDiagnosticsMutex.Lock()
+	ret, specificReturn := fake.containerDiagnosticsReturnsOnCall[len(fake.containerDiagnosticsArgsForCall)]
+	fake.containerDiagnosticsArgsForCall = append(fake.containerDiagnosticsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ContainerDiagnostics", []interface{}{arg1, arg2, arg3})
+	containerDiagnosticsStubCopy := fake.ContainerDiagnosticsStub
+	fake.containerDiagnosticsMutex.Unlock()
+	if containerDiagnosticsStubCopy != nil {
+		return containerDiagnosticsStubCopy(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containerDiagnosticsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnosticsCallCount() int {
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
+	return len(fake.containerDiagnosticsArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnosticsCalls(stub func(lager.Logger, string, int) (rep.ContainerDiagnostics, error)) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = stub
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnosticsArgsForCall(i int) (lager.Logger, string, int) {
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
+	argsForCall := fake.containerDiagnosticsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnosticsReturns(result1 rep.ContainerDiagnostics, result2 error) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = nil
+	fake.containerDiagnosticsReturns = struct {
+		result1 rep.ContainerDiagnostics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) ContainerDiagnosticsReturnsOnCall(i int, result1 rep.ContainerDiagnostics, result2 error) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = nil
+	if fake.containerDiagnosticsReturnsOnCall == nil {
+		fake.containerDiagnosticsReturnsOnCall = make(map[int]struct {
+			result1 rep.ContainerDiagnostics
+			result2 error
+		})
+	}
+	fake.containerDiagnosticsReturnsOnCall[i] = struct {
+		result1 rep.ContainerDiagnostics
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAuctionCellClient) Perform(arg1 lager.Logger, arg2 rep.Work) (rep.Work, error) {
 	fake.performMutex.Lock()
 	ret, specificReturn := fake.performReturnsOnCall[len(fake.performArgsForCall)]
@@ -118,6 +332,198 @@ func (fake *FakeAuctionCellClient) PerformReturnsOnCall(i int, result1 rep.Work,
 	}{result1, result2}
 }
 
+func (fake *FakeAuctionCellClient) PerformDryRun(arg1 lager.Logger, arg2 rep.Work) (rep.Work, error) {
+	fake.performDryRunMutex.Lock()
+	ret, specificReturn := fake.performDryRunReturnsOnCall[len(fake.performDryRunArgsForCall)]
+	fake.performDryRunArgsForCall = append(fake.performDryRunArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.Work
+	}{arg1, arg2})
+	fake.recordInvocation("PerformDryRun", []interface{}{arg1, arg2})
+	performDryRunStubCopy := fake.PerformDryRunStub
+	fake.performDryRunMutex.Unlock()
+	if performDryRunStubCopy != nil {
+		return performDryRunStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.performDryRunReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuctionCellClient) PerformDryRunCallCount() int {
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	return len(fake.performDryRunArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) PerformDryRunCalls(stub func(lager.Logger, rep.Work) (rep.Work, error)) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = stub
+}
+
+func (fake *FakeAuctionCellClient) PerformDryRunArgsForCall(i int) (lager.Logger, rep.Work) {
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	argsForCall := fake.performDryRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuctionCellClient) PerformDryRunReturns(result1 rep.Work, result2 error) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = nil
+	fake.performDryRunReturns = struct {
+		result1 rep.Work
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) PerformDryRunReturnsOnCall(i int, result1 rep.Work, result2 error) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = nil
+	if fake.performDryRunReturnsOnCall == nil {
+		fake.performDryRunReturnsOnCall = make(map[int]struct {
+			result1 rep.Work
+			result2 error
+		})
+	}
+	fake.performDryRunReturnsOnCall[i] = struct {
+		result1 rep.Work
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) Prewarm(arg1 lager.Logger, arg2 rep.PrewarmRequest) error {
+	fake.prewarmMutex.Lock()
+	ret, specificReturn := fake.prewarmReturnsOnCall[len(fake.prewarmArgsForCall)]
+	fake.prewarmArgsForCall = append(fake.prewarmArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.PrewarmRequest
+	}{arg1, arg2})
+	fake.recordInvocation("Prewarm", []interface{}{arg1, arg2})
+	prewarmStubCopy := fake.PrewarmStub
+	fake.prewarmMutex.Unlock()
+	if prewarmStubCopy != nil {
+		return prewarmStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.prewarmReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuctionCellClient) PrewarmCallCount() int {
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	return len(fake.prewarmArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) PrewarmCalls(stub func(lager.Logger, rep.PrewarmRequest) error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = stub
+}
+
+func (fake *FakeAuctionCellClient) PrewarmArgsForCall(i int) (lager.Logger, rep.PrewarmRequest) {
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	argsForCall := fake.prewarmArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuctionCellClient) PrewarmReturns(result1 error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = nil
+	fake.prewarmReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuctionCellClient) PrewarmReturnsOnCall(i int, result1 error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = nil
+	if fake.prewarmReturnsOnCall == nil {
+		fake.prewarmReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.prewarmReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuctionCellClient) Reserve(arg1 lager.Logger, arg2 rep.ReservationRequest) (rep.Reservation, error) {
+	fake.reserveMutex.Lock()
+	ret, specificReturn := fake.reserveReturnsOnCall[len(fake.reserveArgsForCall)]
+	fake.reserveArgsForCall = append(fake.reserveArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.ReservationRequest
+	}{arg1, arg2})
+	fake.recordInvocation("Reserve", []interface{}{arg1, arg2})
+	reserveStubCopy := fake.ReserveStub
+	fake.reserveMutex.Unlock()
+	if reserveStubCopy != nil {
+		return reserveStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reserveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuctionCellClient) ReserveCallCount() int {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	return len(fake.reserveArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) ReserveCalls(stub func(lager.Logger, rep.ReservationRequest) (rep.Reservation, error)) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = stub
+}
+
+func (fake *FakeAuctionCellClient) ReserveArgsForCall(i int) (lager.Logger, rep.ReservationRequest) {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	argsForCall := fake.reserveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuctionCellClient) ReserveReturns(result1 rep.Reservation, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	fake.reserveReturns = struct {
+		result1 rep.Reservation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) ReserveReturnsOnCall(i int, result1 rep.Reservation, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	if fake.reserveReturnsOnCall == nil {
+		fake.reserveReturnsOnCall = make(map[int]struct {
+			result1 rep.Reservation
+			result2 error
+		})
+	}
+	fake.reserveReturnsOnCall[i] = struct {
+		result1 rep.Reservation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAuctionCellClient) Reset() error {
 	fake.resetMutex.Lock()
 	ret, specificReturn := fake.resetReturnsOnCall[len(fake.resetArgsForCall)]
@@ -238,15 +644,92 @@ func (fake *FakeAuctionCellClient) StateReturnsOnCall(i int, result1 rep.CellSta
 	}{result1, result2, result3}
 }
 
+func (fake *FakeAuctionCellClient) StreamState(arg1 lager.Logger, arg2 <-chan struct{}) (<-chan rep.StateEvent, error) {
+	fake.streamStateMutex.Lock()
+	ret, specificReturn := fake.streamStateReturnsOnCall[len(fake.streamStateArgsForCall)]
+	fake.streamStateArgsForCall = append(fake.streamStateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 <-chan struct{}
+	}{arg1, arg2})
+	fake.recordInvocation("StreamState", []interface{}{arg1, arg2})
+	streamStateStubCopy := fake.StreamStateStub
+	fake.streamStateMutex.Unlock()
+	if streamStateStubCopy != nil {
+		return streamStateStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.streamStateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuctionCellClient) StreamStateCallCount() int {
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
+	return len(fake.streamStateArgsForCall)
+}
+
+func (fake *FakeAuctionCellClient) StreamStateCalls(stub func(lager.Logger, <-chan struct{}) (<-chan rep.StateEvent, error)) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = stub
+}
+
+func (fake *FakeAuctionCellClient) StreamStateArgsForCall(i int) (lager.Logger, <-chan struct{}) {
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
+	argsForCall := fake.streamStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuctionCellClient) StreamStateReturns(result1 <-chan rep.StateEvent, result2 error) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = nil
+	fake.streamStateReturns = struct {
+		result1 <-chan rep.StateEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuctionCellClient) StreamStateReturnsOnCall(i int, result1 <-chan rep.StateEvent, result2 error) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = nil
+	if fake.streamStateReturnsOnCall == nil {
+		fake.streamStateReturnsOnCall = make(map[int]struct {
+			result1 <-chan rep.StateEvent
+			result2 error
+		})
+	}
+	fake.streamStateReturnsOnCall[i] = struct {
+		result1 <-chan rep.StateEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAuctionCellClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.containerDetailMutex.RLock()
+	defer fake.containerDetailMutex.RUnlock()
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
 	fake.performMutex.RLock()
 	defer fake.performMutex.RUnlock()
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
 	fake.resetMutex.RLock()
 	defer fake.resetMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -17,18 +17,27 @@ type BatchContainerAllocator interface {
 
 type containerAllocator struct {
 	generateInstanceGuid func() (string, error)
-	stackPathMap         rep.StackPathMap
+	stacks               rep.StackSource
 	executorClient       executor.Client
 }
 
-func NewContainerAllocator(instanceGuidGenerator func() (string, error), stackPathMap rep.StackPathMap, executorClient executor.Client) BatchContainerAllocator {
+func NewContainerAllocator(instanceGuidGenerator func() (string, error), stacks rep.StackSource, executorClient executor.Client) BatchContainerAllocator {
 	return containerAllocator{
 		generateInstanceGuid: instanceGuidGenerator,
-		stackPathMap:         stackPathMap,
+		stacks:               stacks,
 		executorClient:       executorClient,
 	}
 }
 
+// diskMB returns the disk to allocate for a container on the rootfs at path.
+// A disk of 0 is unlimited, so it is never increased.
+func (ca containerAllocator) diskMB(diskMB int, rootFSPath string) int {
+	if reporter, ok := ca.stacks.(rep.StackDiskReporter); ok && diskMB > 0 {
+		diskMB += reporter.StackDiskMB(rootFSPath)
+	}
+	return diskMB
+}
+
 func buildLRPTags(lrp rep.LRP, instanceGuid string) executor.Tags {
 	tags := executor.Tags{}
 	tags[rep.DomainTag] = lrp.Domain
@@ -42,6 +51,14 @@ func buildLRPTags(lrp rep.LRP, instanceGuid string) executor.Tags {
 	tags[rep.PlacementTagsTag] = string(placementTags)
 	tags[rep.VolumeDriversTag] = string(volumeDrivers)
 
+	if lrp.CPUWeight > 0 {
+		tags[rep.CPUWeightTag] = strconv.Itoa(int(lrp.CPUWeight))
+	}
+
+	if lrp.Priority != 0 {
+		tags[rep.PriorityTag] = strconv.Itoa(int(lrp.Priority))
+	}
+
 	return tags
 }
 
@@ -54,6 +71,14 @@ func buildTaskTags(task rep.Task) executor.Tags {
 	volumeDrivers, _ := json.Marshal(task.PlacementConstraint.VolumeDrivers)
 	tags[rep.PlacementTagsTag] = string(placementTags)
 	tags[rep.VolumeDriversTag] = string(volumeDrivers)
+
+	if task.CPUWeight > 0 {
+		tags[rep.CPUWeightTag] = strconv.Itoa(int(task.CPUWeight))
+	}
+
+	if task.Priority != 0 {
+		tags[rep.PriorityTag] = strconv.Itoa(int(task.Priority))
+	}
 	return tags
 }
 
@@ -65,12 +90,14 @@ func (ca containerAllocator) BatchLRPAllocationRequest(logger lager.Logger, enab
 	for _, lrp := range lrps {
 		instanceGuid, err := ca.generateInstanceGuid()
 		if err != nil {
+			lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonInstanceGuidGenerationFailed, err.Error())
 			unallocatedLRPs = append(unallocatedLRPs, lrp)
 			continue
 		}
 
-		_, err = ca.stackPathMap.PathForRootFS(lrp.RootFs)
+		rootFSPath, err := ca.stacks.Stacks().PathForRootFS(lrp.RootFs)
 		if err != nil {
+			lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonRootFSNotFound, err.Error())
 			unallocatedLRPs = append(unallocatedLRPs, lrp)
 			continue
 		}
@@ -80,7 +107,7 @@ func (ca containerAllocator) BatchLRPAllocationRequest(logger lager.Logger, enab
 			memoryMB += proxyMemoryAllocation
 		}
 
-		resource := executor.NewResource(memoryMB, int(lrp.DiskMB), int(lrp.MaxPids))
+		resource := executor.NewResource(memoryMB, ca.diskMB(int(lrp.DiskMB), rootFSPath), int(lrp.MaxPids))
 		containerGuid := rep.LRPContainerGuid(lrp.ProcessGuid, instanceGuid)
 
 		lrpGuidMap[containerGuid] = lrp
@@ -102,6 +129,7 @@ func (ca containerAllocator) BatchLRPAllocationRequest(logger lager.Logger, enab
 	for _, failure := range failures {
 		logger.Error("container-allocation-failure", &failure, lager.Data{"failed-request": failure.AllocationRequest})
 		if lrp, found := lrpGuidMap[failure.Guid]; found {
+			lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, failure.Error())
 			unallocatedLRPs = append(unallocatedLRPs, lrp)
 		}
 	}
@@ -118,14 +146,15 @@ func (ca containerAllocator) BatchTaskAllocationRequest(logger lager.Logger, tas
 
 	for _, task := range tasks {
 		taskMap[task.TaskGuid] = task
-		_, err := ca.stackPathMap.PathForRootFS(task.RootFs)
+		rootFSPath, err := ca.stacks.Stacks().PathForRootFS(task.RootFs)
 		if err != nil {
+			task.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonRootFSNotFound, err.Error())
 			failedTasks = append(failedTasks, task)
 			continue
 		}
 
 		tags := buildTaskTags(task)
-		resource := executor.NewResource(int(task.MemoryMB), int(task.DiskMB), int(task.MaxPids))
+		resource := executor.NewResource(int(task.MemoryMB), ca.diskMB(int(task.DiskMB), rootFSPath), int(task.MaxPids))
 		requests = append(requests, executor.NewAllocationRequest(task.TaskGuid, &resource, tags))
 	}
 
@@ -143,6 +172,7 @@ func (ca containerAllocator) BatchTaskAllocationRequest(logger lager.Logger, tas
 	for _, failure := range failures {
 		logger.Error("container-allocation-failure", &failure, lager.Data{"failed-request": failure.AllocationRequest})
 		if task, found := taskMap[failure.Guid]; found {
+			task.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, failure.Error())
 			unallocatedTasks = append(unallocatedTasks, task)
 		}
 	}
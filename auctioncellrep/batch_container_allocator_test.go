@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/auctioncellrep"
+	"code.cloudfoundry.org/rep/stacks"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -26,6 +27,7 @@ var _ = Describe("ContainerAllocator", func() {
 		fakeGenerateContainerGuid func() (string, error)
 		logger                    *lagertest.TestLogger
 		commonErr                 error
+		stackSource               rep.StackSource
 
 		allocator auctioncellrep.BatchContainerAllocator
 	)
@@ -37,6 +39,7 @@ var _ = Describe("ContainerAllocator", func() {
 		proxyMemoryAllocation = 12
 		executorClient = new(fake_client.FakeClient)
 		commonErr = errors.New("Failed to fetch")
+		stackSource = rep.StackPathMap{linuxStack: linuxPath}
 
 		fakeGenerateContainerGuidCallCount := 0
 		fakeGenerateContainerGuid = func() (string, error) {
@@ -48,7 +51,7 @@ var _ = Describe("ContainerAllocator", func() {
 	JustBeforeEach(func() {
 		allocator = auctioncellrep.NewContainerAllocator(
 			fakeGenerateContainerGuid,
-			rep.StackPathMap{linuxStack: linuxPath},
+			stackSource,
 			executorClient,
 		)
 	})
@@ -94,6 +97,67 @@ var _ = Describe("ContainerAllocator", func() {
 			Expect(failedWork).To(BeEmpty())
 		})
 
+		Context("when the LRP requests a cpu weight", func() {
+			BeforeEach(func() {
+				lrp1.CPUWeight = 75
+			})
+
+			It("records the cpu weight in the container tags", func() {
+				allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1})
+
+				Expect(executorClient.AllocateContainersCallCount()).To(Equal(1))
+				_, arg := executorClient.AllocateContainersArgsForCall(0)
+				Expect(arg).To(HaveLen(1))
+				Expect(arg[0].Tags).To(HaveKeyWithValue(rep.CPUWeightTag, "75"))
+			})
+		})
+
+		Context("when the LRP's stack was added after the executor started", func() {
+			BeforeEach(func() {
+				stackMap := stacks.NewExecutorMap(rep.StackPathMap{"cflinuxfs3": "/data/cflinuxfs3"},
+					func(string) error { return nil },
+					func(string) (uint64, error) { return 300 * 1024 * 1024, nil },
+				)
+				stackMap.Swap(rep.StackPathMap{"cflinuxfs3": "/data/cflinuxfs3", linuxStack: linuxPath})
+				stackSource = stackMap
+			})
+
+			It("adds the size of the stack's rootfs to the container's disk", func() {
+				allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1})
+
+				_, arg := executorClient.AllocateContainersArgsForCall(0)
+				Expect(arg).To(HaveLen(1))
+				Expect(arg[0].DiskMB).To(Equal(int(lrp1.DiskMB) + 300))
+			})
+
+			Context("and the LRP has unlimited disk", func() {
+				BeforeEach(func() {
+					lrp1.DiskMB = 0
+				})
+
+				It("keeps the disk unlimited", func() {
+					allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1})
+
+					_, arg := executorClient.AllocateContainersArgsForCall(0)
+					Expect(arg[0].DiskMB).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the LRP has a priority", func() {
+			BeforeEach(func() {
+				lrp1.Priority = 10
+			})
+
+			It("records the priority in the container tags", func() {
+				allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1})
+
+				_, arg := executorClient.AllocateContainersArgsForCall(0)
+				Expect(arg).To(HaveLen(1))
+				Expect(arg[0].Tags).To(HaveKeyWithValue(rep.PriorityTag, "10"))
+			})
+		})
+
 		Context("when a container fails to be allocated", func() {
 			BeforeEach(func() {
 				allocationRequest := allocationRequestFromLRP(lrp2)
@@ -103,7 +167,27 @@ var _ = Describe("ContainerAllocator", func() {
 
 			It("marks the corresponding LRP Auctions as failed", func() {
 				failedWork := allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1, lrp2})
-				Expect(failedWork).To(ConsistOf(lrp2))
+
+				expectedLRP := lrp2
+				expectedLRP.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, commonErr.Error())
+				Expect(failedWork).To(ConsistOf(expectedLRP))
+			})
+		})
+
+		Context("when an instance guid cannot be generated", func() {
+			BeforeEach(func() {
+				fakeGenerateContainerGuid = func() (string, error) {
+					return "", commonErr
+				}
+			})
+
+			It("marks the LRPs as failed with the generation error", func() {
+				failedWork := allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{lrp1})
+
+				expectedLRP := lrp1
+				expectedLRP.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonInstanceGuidGenerationFailed, commonErr.Error())
+				Expect(failedWork).To(ConsistOf(expectedLRP))
+				Expect(executorClient.AllocateContainersCallCount()).To(Equal(0))
 			})
 		})
 
@@ -195,7 +279,9 @@ var _ = Describe("ContainerAllocator", func() {
 
 				It("marks the other LRP as failed", func() {
 					failedLRPs := allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{validLRP, invalidLRP})
-					Expect(failedLRPs).To(ConsistOf(invalidLRP))
+					Expect(failedLRPs).To(HaveLen(1))
+					Expect(failedLRPs[0].InstanceGUID).To(Equal(invalidLRP.InstanceGUID))
+					Expect(failedLRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
 				})
 			})
 
@@ -217,7 +303,8 @@ var _ = Describe("ContainerAllocator", func() {
 				It("marks the LRPs with invalid RootFS paths as failed", func() {
 					failedLRPs := allocator.BatchLRPAllocationRequest(logger, enableContainerProxy, proxyMemoryAllocation, []rep.LRP{validLRP, invalidLRP})
 					Expect(failedLRPs).To(HaveLen(1))
-					Expect(failedLRPs).To(ContainElement(invalidLRP))
+					Expect(failedLRPs[0].InstanceGUID).To(Equal(invalidLRP.InstanceGUID))
+					Expect(failedLRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
 				})
 			})
 
@@ -283,6 +370,25 @@ var _ = Describe("ContainerAllocator", func() {
 			))
 		})
 
+		Context("when the Task's stack was added after the executor started", func() {
+			BeforeEach(func() {
+				stackMap := stacks.NewExecutorMap(rep.StackPathMap{},
+					func(string) error { return nil },
+					func(string) (uint64, error) { return 300 * 1024 * 1024, nil },
+				)
+				stackMap.Swap(rep.StackPathMap{linuxStack: linuxPath})
+				stackSource = stackMap
+			})
+
+			It("adds the size of the stack's rootfs to the container's disk", func() {
+				allocator.BatchTaskAllocationRequest(logger, []rep.Task{task1})
+
+				_, arg := executorClient.AllocateContainersArgsForCall(0)
+				Expect(arg).To(HaveLen(1))
+				Expect(arg[0].DiskMB).To(Equal(int(task1.DiskMB) + 300))
+			})
+		})
+
 		Context("when all containers can be successfully allocated", func() {
 			BeforeEach(func() {
 				executorClient.AllocateContainersReturns([]executor.AllocationFailure{})
@@ -309,7 +415,10 @@ var _ = Describe("ContainerAllocator", func() {
 
 			It("marks the corresponding Tasks as failed", func() {
 				failedTasks := allocator.BatchTaskAllocationRequest(logger, []rep.Task{task1, task2})
-				Expect(failedTasks).To(ConsistOf(task1))
+
+				expectedTask := task1
+				expectedTask.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, commonErr.Error())
+				Expect(failedTasks).To(ConsistOf(expectedTask))
 			})
 
 			It("logs the container allocation failure", func() {
@@ -356,7 +465,9 @@ var _ = Describe("ContainerAllocator", func() {
 
 				It("marks the Task as failed", func() {
 					failedTasks := allocator.BatchTaskAllocationRequest(logger, []rep.Task{validTask, invalidTask})
-					Expect(failedTasks).To(ConsistOf(invalidTask))
+					Expect(failedTasks).To(HaveLen(1))
+					Expect(failedTasks[0].TaskGuid).To(Equal(invalidTask.TaskGuid))
+					Expect(failedTasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
 				})
 			})
 
@@ -378,7 +489,8 @@ var _ = Describe("ContainerAllocator", func() {
 				It("marks the tasks with invalid RootFS paths as failed", func() {
 					failedTasks := allocator.BatchTaskAllocationRequest(logger, []rep.Task{validTask, invalidTask})
 					Expect(failedTasks).To(HaveLen(1))
-					Expect(failedTasks).To(ContainElement(invalidTask))
+					Expect(failedTasks[0].TaskGuid).To(Equal(invalidTask.TaskGuid))
+					Expect(failedTasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonRootFSNotFound))
 				})
 			})
 
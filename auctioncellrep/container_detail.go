@@ -0,0 +1,66 @@
+package auctioncellrep
+
+import (
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+// ContainerDetail returns the executor's view of a single container along
+// with its latest cached metrics. It returns executor.ErrContainerNotFound if
+// the container does not exist.
+func (a *AuctionCellRep) ContainerDetail(logger lager.Logger, guid string) (rep.ContainerDetail, error) {
+	logger = logger.Session("container-detail", lager.Data{"guid": guid})
+
+	container, err := a.client.GetContainer(logger, guid)
+	if err != nil {
+		if err != executor.ErrContainerNotFound {
+			logger.Error("failed-to-get-container", err)
+		}
+		return rep.ContainerDetail{}, err
+	}
+
+	detail := rep.ContainerDetail{
+		Guid:      container.Guid,
+		Lifecycle: container.Tags[rep.LifecycleTag],
+		Domain:    container.Tags[rep.DomainTag],
+		State:     container.State,
+		RunResult: container.RunResult,
+		Resource: rep.Resource{
+			MemoryMB:  int32(container.MemoryMB),
+			DiskMB:    int32(container.DiskMB),
+			MaxPids:   int32(container.MaxPids),
+			CPUWeight: containerCPUWeight(&container),
+		},
+		MemoryLimit: container.MemoryLimit,
+		DiskLimit:   container.DiskLimit,
+		Tags:        container.Tags,
+		NetInfo: rep.ContainerNetInfo{
+			ExternalIP: container.ExternalIP,
+			InternalIP: container.InternalIP,
+			Ports:      container.Ports,
+		},
+		AllocatedAt: container.AllocatedAt,
+	}
+
+	switch detail.Lifecycle {
+	case rep.LRPLifecycle:
+		key, err := rep.ActualLRPKeyFromTags(container.Tags)
+		if err != nil {
+			logger.Error("failed-to-extract-key", err)
+			return rep.ContainerDetail{}, err
+		}
+		detail.ProcessGuid = key.ProcessGuid
+		detail.Index = key.Index
+		detail.InstanceGuid = container.Tags[rep.InstanceGuidTag]
+	case rep.TaskLifecycle:
+		detail.TaskGuid = container.Guid
+	}
+
+	if metrics, ok := a.containerMetricsProvider.Metrics()[guid]; ok && metrics != nil {
+		cached := *metrics
+		detail.Metrics = &cached
+	}
+
+	return detail, nil
+}
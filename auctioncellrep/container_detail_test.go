@@ -0,0 +1,146 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/containermetrics"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerDetail", func() {
+	var (
+		cellRep                      *auctioncellrep.AuctionCellRep
+		client                       *fake_client.FakeClient
+		fakeContainerMetricsProvider *fakes.FakeContainerMetricsProvider
+		logger                       *lagertest.TestLogger
+
+		container executor.Container
+		detail    rep.ContainerDetail
+		err       error
+	)
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		fakeContainerMetricsProvider = new(fakes.FakeContainerMetricsProvider)
+		logger = lagertest.NewTestLogger("test")
+
+		container = createContainer(executor.StateRunning, rep.LRPLifecycle)
+		container.Tags[rep.CPUWeightTag] = "50"
+		container.ExternalIP = "10.0.0.1"
+		container.InternalIP = "10.255.0.1"
+		container.Ports = []executor.PortMapping{{ContainerPort: 8080, HostPort: 61000}}
+		container.MemoryLimit = 20 * 1024 * 1024
+		container.AllocatedAt = 1234
+		container.RunResult = executor.ContainerRunResult{Failed: true, FailureReason: "boom"}
+		client.GetContainerReturns(container, nil)
+
+		fakeContainerMetricsProvider.MetricsReturns(map[string]*containermetrics.CachedContainerMetrics{
+			"some-container-guid": {MetricGUID: "some-metric-guid", MemoryUsageBytes: 1024},
+		})
+
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: fakeContainerMetricsProvider,
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       &fake_evacuation_context.FakeEvacuationReporter{},
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			Clock:                    fakeclock.NewFakeClock(time.Now()),
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                new(fakes.FakeBatchContainerAllocator),
+		})
+	})
+
+	JustBeforeEach(func() {
+		detail, err = cellRep.ContainerDetail(logger, "some-container-guid")
+	})
+
+	It("fetches the container from the executor", func() {
+		Expect(client.GetContainerCallCount()).To(Equal(1))
+		_, guid := client.GetContainerArgsForCall(0)
+		Expect(guid).To(Equal("some-container-guid"))
+	})
+
+	It("describes an LRP instance's container along with its metrics", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(detail).To(Equal(rep.ContainerDetail{
+			Guid:         "some-container-guid",
+			Lifecycle:    rep.LRPLifecycle,
+			Domain:       "domain",
+			ProcessGuid:  "some-process-guid",
+			InstanceGuid: "some-instance-guid",
+			Index:        1,
+			State:        executor.StateRunning,
+			RunResult:    executor.ContainerRunResult{Failed: true, FailureReason: "boom"},
+			Resource:     rep.Resource{MemoryMB: 20, DiskMB: 10, MaxPids: 100, CPUWeight: 50},
+			MemoryLimit:  20 * 1024 * 1024,
+			Tags:         container.Tags,
+			NetInfo: rep.ContainerNetInfo{
+				ExternalIP: "10.0.0.1",
+				InternalIP: "10.255.0.1",
+				Ports:      []executor.PortMapping{{ContainerPort: 8080, HostPort: 61000}},
+			},
+			AllocatedAt: 1234,
+			Metrics:     &containermetrics.CachedContainerMetrics{MetricGUID: "some-metric-guid", MemoryUsageBytes: 1024},
+		}))
+	})
+
+	Context("when the container is a task", func() {
+		BeforeEach(func() {
+			client.GetContainerReturns(createContainer(executor.StateCompleted, rep.TaskLifecycle), nil)
+		})
+
+		It("sets the task guid instead of the LRP keys", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detail.Lifecycle).To(Equal(rep.TaskLifecycle))
+			Expect(detail.TaskGuid).To(Equal("some-container-guid"))
+			Expect(detail.ProcessGuid).To(BeEmpty())
+			Expect(detail.InstanceGuid).To(BeEmpty())
+		})
+	})
+
+	Context("when there are no metrics for the container yet", func() {
+		BeforeEach(func() {
+			fakeContainerMetricsProvider.MetricsReturns(nil)
+		})
+
+		It("omits the metrics", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detail.Metrics).To(BeNil())
+		})
+	})
+
+	Context("when the container does not exist", func() {
+		BeforeEach(func() {
+			client.GetContainerReturns(executor.Container{}, executor.ErrContainerNotFound)
+		})
+
+		It("returns ErrContainerNotFound", func() {
+			Expect(err).To(Equal(executor.ErrContainerNotFound))
+		})
+	})
+
+	Context("when the executor fails", func() {
+		BeforeEach(func() {
+			client.GetContainerReturns(executor.Container{}, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
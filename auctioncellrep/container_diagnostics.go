@@ -0,0 +1,118 @@
+package auctioncellrep
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+const redacted = "[REDACTED]"
+
+// ContainerDiagnostics gathers the executor's record of a container, its
+// latest cached metrics and the last resultFileLines lines of its result
+// file. It returns executor.ErrContainerNotFound if the container does not
+// exist. Failing to read the result file does not fail the diagnostics.
+func (a *AuctionCellRep) ContainerDiagnostics(logger lager.Logger, guid string, resultFileLines int) (rep.ContainerDiagnostics, error) {
+	logger = logger.Session("container-diagnostics", lager.Data{"guid": guid})
+
+	container, err := a.client.GetContainer(logger, guid)
+	if err != nil {
+		if err != executor.ErrContainerNotFound {
+			logger.Error("failed-to-get-container", err)
+		}
+		return rep.ContainerDiagnostics{}, err
+	}
+
+	diagnostics := rep.ContainerDiagnostics{Container: redactContainer(container)}
+
+	if metrics, ok := a.containerMetricsProvider.Metrics()[guid]; ok && metrics != nil {
+		cached := *metrics
+		diagnostics.Metrics = &cached
+	}
+
+	resultFile := container.Tags[rep.ResultFileTag]
+	if resultFile != "" && resultFileLines > 0 {
+		tail, err := a.resultFileTail(logger, guid, resultFile, resultFileLines)
+		if err != nil {
+			logger.Info("failed-to-fetch-result-file", lager.Data{"error": err.Error()})
+			diagnostics.ResultFileError = err.Error()
+		} else {
+			diagnostics.ResultFileTail = tail
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// resultFileTail returns the last lines of the container's result file, as
+// read by the generator when it completes a task.
+func (a *AuctionCellRep) resultFileTail(logger lager.Logger, guid, filename string, lines int) (string, error) {
+	contents, err := a.containerDelegate.FetchContainerResultFile(logger, guid, filename)
+	if err != nil {
+		return "", err
+	}
+
+	contents = strings.TrimSuffix(contents, "\n")
+	if contents == "" {
+		return "", nil
+	}
+
+	all := strings.Split(contents, "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.Join(all, "\n"), nil
+}
+
+// redactContainer copies the fields of the container that are known to be
+// safe to hand to an operator. Anything else, including fields added to the
+// executor later, is left out. Environment variables keep their names only,
+// and cached dependencies their names and destinations.
+func redactContainer(container executor.Container) executor.Container {
+	redactedContainer := executor.Container{
+		Guid:                                  container.Guid,
+		Resource:                              container.Resource,
+		Tags:                                  container.Tags,
+		State:                                 container.State,
+		AllocatedAt:                           container.AllocatedAt,
+		ExternalIP:                            container.ExternalIP,
+		InternalIP:                            container.InternalIP,
+		RunResult:                             container.RunResult,
+		MemoryLimit:                           container.MemoryLimit,
+		DiskLimit:                             container.DiskLimit,
+		AdvertisePreferenceForInstanceAddress: container.AdvertisePreferenceForInstanceAddress,
+		RunInfo: executor.RunInfo{
+			RootFSPath:           container.RootFSPath,
+			CPUWeight:            container.CPUWeight,
+			Ports:                container.Ports,
+			LogConfig:            container.LogConfig,
+			MetricsConfig:        container.MetricsConfig,
+			StartTimeoutMs:       container.StartTimeoutMs,
+			Privileged:           container.Privileged,
+			EnableContainerProxy: container.EnableContainerProxy,
+		},
+	}
+
+	if container.Env != nil {
+		env := make([]executor.EnvironmentVariable, len(container.Env))
+		for i := range container.Env {
+			env[i] = executor.EnvironmentVariable{Name: container.Env[i].Name, Value: redacted}
+		}
+		redactedContainer.Env = env
+	}
+
+	if container.CachedDependencies != nil {
+		dependencies := make([]executor.CachedDependency, len(container.CachedDependencies))
+		for i := range container.CachedDependencies {
+			dependencies[i] = executor.CachedDependency{
+				Name: container.CachedDependencies[i].Name,
+				To:   container.CachedDependencies[i].To,
+			}
+		}
+		redactedContainer.CachedDependencies = dependencies
+	}
+
+	return redactedContainer
+}
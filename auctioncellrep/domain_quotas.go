@@ -0,0 +1,40 @@
+package auctioncellrep
+
+import (
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/rep"
+)
+
+// domainQuotaFailure returns a placement failure if placing the resource
+// would exceed the quota of the domain.
+func (a *AuctionCellRep) domainQuotaFailure(usage map[string]rep.DomainUsage, domain string, resource *rep.Resource) *rep.PlacementFailure {
+	quota, found := a.domainQuotas[domain]
+	if !found {
+		return nil
+	}
+
+	err := quota.Admit(domain, usage[domain], resource)
+	if err != nil {
+		return rep.NewPlacementFailure(rep.PlacementFailureReasonDomainQuotaExceeded, err.Error())
+	}
+	return nil
+}
+
+func addContainerDomainUsage(usage map[string]rep.DomainUsage, container *executor.Container) {
+	if container.Tags == nil {
+		return
+	}
+
+	resource := rep.Resource{MemoryMB: int32(container.MemoryMB), DiskMB: int32(container.DiskMB)}
+	addDomainUsage(usage, container.Tags[rep.DomainTag], &resource)
+}
+
+func addDomainUsage(usage map[string]rep.DomainUsage, domain string, resource *rep.Resource) {
+	if domain == "" {
+		return
+	}
+
+	domainUsage := usage[domain]
+	domainUsage.Add(resource)
+	usage[domain] = domainUsage
+}
@@ -0,0 +1,144 @@
+package auctioncellrep_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Domain quotas", func() {
+	var (
+		cellRep                *auctioncellrep.AuctionCellRep
+		client                 *fake_client.FakeClient
+		logger                 *lagertest.TestLogger
+		fakeContainerAllocator *fakes.FakeBatchContainerAllocator
+
+		domainQuotas rep.DomainQuotas
+		lrp          rep.LRP
+		task         rep.Task
+	)
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		logger = lagertest.NewTestLogger("test")
+		fakeContainerAllocator = new(fakes.FakeBatchContainerAllocator)
+
+		resources := executor.ExecutorResources{MemoryMB: 4096, DiskMB: 4096, Containers: 10}
+		client.TotalResourcesReturns(resources, nil)
+		client.RemainingResourcesReturns(resources, nil)
+		client.HealthyReturns(true)
+
+		existing := createContainer(executor.StateRunning, rep.TaskLifecycle)
+		existing.Tags[rep.DomainTag] = "tenant-a"
+		existing.Resource = executor.NewResource(512, 256, 0)
+		client.ListContainersReturns([]executor.Container{existing}, nil)
+
+		domainQuotas = rep.DomainQuotas{"tenant-a": {MemoryMB: 1024, Containers: 3}}
+
+		lrp = rep.NewLRP(
+			"ig-1",
+			models.NewActualLRPKey("process-guid", 0, "tenant-a"),
+			rep.NewResource(256, 256, 0),
+			rep.PlacementConstraint{},
+		)
+		task = rep.NewTask("task-guid", "tenant-a", rep.NewResource(512, 256, 0), rep.PlacementConstraint{})
+	})
+
+	JustBeforeEach(func() {
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       &fake_evacuation_context.FakeEvacuationReporter{},
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			DomainQuotas:             domainQuotas,
+			Clock:                    fakeclock.NewFakeClock(time.Now()),
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                fakeContainerAllocator,
+		})
+	})
+
+	Describe("State", func() {
+		It("reports the usage of each domain and the configured quotas", func() {
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(state.DomainUsage).To(Equal(map[string]rep.DomainUsage{
+				"tenant-a": {MemoryMB: 512, DiskMB: 256, Containers: 1},
+			}))
+			Expect(state.DomainQuotas).To(Equal(domainQuotas))
+		})
+	})
+
+	Describe("Perform", func() {
+		It("allocates work that fits within the quota", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(BeEmpty())
+
+			_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+			Expect(lrpRequests).To(ConsistOf(lrp))
+		})
+
+		It("rejects the work that would exceed the quota", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, Tasks: []rep.Task{task}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(failedWork.LRPs).To(BeEmpty())
+			Expect(failedWork.Tasks).To(HaveLen(1))
+			Expect(failedWork.Tasks[0].TaskGuid).To(Equal("task-guid"))
+			Expect(failedWork.Tasks[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(
+				rep.PlacementFailureReasonDomainQuotaExceeded,
+				"domain quota exceeded for tenant-a: memory",
+			)))
+
+			_, taskRequests := fakeContainerAllocator.BatchTaskAllocationRequestArgsForCall(0)
+			Expect(taskRequests).To(BeEmpty())
+		})
+
+		It("does not limit domains without a quota", func() {
+			task.Domain = "tenant-b"
+			task.MemoryMB = 2048
+
+			failedWork, err := cellRep.Perform(logger, rep.Work{Tasks: []rep.Task{task}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.Tasks).To(BeEmpty())
+		})
+
+		It("reports quota failures in a dry run", func() {
+			failedWork, err := cellRep.PerformDryRun(logger, rep.Work{LRPs: []rep.LRP{lrp}, Tasks: []rep.Task{task}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(failedWork.LRPs).To(BeEmpty())
+			Expect(failedWork.Tasks).To(HaveLen(1))
+			Expect(failedWork.Tasks[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonDomainQuotaExceeded))
+		})
+
+		Context("when no quotas are configured", func() {
+			BeforeEach(func() {
+				domainQuotas = nil
+			})
+
+			It("does not list the containers", func() {
+				_, err := cellRep.Perform(logger, rep.Work{Tasks: []rep.Task{task}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.ListContainersCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package auctioncellrep
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/bbs"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+//go:generate counterfeiter . EvictionReporter
+
+// EvictionReporter tells the BBS about work evicted from the cell to make room
+// for higher priority work, so that the BBS places it elsewhere.
+type EvictionReporter interface {
+	ReportEviction(logger lager.Logger, eviction rep.Eviction) error
+}
+
+type bbsEvictionReporter struct {
+	bbsClient bbs.InternalClient
+	cellID    string
+}
+
+func NewEvictionReporter(bbsClient bbs.InternalClient, cellID string) EvictionReporter {
+	return &bbsEvictionReporter{
+		bbsClient: bbsClient,
+		cellID:    cellID,
+	}
+}
+
+// ReportEviction unclaims an evicted LRP instance, as evacuation does, so that
+// the BBS auctions a replacement right away. An evicted task is rejected so
+// that the BBS can retry it on another cell.
+func (r *bbsEvictionReporter) ReportEviction(logger lager.Logger, eviction rep.Eviction) error {
+	if eviction.TaskGuid != "" {
+		return r.bbsClient.RejectTask(logger, eviction.TaskGuid, fmt.Sprintf("evicted by %s", eviction.PreemptedBy))
+	}
+
+	lrpKey := models.NewActualLRPKey(eviction.ProcessGuid, eviction.Index, eviction.Domain)
+	instanceKey := models.NewActualLRPInstanceKey(eviction.InstanceGuid, r.cellID)
+	_, err := r.bbsClient.EvacuateClaimedActualLRP(logger, &lrpKey, &instanceKey)
+	return err
+}
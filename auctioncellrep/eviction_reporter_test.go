@@ -0,0 +1,93 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/bbs/fake_bbs"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EvictionReporter", func() {
+	var (
+		bbsClient *fake_bbs.FakeInternalClient
+		logger    *lagertest.TestLogger
+		reporter  auctioncellrep.EvictionReporter
+	)
+
+	BeforeEach(func() {
+		bbsClient = new(fake_bbs.FakeInternalClient)
+		logger = lagertest.NewTestLogger("test")
+		reporter = auctioncellrep.NewEvictionReporter(bbsClient, cellID)
+	})
+
+	Context("when an LRP instance is evicted", func() {
+		var eviction rep.Eviction
+
+		BeforeEach(func() {
+			eviction = rep.Eviction{
+				ContainerGuid: "container-guid",
+				ProcessGuid:   "process-guid",
+				InstanceGuid:  "instance-guid",
+				Index:         2,
+				Domain:        "domain",
+				PreemptedBy:   "critical-app.0",
+			}
+		})
+
+		It("unclaims the instance so that the BBS auctions a replacement", func() {
+			Expect(reporter.ReportEviction(logger, eviction)).To(Succeed())
+
+			Expect(bbsClient.EvacuateClaimedActualLRPCallCount()).To(Equal(1))
+			_, lrpKey, instanceKey := bbsClient.EvacuateClaimedActualLRPArgsForCall(0)
+			Expect(*lrpKey).To(Equal(models.NewActualLRPKey("process-guid", 2, "domain")))
+			Expect(*instanceKey).To(Equal(models.NewActualLRPInstanceKey("instance-guid", cellID)))
+		})
+
+		Context("when the BBS fails", func() {
+			BeforeEach(func() {
+				bbsClient.EvacuateClaimedActualLRPReturns(false, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(reporter.ReportEviction(logger, eviction)).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Context("when a task is evicted", func() {
+		var eviction rep.Eviction
+
+		BeforeEach(func() {
+			eviction = rep.Eviction{
+				ContainerGuid: "task-guid",
+				TaskGuid:      "task-guid",
+				Domain:        "domain",
+				PreemptedBy:   "critical-app.0",
+			}
+		})
+
+		It("rejects the task so that the BBS can retry it elsewhere", func() {
+			Expect(reporter.ReportEviction(logger, eviction)).To(Succeed())
+
+			Expect(bbsClient.RejectTaskCallCount()).To(Equal(1))
+			_, guid, reason := bbsClient.RejectTaskArgsForCall(0)
+			Expect(guid).To(Equal("task-guid"))
+			Expect(reason).To(Equal("evicted by critical-app.0"))
+		})
+
+		Context("when the BBS fails", func() {
+			BeforeEach(func() {
+				bbsClient.RejectTaskReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(reporter.ReportEviction(logger, eviction)).To(MatchError("boom"))
+			})
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package auctioncellrep
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/rep"
+)
+
+var ErrMaxInstancesPerCellReached = errors.New("maximum instances of the process per cell reached")
+
+// addContainerInstanceCount counts the container against its process if it
+// is an LRP container.
+func addContainerInstanceCount(counts map[string]int, container *executor.Container) {
+	if container.Tags == nil || container.Tags[rep.LifecycleTag] != rep.LRPLifecycle {
+		return
+	}
+	counts[container.Tags[rep.ProcessGuidTag]]++
+}
+
+func instanceLimitFailure(counts map[string]int, lrp *rep.LRP) *rep.PlacementFailure {
+	if lrp.MaxInstancesPerCell > 0 && counts[lrp.ProcessGuid] >= int(lrp.MaxInstancesPerCell) {
+		return rep.NewPlacementFailure(rep.PlacementFailureReasonMaxInstancesPerCell, ErrMaxInstancesPerCellReached.Error())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package auctioncellrep_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Max instances per cell", func() {
+	var (
+		cellRep                *auctioncellrep.AuctionCellRep
+		client                 *fake_client.FakeClient
+		logger                 *lagertest.TestLogger
+		fakeContainerAllocator *fakes.FakeBatchContainerAllocator
+
+		instance1, instance2 rep.LRP
+	)
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		logger = lagertest.NewTestLogger("test")
+		fakeContainerAllocator = new(fakes.FakeBatchContainerAllocator)
+
+		resources := executor.ExecutorResources{MemoryMB: 4096, DiskMB: 4096, Containers: 10}
+		client.TotalResourcesReturns(resources, nil)
+		client.RemainingResourcesReturns(resources, nil)
+		client.HealthyReturns(true)
+
+		existing := createContainer(executor.StateRunning, rep.LRPLifecycle)
+		client.ListContainersReturns([]executor.Container{existing}, nil)
+
+		instance1 = rep.NewLRP(
+			"ig-1",
+			models.NewActualLRPKey("some-process-guid", 2, "domain"),
+			rep.NewResource(256, 256, 0),
+			rep.PlacementConstraint{MaxInstancesPerCell: 2},
+		)
+		instance2 = rep.NewLRP(
+			"ig-2",
+			models.NewActualLRPKey("some-process-guid", 3, "domain"),
+			rep.NewResource(256, 256, 0),
+			rep.PlacementConstraint{MaxInstancesPerCell: 2},
+		)
+	})
+
+	JustBeforeEach(func() {
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       &fake_evacuation_context.FakeEvacuationReporter{},
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			Clock:                    fakeclock.NewFakeClock(time.Now()),
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                fakeContainerAllocator,
+		})
+	})
+
+	Describe("Perform", func() {
+		It("rejects instances beyond the limit, counting the existing containers", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{instance1, instance2}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(failedWork.LRPs).To(HaveLen(1))
+			Expect(failedWork.LRPs[0].InstanceGUID).To(Equal("ig-2"))
+			Expect(failedWork.LRPs[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(
+				rep.PlacementFailureReasonMaxInstancesPerCell,
+				auctioncellrep.ErrMaxInstancesPerCellReached.Error(),
+			)))
+
+			_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+			Expect(lrpRequests).To(ConsistOf(instance1))
+		})
+
+		It("does not list containers when no LRP sets a limit", func() {
+			instance1.MaxInstancesPerCell = 0
+			instance2.MaxInstancesPerCell = 0
+
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{instance1, instance2}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(BeEmpty())
+			Expect(client.ListContainersCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("PerformDryRun", func() {
+		It("reports the instances that would exceed the limit", func() {
+			failedWork, err := cellRep.PerformDryRun(logger, rep.Work{LRPs: []rep.LRP{instance1, instance2}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(failedWork.LRPs).To(HaveLen(1))
+			Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonMaxInstancesPerCell))
+		})
+	})
+})
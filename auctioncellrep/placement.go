@@ -0,0 +1,222 @@
+package auctioncellrep
+
+import (
+	"sort"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+// cellCapacity is what is left of the cell for new work. CPU weight and PIDs
+// are only tracked when the total has them.
+type cellCapacity struct {
+	total     rep.Resources
+	available rep.Resources
+}
+
+// shortfall returns how much of each resource the cell lacks to fit the
+// resource. Non-positive values mean there is enough.
+func (c *cellCapacity) shortfall(resource *rep.Resource) rep.Resources {
+	shortfall := rep.Resources{
+		MemoryMB:   resource.MemoryMB - c.available.MemoryMB,
+		DiskMB:     resource.DiskMB - c.available.DiskMB,
+		Containers: 1 - c.available.Containers,
+	}
+	if c.total.CPUWeight > 0 {
+		shortfall.CPUWeight = resource.CPUWeight - c.available.CPUWeight
+	}
+	if c.total.MaxPids > 0 {
+		shortfall.MaxPids = resource.MaxPids - c.available.MaxPids
+	}
+	return shortfall
+}
+
+func (c *cellCapacity) add(resources rep.Resources) {
+	c.available.MemoryMB += resources.MemoryMB
+	c.available.DiskMB += resources.DiskMB
+	c.available.Containers += resources.Containers
+	c.available.CPUWeight += resources.CPUWeight
+	c.available.MaxPids += resources.MaxPids
+}
+
+// placementState is the part of the cell's state that placing work depends
+// on. It is read without computing the full cell state, so it does not bump
+// the state generation.
+type placementState struct {
+	capacity       cellCapacity
+	domainUsage    map[string]rep.DomainUsage
+	instanceCounts map[string]int
+	// containers is only listed when the work or the cell's limits need it.
+	containers []executor.Container
+}
+
+// removeEvicted takes a container planned for eviction out of the domain
+// usage and instance counts, so that the rest of the work can be placed in
+// what it held. Usage and counts are only tracked when the containers were
+// listed to place the work.
+func (s *placementState) removeEvicted(candidate *evictionCandidate) {
+	if usage, ok := s.domainUsage[candidate.Domain]; ok {
+		usage.Subtract(&rep.Resource{MemoryMB: candidate.resources.MemoryMB, DiskMB: candidate.resources.DiskMB})
+		s.domainUsage[candidate.Domain] = usage
+	}
+	if candidate.TaskGuid == "" && s.instanceCounts[candidate.ProcessGuid] > 0 {
+		s.instanceCounts[candidate.ProcessGuid]--
+	}
+}
+
+// workPlacement is the outcome of fitting work onto the cell: the work that
+// fits, the work that does not and the evictions planned to make room.
+type workPlacement struct {
+	lrps       []rep.LRP
+	tasks      []rep.Task
+	failed     rep.Work
+	preemption *preemptionPlan
+}
+
+// placeWork decides which of the work fits on the cell and which lower
+// priority containers must be evicted to make room, without allocating or
+// evicting anything. The capacity held by reservations other than the one
+// named by reservationID is not available to it. Perform and PerformDryRun
+// both place work with it so that a dry run reports what Perform would do.
+// It must be called with the reservations lock held.
+func (a *AuctionCellRep) placeWork(logger lager.Logger, work rep.Work, reservationID string) (workPlacement, error) {
+	state, err := a.placementState(logger, work, reservationID)
+	if err != nil {
+		return workPlacement{}, err
+	}
+
+	placement := workPlacement{preemption: newPreemptionPlan(a.client, a.evictionReporter, state.containers)}
+	stacks := a.stacks.Stacks()
+
+	lrps := make([]rep.LRP, len(work.LRPs))
+	copy(lrps, work.LRPs)
+	sort.SliceStable(lrps, func(i, j int) bool {
+		if lrps[i].Priority != lrps[j].Priority {
+			return lrps[i].Priority > lrps[j].Priority
+		}
+		return lrps[i].MemoryMB > lrps[j].MemoryMB
+	})
+
+	for _, lrp := range lrps {
+		resource := lrp.Resource.Copy()
+		if a.enableContainerProxy {
+			resource.MemoryMB += int32(a.proxyMemoryAllocation)
+		}
+
+		failure := rootFSFailure(stacks, a.preloadedVersionRanges, lrp.RootFs)
+		if failure == nil {
+			failure = instanceLimitFailure(state.instanceCounts, &lrp)
+		}
+		if failure == nil {
+			failure = a.domainQuotaFailure(state.domainUsage, lrp.Domain, &resource)
+		}
+		if failure == nil {
+			failure = placement.preemption.place(logger, &state, &resource, lrp.Priority, lrp.Identifier())
+		}
+		if failure != nil {
+			lrp.PlacementFailure = failure
+			placement.failed.LRPs = append(placement.failed.LRPs, lrp)
+			continue
+		}
+
+		addDomainUsage(state.domainUsage, lrp.Domain, &resource)
+		state.instanceCounts[lrp.ProcessGuid]++
+		placement.lrps = append(placement.lrps, lrp)
+	}
+
+	for _, task := range work.Tasks {
+		resource := task.Resource.Copy()
+
+		failure := rootFSFailure(stacks, a.preloadedVersionRanges, task.RootFs)
+		if failure == nil {
+			failure = a.domainQuotaFailure(state.domainUsage, task.Domain, &resource)
+		}
+		if failure == nil {
+			failure = placement.preemption.place(logger, &state, &resource, task.Priority, task.Identifier())
+		}
+		if failure != nil {
+			task.PlacementFailure = failure
+			placement.failed.Tasks = append(placement.failed.Tasks, task)
+			continue
+		}
+
+		addDomainUsage(state.domainUsage, task.Domain, &resource)
+		placement.tasks = append(placement.tasks, task)
+	}
+
+	return placement, nil
+}
+
+// placementState reads the cell's remaining resources and, only if the work
+// or the cell's limits need them, lists its containers once to compute the
+// domain usage, instance counts and used CPU weight and PIDs.
+func (a *AuctionCellRep) placementState(logger lager.Logger, work rep.Work, reservationID string) (placementState, error) {
+	remainingResources, err := a.client.RemainingResources(logger)
+	if err != nil {
+		logger.Error("failed-gathering-remaining-resources", err)
+		return placementState{}, err
+	}
+
+	state := placementState{
+		capacity:       cellCapacity{available: a.convertResources(remainingResources)},
+		domainUsage:    map[string]rep.DomainUsage{},
+		instanceCounts: map[string]int{},
+	}
+	subtractReserved(&state.capacity.available, a.reservedResources(reservationID))
+
+	if !a.placementNeedsContainers(work) {
+		return state, nil
+	}
+
+	state.containers, err = a.client.ListContainers(logger)
+	if err != nil {
+		logger.Error("failed-fetching-containers", err)
+		return placementState{}, err
+	}
+
+	usedCPUWeight := 0
+	usedPids := 0
+	for i := range state.containers {
+		container := &state.containers[i]
+		addContainerDomainUsage(state.domainUsage, container)
+		addContainerInstanceCount(state.instanceCounts, container)
+		usedCPUWeight += int(containerCPUWeight(container))
+		usedPids += container.MaxPids
+	}
+
+	if a.cpuWeightCapacity > 0 {
+		state.capacity.total.CPUWeight = int32(a.cpuWeightCapacity)
+		state.capacity.available.CPUWeight = int32(a.cpuWeightCapacity - usedCPUWeight)
+	}
+	if a.pidCapacity > 0 {
+		state.capacity.total.MaxPids = int32(a.pidCapacity)
+		state.capacity.available.MaxPids = int32(a.pidCapacity - usedPids)
+	}
+
+	return state, nil
+}
+
+func (a *AuctionCellRep) placementNeedsContainers(work rep.Work) bool {
+	if len(a.domainQuotas) > 0 || a.cpuWeightCapacity > 0 || a.pidCapacity > 0 {
+		return true
+	}
+
+	for i := range work.LRPs {
+		if work.LRPs[i].MaxInstancesPerCell > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func rootFSFailure(stacks rep.StackPathMap, versionRanges map[string]string, rootFS string) *rep.PlacementFailure {
+	_, err := stacks.PathForRootFS(rootFS)
+	if err == nil {
+		err = rep.VerifyStackVersion(rootFS, versionRanges)
+	}
+	if err != nil {
+		return rep.NewPlacementFailure(rep.PlacementFailureReasonRootFSNotFound, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package auctioncellrep
+
+import (
+	"sort"
+	"strconv"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+// preemptionPlan chooses lower priority containers to evict when higher
+// priority work does not fit on the cell. Containers are only listed the first
+// time an eviction is needed, unless they were already listed to place the
+// work, and nothing is evicted until execute is called.
+type preemptionPlan struct {
+	client     executor.Client
+	reporter   EvictionReporter
+	listed     bool
+	candidates []evictionCandidate
+	evictions  []*rep.Eviction
+}
+
+// evictionCandidate is a running container that could be evicted, along with
+// the resources it would free.
+type evictionCandidate struct {
+	*rep.Eviction
+	resources rep.Resources
+}
+
+// newPreemptionPlan returns a plan that chooses among the given containers,
+// or lists them itself when they are nil.
+func newPreemptionPlan(client executor.Client, reporter EvictionReporter, containers []executor.Container) *preemptionPlan {
+	plan := &preemptionPlan{client: client, reporter: reporter}
+	if containers != nil {
+		plan.addCandidates(containers)
+	}
+	return plan
+}
+
+// place takes the resource from the cell's capacity, planning the eviction of
+// lower priority containers if it does not fit. It returns a placement
+// failure, and takes nothing, if the resource cannot fit.
+//
+// Only work with a positive priority preempts: work without a priority, and
+// work given a negative one to mark it as the first to go, never evicts
+// anything to make room for itself.
+func (p *preemptionPlan) place(logger lager.Logger, state *placementState, resource *rep.Resource, priority int32, preemptedBy string) *rep.PlacementFailure {
+	capacity := &state.capacity
+	shortfall := capacity.shortfall(resource)
+
+	if !covers(rep.Resources{}, shortfall) {
+		freed, ok := p.free(logger, state, priority, shortfall, preemptedBy)
+		if !ok {
+			return insufficientResourcesFailure(shortfall)
+		}
+		capacity.add(freed)
+	}
+
+	capacity.available.MemoryMB -= resource.MemoryMB
+	capacity.available.DiskMB -= resource.DiskMB
+	capacity.available.Containers--
+	if capacity.total.CPUWeight > 0 {
+		capacity.available.CPUWeight -= resource.CPUWeight
+	}
+	if capacity.total.MaxPids > 0 {
+		capacity.available.MaxPids -= resource.MaxPids
+	}
+	return nil
+}
+
+// free plans evictions of containers with a priority lower than the given
+// priority until the shortfall is covered, taking the evicted containers out
+// of the domain usage and instance counts of the state. It returns the
+// resources freed, or false if the shortfall cannot be covered, in which case
+// nothing is planned.
+func (p *preemptionPlan) free(logger lager.Logger, state *placementState, priority int32, shortfall rep.Resources, preemptedBy string) (rep.Resources, bool) {
+	if priority <= 0 {
+		return rep.Resources{}, false
+	}
+
+	if !p.listed {
+		containers, err := p.client.ListContainers(logger)
+		if err != nil {
+			logger.Error("failed-listing-eviction-candidates", err)
+			return rep.Resources{}, false
+		}
+		p.addCandidates(containers)
+	}
+
+	var chosen []int
+	freed := rep.Resources{}
+	for i, candidate := range p.candidates {
+		if covers(freed, shortfall) {
+			break
+		}
+		if candidate.PreemptedBy != "" || candidate.Priority >= priority {
+			continue
+		}
+		chosen = append(chosen, i)
+		freed.MemoryMB += candidate.resources.MemoryMB
+		freed.DiskMB += candidate.resources.DiskMB
+		freed.Containers += candidate.resources.Containers
+		freed.CPUWeight += candidate.resources.CPUWeight
+		freed.MaxPids += candidate.resources.MaxPids
+	}
+
+	if !covers(freed, shortfall) {
+		return rep.Resources{}, false
+	}
+
+	for _, i := range chosen {
+		p.candidates[i].PreemptedBy = preemptedBy
+		p.evictions = append(p.evictions, p.candidates[i].Eviction)
+		state.removeEvicted(&p.candidates[i])
+	}
+	return freed, true
+}
+
+// addCandidates orders the running containers from the lowest priority to the
+// highest, and the largest to the smallest within a priority.
+func (p *preemptionPlan) addCandidates(containers []executor.Container) {
+	p.listed = true
+
+	for i := range containers {
+		container := &containers[i]
+		eviction := evictionFromContainer(container)
+		if eviction == nil {
+			continue
+		}
+		p.candidates = append(p.candidates, evictionCandidate{
+			Eviction: eviction,
+			resources: rep.Resources{
+				MemoryMB:   int32(container.MemoryMB),
+				DiskMB:     int32(container.DiskMB),
+				Containers: 1,
+				CPUWeight:  containerCPUWeight(container),
+				MaxPids:    int32(container.MaxPids),
+			},
+		})
+	}
+
+	sort.SliceStable(p.candidates, func(i, j int) bool {
+		if p.candidates[i].Priority != p.candidates[j].Priority {
+			return p.candidates[i].Priority < p.candidates[j].Priority
+		}
+		return p.candidates[i].MemoryMB > p.candidates[j].MemoryMB
+	})
+}
+
+// execute evicts the planned containers. Each eviction is reported to the BBS
+// before its container is touched, and is skipped if it cannot be reported so
+// that the BBS never loses track of the work. LRP containers are then stopped
+// before they are deleted so that their processes are signalled as they would
+// be by StopLRPInstance; task containers are deleted as they are by
+// CancelTask.
+func (p *preemptionPlan) execute(logger lager.Logger) []rep.Eviction {
+	var evicted []rep.Eviction
+	for _, eviction := range p.evictions {
+		logger := logger.Session("evict", lager.Data{
+			"container-guid": eviction.ContainerGuid,
+			"priority":       eviction.Priority,
+			"preempted-by":   eviction.PreemptedBy,
+		})
+
+		err := p.reporter.ReportEviction(logger, *eviction)
+		if err != nil {
+			logger.Error("failed-reporting-eviction", err)
+			continue
+		}
+
+		if eviction.TaskGuid == "" {
+			err := p.client.StopContainer(logger, eviction.ContainerGuid)
+			if err != nil && err != executor.ErrContainerNotFound {
+				logger.Error("failed-stopping-container", err)
+				continue
+			}
+		}
+
+		err = p.client.DeleteContainer(logger, eviction.ContainerGuid)
+		if err != nil && err != executor.ErrContainerNotFound {
+			logger.Error("failed-deleting-container", err)
+			continue
+		}
+
+		logger.Info("evicted")
+		evicted = append(evicted, *eviction)
+	}
+	return evicted
+}
+
+func covers(freed, shortfall rep.Resources) bool {
+	return freed.MemoryMB >= shortfall.MemoryMB &&
+		freed.DiskMB >= shortfall.DiskMB &&
+		freed.Containers >= shortfall.Containers &&
+		freed.CPUWeight >= shortfall.CPUWeight &&
+		freed.MaxPids >= shortfall.MaxPids
+}
+
+func insufficientResourcesFailure(shortfall rep.Resources) *rep.PlacementFailure {
+	problems := map[string]struct{}{}
+	if shortfall.MemoryMB > 0 {
+		problems["memory"] = struct{}{}
+	}
+	if shortfall.DiskMB > 0 {
+		problems["disk"] = struct{}{}
+	}
+	if shortfall.Containers > 0 {
+		problems["containers"] = struct{}{}
+	}
+	if shortfall.CPUWeight > 0 {
+		problems["cpu"] = struct{}{}
+	}
+	if shortfall.MaxPids > 0 {
+		problems["pids"] = struct{}{}
+	}
+
+	err := rep.InsufficientResourcesError{Problems: problems}
+	return rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, err.Error())
+}
+
+func evictionFromContainer(container *executor.Container) *rep.Eviction {
+	if container.Tags == nil || container.State == executor.StateCompleted {
+		return nil
+	}
+
+	eviction := &rep.Eviction{
+		ContainerGuid: container.Guid,
+		Domain:        container.Tags[rep.DomainTag],
+		Priority:      containerPriority(container),
+		MemoryMB:      int32(container.MemoryMB),
+	}
+
+	switch container.Tags[rep.LifecycleTag] {
+	case rep.LRPLifecycle:
+		key, err := rep.ActualLRPKeyFromTags(container.Tags)
+		if err != nil {
+			return nil
+		}
+		eviction.ProcessGuid = key.ProcessGuid
+		eviction.Index = key.Index
+		eviction.InstanceGuid = container.Tags[rep.InstanceGuidTag]
+	case rep.TaskLifecycle:
+		eviction.TaskGuid = container.Guid
+	default:
+		return nil
+	}
+
+	return eviction
+}
+
+func containerPriority(container *executor.Container) int32 {
+	priority, err := strconv.ParseInt(container.Tags[rep.PriorityTag], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(priority)
+}
@@ -0,0 +1,287 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Preemption", func() {
+	var (
+		cellRep                *auctioncellrep.AuctionCellRep
+		client                 *fake_client.FakeClient
+		logger                 *lagertest.TestLogger
+		evacuationReporter     *fake_evacuation_context.FakeEvacuationReporter
+		evictionReporter       *fakes.FakeEvictionReporter
+		fakeContainerAllocator *fakes.FakeBatchContainerAllocator
+		domainQuotas           rep.DomainQuotas
+
+		lowPriorityLRP, lowPriorityTask, highPriorityLRP executor.Container
+		lrp                                              rep.LRP
+	)
+
+	priorityContainer := func(guid, lifecycle string, priority string, memoryMB int) executor.Container {
+		container := createContainer(executor.StateRunning, lifecycle)
+		container.Guid = guid
+		container.Resource = executor.NewResource(memoryMB, 100, 0)
+		container.Tags[rep.PriorityTag] = priority
+		return container
+	}
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		logger = lagertest.NewTestLogger("test")
+		evacuationReporter = &fake_evacuation_context.FakeEvacuationReporter{}
+		evictionReporter = new(fakes.FakeEvictionReporter)
+		fakeContainerAllocator = new(fakes.FakeBatchContainerAllocator)
+		domainQuotas = nil
+
+		client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 256, DiskMB: 4096, Containers: 10}, nil)
+
+		lowPriorityLRP = priorityContainer("low-lrp", rep.LRPLifecycle, "1", 512)
+		lowPriorityTask = priorityContainer("low-task", rep.TaskLifecycle, "", 1024)
+		highPriorityLRP = priorityContainer("high-lrp", rep.LRPLifecycle, "100", 2048)
+		client.ListContainersReturns([]executor.Container{highPriorityLRP, lowPriorityLRP, lowPriorityTask}, nil)
+
+		lrp = rep.NewLRP(
+			"ig-1",
+			models.NewActualLRPKey("critical-app", 0, "domain"),
+			rep.NewResource(1024, 256, 0),
+			rep.PlacementConstraint{},
+		)
+		lrp.Priority = 10
+	})
+
+	JustBeforeEach(func() {
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       evacuationReporter,
+			EvictionReporter:         evictionReporter,
+			DomainQuotas:             domainQuotas,
+			Clock:                    fakeclock.NewFakeClock(time.Now()),
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                fakeContainerAllocator,
+		})
+	})
+
+	It("evicts the lowest priority containers needed to fit the work", func() {
+		failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failedWork.LRPs).To(BeEmpty())
+
+		Expect(client.DeleteContainerCallCount()).To(Equal(1))
+		_, guid := client.DeleteContainerArgsForCall(0)
+		Expect(guid).To(Equal("low-task"))
+		Expect(client.StopContainerCallCount()).To(Equal(0))
+
+		Expect(failedWork.Evictions).To(ConsistOf(rep.Eviction{
+			ContainerGuid: "low-task",
+			TaskGuid:      "low-task",
+			Domain:        "domain",
+			Priority:      0,
+			MemoryMB:      1024,
+			PreemptedBy:   lrp.Identifier(),
+		}))
+
+		_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+		Expect(lrpRequests).To(ConsistOf(lrp))
+	})
+
+	It("reports each eviction to the BBS", func() {
+		lrp.MemoryMB = 1792
+
+		_, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(evictionReporter.ReportEvictionCallCount()).To(Equal(2))
+		_, eviction := evictionReporter.ReportEvictionArgsForCall(0)
+		Expect(eviction.TaskGuid).To(Equal("low-task"))
+		_, eviction = evictionReporter.ReportEvictionArgsForCall(1)
+		Expect(eviction.ProcessGuid).To(Equal("some-process-guid"))
+		Expect(eviction.InstanceGuid).To(Equal("some-instance-guid"))
+	})
+
+	It("stops and deletes evicted LRP containers", func() {
+		lrp.MemoryMB = 1792
+
+		failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failedWork.LRPs).To(BeEmpty())
+
+		Expect(client.StopContainerCallCount()).To(Equal(1))
+		_, guid := client.StopContainerArgsForCall(0)
+		Expect(guid).To(Equal("low-lrp"))
+		Expect(client.DeleteContainerCallCount()).To(Equal(2))
+
+		Expect(failedWork.Evictions).To(HaveLen(2))
+		Expect(failedWork.Evictions[1].ProcessGuid).To(Equal("some-process-guid"))
+		Expect(failedWork.Evictions[1].InstanceGuid).To(Equal("some-instance-guid"))
+		Expect(failedWork.Evictions[1].Priority).To(BeEquivalentTo(1))
+	})
+
+	It("does not evict containers with an equal or higher priority", func() {
+		lrp.MemoryMB = 4096
+
+		failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(failedWork.LRPs).To(HaveLen(1))
+		Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonInsufficientResources))
+		Expect(failedWork.Evictions).To(BeEmpty())
+		Expect(client.DeleteContainerCallCount()).To(Equal(0))
+	})
+
+	It("does not evict anything for work without a priority", func() {
+		lrp.Priority = 0
+
+		failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(failedWork.LRPs).To(HaveLen(1))
+		Expect(client.ListContainersCallCount()).To(Equal(0))
+	})
+
+	It("evicts for high priority tasks", func() {
+		task := rep.NewTask("critical-task", "domain", rep.NewResource(512, 256, 0), rep.PlacementConstraint{})
+		task.Priority = 5
+
+		failedWork, err := cellRep.Perform(logger, rep.Work{Tasks: []rep.Task{task}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(failedWork.Evictions).To(HaveLen(1))
+		Expect(failedWork.Evictions[0].ContainerGuid).To(Equal("low-task"))
+		Expect(failedWork.Evictions[0].PreemptedBy).To(Equal("critical-task"))
+	})
+
+	Context("when an eviction frees a slot in a domain's quota", func() {
+		var task rep.Task
+
+		BeforeEach(func() {
+			domainQuotas = rep.DomainQuotas{"domain": {Containers: 3}}
+			lrp.Domain = "other-domain"
+			task = rep.NewTask("small-task", "domain", rep.NewResource(128, 100, 0), rep.PlacementConstraint{})
+		})
+
+		It("places later work of the domain in it", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, Tasks: []rep.Task{task}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(BeEmpty())
+			Expect(failedWork.Tasks).To(BeEmpty())
+			Expect(failedWork.Evictions).To(HaveLen(1))
+			Expect(failedWork.Evictions[0].ContainerGuid).To(Equal("low-task"))
+
+			_, taskRequests := fakeContainerAllocator.BatchTaskAllocationRequestArgsForCall(0)
+			Expect(taskRequests).To(ConsistOf(task))
+		})
+	})
+
+	Context("when an eviction frees an instance of a process limited per cell", func() {
+		var otherInstance rep.LRP
+
+		BeforeEach(func() {
+			client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 512, DiskMB: 4096, Containers: 10}, nil)
+			lrp.MemoryMB = 1792
+
+			otherInstance = rep.NewLRP(
+				"ig-2",
+				models.NewActualLRPKey("some-process-guid", 2, "domain"),
+				rep.NewResource(128, 100, 0),
+				rep.PlacementConstraint{},
+			)
+			otherInstance.MaxInstancesPerCell = 2
+		})
+
+		It("places another instance of the process", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{otherInstance, lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(BeEmpty())
+			Expect(failedWork.Evictions).To(HaveLen(2))
+
+			_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+			Expect(lrpRequests).To(ConsistOf(lrp, otherInstance))
+		})
+	})
+
+	Describe("PerformDryRun", func() {
+		It("reports the evictions Perform would make without evicting anything", func() {
+			failedWork, err := cellRep.PerformDryRun(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(BeEmpty())
+
+			Expect(failedWork.Evictions).To(HaveLen(1))
+			Expect(failedWork.Evictions[0].ContainerGuid).To(Equal("low-task"))
+			Expect(failedWork.Evictions[0].PreemptedBy).To(Equal(lrp.Identifier()))
+
+			Expect(evictionReporter.ReportEvictionCallCount()).To(Equal(0))
+			Expect(client.StopContainerCallCount()).To(Equal(0))
+			Expect(client.DeleteContainerCallCount()).To(Equal(0))
+			Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(0))
+		})
+
+		It("fails the work Perform would fail", func() {
+			lrp.MemoryMB = 4096
+
+			failedWork, err := cellRep.PerformDryRun(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.LRPs).To(HaveLen(1))
+			Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonInsufficientResources))
+			Expect(failedWork.Evictions).To(BeEmpty())
+		})
+	})
+
+	Context("when an eviction cannot be reported to the BBS", func() {
+		BeforeEach(func() {
+			evictionReporter.ReportEvictionReturns(errors.New("boom"))
+		})
+
+		It("leaves the container alone and does not report the eviction", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.Evictions).To(BeEmpty())
+			Expect(client.StopContainerCallCount()).To(Equal(0))
+			Expect(client.DeleteContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when deleting an evicted container fails", func() {
+		BeforeEach(func() {
+			client.DeleteContainerReturns(errors.New("boom"))
+		})
+
+		It("does not report the eviction", func() {
+			failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failedWork.Evictions).To(BeEmpty())
+		})
+	})
+
+	Context("when the cell is evacuating", func() {
+		BeforeEach(func() {
+			evacuationReporter.EvacuatingReturns(true)
+		})
+
+		It("does not evict anything", func() {
+			_, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.DeleteContainerCallCount()).To(Equal(0))
+		})
+	})
+})
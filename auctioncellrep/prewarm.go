@@ -0,0 +1,292 @@
+package auctioncellrep
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+const (
+	// PrewarmPollInterval is how often a prewarm container is checked while
+	// its image and cached dependencies are being fetched.
+	PrewarmPollInterval = time.Second
+
+	// PrewarmTimeout is how long a prewarm container may take to be created
+	// before it is abandoned.
+	PrewarmTimeout = 10 * time.Minute
+
+	// PrewarmExpiry is how long a warmed rootfs or cached dependency is
+	// advertised as warm. Garden and the executor reclaim unused images and
+	// cached dependencies, so a warm rootfs is not trusted forever.
+	PrewarmExpiry = time.Hour
+
+	// MaxPrewarmWorkers bounds the number of prewarm containers created at
+	// once. Further rootfses wait in a queue.
+	MaxPrewarmWorkers = 4
+)
+
+var ErrInvalidPrewarmRequest = errors.New("prewarm request must name at least one valid rootfs")
+var ErrPrewarmTimedOut = errors.New("timed out waiting for prewarm container to be created")
+var ErrPrewarmContainerFailed = errors.New("prewarm container failed")
+
+type prewarmJob struct {
+	logger       lager.Logger
+	rootFS       string
+	rootFSPath   string
+	dependencies []executor.CachedDependency
+}
+
+// Prewarm starts fetching the requested rootfs images and cached dependencies
+// in the background. Each image is fetched by creating a short-lived container
+// from it, which is deleted once it has been created. Images that are already
+// warm or being warmed are skipped.
+func (a *AuctionCellRep) Prewarm(logger lager.Logger, request rep.PrewarmRequest) error {
+	logger = logger.Session("prewarm", lager.Data{
+		"rootfses":            request.RootFSes,
+		"cached-dependencies": len(request.CachedDependencies),
+	})
+
+	if len(request.RootFSes) == 0 {
+		logger.Error("invalid-prewarm-request", ErrInvalidPrewarmRequest)
+		return ErrInvalidPrewarmRequest
+	}
+
+	rootFSPaths := make([]string, len(request.RootFSes))
+	for i, rootFS := range request.RootFSes {
+		if rootFS == "" {
+			logger.Error("invalid-rootfs", ErrInvalidPrewarmRequest)
+			return ErrInvalidPrewarmRequest
+		}
+
+		path, err := a.stacks.Stacks().PathForRootFS(rootFS)
+		if err == nil {
+			err = rep.VerifyStackVersion(rootFS, a.preloadedVersionRanges)
+		}
+		if err != nil {
+			logger.Error("failed-to-resolve-rootfs", err, lager.Data{"rootfs": rootFS})
+			return ErrInvalidPrewarmRequest
+		}
+		rootFSPaths[i] = path
+	}
+
+	if a.evacuationReporter.Evacuating() {
+		logger.Error("cell-evacuating", ErrCellEvacuating)
+		return ErrCellEvacuating
+	}
+
+	dependencies := rep.ConvertCachedDependencies(request.CachedDependencies)
+
+	a.prewarmLock.Lock()
+	defer a.prewarmLock.Unlock()
+
+	for i, rootFS := range request.RootFSes {
+		var rootFSDependencies []executor.CachedDependency
+		if i == 0 {
+			rootFSDependencies = dependencies
+		}
+
+		warmedAt, found := a.prewarmedRootFSes[rootFS]
+		if found && a.prewarmExpired(warmedAt) {
+			found = false
+		}
+		if found && len(rootFSDependencies) == 0 {
+			logger.Debug("skipping-rootfs", lager.Data{"rootfs": rootFS})
+			continue
+		}
+		if !found {
+			a.prewarmedRootFSes[rootFS] = time.Time{}
+		}
+
+		a.enqueuePrewarm(prewarmJob{
+			logger:       logger,
+			rootFS:       rootFS,
+			rootFSPath:   rootFSPaths[i],
+			dependencies: rootFSDependencies,
+		})
+	}
+
+	return nil
+}
+
+// enqueuePrewarm queues the job and starts a worker for it unless
+// MaxPrewarmWorkers are already running. It must be called with the
+// prewarmLock held.
+func (a *AuctionCellRep) enqueuePrewarm(job prewarmJob) {
+	a.prewarmQueue = append(a.prewarmQueue, job)
+	if a.prewarmWorkers < MaxPrewarmWorkers {
+		a.prewarmWorkers++
+		go a.prewarmWorker()
+	}
+}
+
+// prewarmWorker runs queued jobs until the queue is empty.
+func (a *AuctionCellRep) prewarmWorker() {
+	for {
+		a.prewarmLock.Lock()
+		if len(a.prewarmQueue) == 0 {
+			a.prewarmWorkers--
+			a.prewarmLock.Unlock()
+			return
+		}
+		job := a.prewarmQueue[0]
+		a.prewarmQueue = a.prewarmQueue[1:]
+		a.prewarmLock.Unlock()
+
+		a.prewarm(job.logger, job.rootFS, job.rootFSPath, job.dependencies)
+	}
+}
+
+// prewarmExpired returns true if a rootfs or cached dependency warmed at the
+// given time is no longer trusted to be warm. A zero time means that it is
+// still being warmed.
+func (a *AuctionCellRep) prewarmExpired(warmedAt time.Time) bool {
+	return !warmedAt.IsZero() && a.clock.Since(warmedAt) >= PrewarmExpiry
+}
+
+// warmResources returns the sorted rootfses and cached dependency keys that
+// have finished warming and have not expired, forgetting the expired ones.
+func (a *AuctionCellRep) warmResources() ([]string, []string) {
+	a.prewarmLock.Lock()
+	defer a.prewarmLock.Unlock()
+
+	var rootFSes, cacheKeys []string
+	for rootFS, warmedAt := range a.prewarmedRootFSes {
+		if a.prewarmExpired(warmedAt) {
+			delete(a.prewarmedRootFSes, rootFS)
+		} else if !warmedAt.IsZero() {
+			rootFSes = append(rootFSes, rootFS)
+		}
+	}
+	for cacheKey, warmedAt := range a.prewarmedDependencies {
+		if a.prewarmExpired(warmedAt) {
+			delete(a.prewarmedDependencies, cacheKey)
+		} else {
+			cacheKeys = append(cacheKeys, cacheKey)
+		}
+	}
+
+	sort.Strings(rootFSes)
+	sort.Strings(cacheKeys)
+	return rootFSes, cacheKeys
+}
+
+// imageFetched returns true once the container's rootfs image has been
+// fetched.
+func imageFetched(container *executor.Container) bool {
+	switch container.State {
+	case executor.StateCreated, executor.StateRunning, executor.StateCompleted:
+		return true
+	}
+	return false
+}
+
+// addCachedRootFS records a rootfs whose image is cached on the cell.
+// Preloaded stacks are not recorded, as they are advertised by the rootfs
+// providers.
+func addCachedRootFS(cached map[string]struct{}, rootFS string) {
+	if rootFS == "" || strings.HasPrefix(rootFS, models.PreloadedRootFSScheme+":") {
+		return
+	}
+	cached[rootFS] = struct{}{}
+}
+
+func sortedRootFSes(rootFSes map[string]struct{}) []string {
+	var sorted []string
+	for rootFS := range rootFSes {
+		sorted = append(sorted, rootFS)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func (a *AuctionCellRep) prewarm(logger lager.Logger, rootFS, rootFSPath string, dependencies []executor.CachedDependency) {
+	guid, err := GenerateGuid()
+	if err == nil {
+		guid = "prewarm-" + guid
+		logger = logger.Session("prewarm-container", lager.Data{"rootfs": rootFS, "container-guid": guid})
+		err = a.runPrewarmContainer(logger, guid, rootFSPath, dependencies)
+	}
+
+	a.prewarmLock.Lock()
+	defer a.prewarmLock.Unlock()
+
+	if err != nil {
+		logger.Error("failed-to-prewarm", err)
+		if a.prewarmedRootFSes[rootFS].IsZero() {
+			delete(a.prewarmedRootFSes, rootFS)
+		}
+		return
+	}
+
+	logger.Info("prewarmed")
+	now := a.clock.Now()
+	a.prewarmedRootFSes[rootFS] = now
+	for _, dependency := range dependencies {
+		if dependency.CacheKey != "" {
+			a.prewarmedDependencies[dependency.CacheKey] = now
+		}
+	}
+}
+
+func (a *AuctionCellRep) runPrewarmContainer(logger lager.Logger, guid, rootFSPath string, dependencies []executor.CachedDependency) error {
+	tags := executor.Tags{rep.LifecycleTag: rep.PrewarmLifecycle}
+	resource := executor.NewResource(0, 0, 0)
+	allocation := executor.NewAllocationRequest(guid, &resource, tags)
+
+	failures := a.client.AllocateContainers(logger, []executor.AllocationRequest{allocation})
+	if len(failures) > 0 {
+		return &failures[0]
+	}
+
+	defer func() {
+		err := a.client.DeleteContainer(logger, guid)
+		if err != nil && err != executor.ErrContainerNotFound {
+			logger.Error("failed-to-delete-container", err)
+		}
+	}()
+
+	// The image and cached dependencies are fetched when the container is
+	// created, before its action runs. The action is tried so that images
+	// without /bin/true or a root user, such as scratch or distroless ones,
+	// do not fail the container.
+	runRequest := executor.NewRunRequest(guid, &executor.RunInfo{
+		RootFSPath:         rootFSPath,
+		CachedDependencies: dependencies,
+		Action:             models.WrapAction(models.Try(&models.RunAction{Path: "/bin/true", User: "root"})),
+	}, tags)
+
+	err := a.client.RunContainer(logger, &runRequest)
+	if err != nil {
+		return err
+	}
+
+	start := a.clock.Now()
+	for {
+		container, err := a.client.GetContainer(logger, guid)
+		if err != nil {
+			return err
+		}
+
+		switch container.State {
+		case executor.StateCreated, executor.StateRunning:
+			return nil
+		case executor.StateCompleted:
+			if container.RunResult.Failed {
+				logger.Error("container-failed", ErrPrewarmContainerFailed, lager.Data{"failure-reason": container.RunResult.FailureReason})
+				return ErrPrewarmContainerFailed
+			}
+			return nil
+		}
+
+		if a.clock.Since(start) >= PrewarmTimeout {
+			return ErrPrewarmTimedOut
+		}
+		a.clock.Sleep(PrewarmPollInterval)
+	}
+}
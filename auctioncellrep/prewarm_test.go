@@ -0,0 +1,346 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Prewarm", func() {
+	var (
+		cellRep            *auctioncellrep.AuctionCellRep
+		client             *fake_client.FakeClient
+		logger             *lagertest.TestLogger
+		evacuationReporter *fake_evacuation_context.FakeEvacuationReporter
+		fakeClock          *fakeclock.FakeClock
+
+		request rep.PrewarmRequest
+	)
+
+	warmRootFSes := func() []string {
+		state, _, err := cellRep.State(logger)
+		Expect(err).NotTo(HaveOccurred())
+		return state.WarmRootFSes
+	}
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		logger = lagertest.NewTestLogger("test")
+		evacuationReporter = &fake_evacuation_context.FakeEvacuationReporter{}
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		client.HealthyReturns(true)
+		client.GetContainerReturns(executor.Container{State: executor.StateCreated}, nil)
+
+		request = rep.PrewarmRequest{
+			RootFSes: []string{models.PreloadedRootFS(linuxStack)},
+			CachedDependencies: []*models.CachedDependency{
+				{From: "http://example.com/buildpack.zip", To: "/tmp/buildpack", CacheKey: "buildpack"},
+			},
+		}
+
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       evacuationReporter,
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			Clock:                    fakeClock,
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                new(fakes.FakeBatchContainerAllocator),
+		})
+	})
+
+	It("creates a container from the resolved rootfs with the cached dependencies", func() {
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+		Eventually(client.RunContainerCallCount).Should(Equal(1))
+		_, runRequest := client.RunContainerArgsForCall(0)
+		Expect(runRequest.RootFSPath).To(Equal(linuxPath))
+		Expect(runRequest.Tags).To(Equal(executor.Tags{rep.LifecycleTag: rep.PrewarmLifecycle}))
+		Expect(runRequest.CachedDependencies).To(Equal([]executor.CachedDependency{
+			{From: "http://example.com/buildpack.zip", To: "/tmp/buildpack", CacheKey: "buildpack"},
+		}))
+
+		Expect(client.AllocateContainersCallCount()).To(Equal(1))
+		_, allocations := client.AllocateContainersArgsForCall(0)
+		Expect(allocations).To(HaveLen(1))
+		Expect(allocations[0].Guid).To(Equal(runRequest.Guid))
+	})
+
+	It("deletes the container and advertises the warm rootfs and dependencies", func() {
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+		Eventually(warmRootFSes).Should(ConsistOf(models.PreloadedRootFS(linuxStack)))
+		Expect(client.DeleteContainerCallCount()).To(Equal(1))
+
+		state, _, err := cellRep.State(logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.WarmCachedDependencies).To(ConsistOf("buildpack"))
+	})
+
+	It("reports warm images as cached", func() {
+		request.RootFSes = []string{"docker:///cloudfoundry/grace"}
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+		Eventually(warmRootFSes).Should(HaveLen(1))
+
+		state, _, err := cellRep.State(logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.CachedRootFSes).To(ConsistOf("docker:///cloudfoundry/grace"))
+	})
+
+	It("does not warm a rootfs again", func() {
+		request.CachedDependencies = nil
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+		Eventually(warmRootFSes).Should(HaveLen(1))
+
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+		Consistently(client.RunContainerCallCount).Should(Equal(1))
+	})
+
+	It("tries the container's action so that minimal images can be warmed", func() {
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+		Eventually(client.RunContainerCallCount).Should(Equal(1))
+		_, runRequest := client.RunContainerArgsForCall(0)
+		Expect(runRequest.Action.TryAction).NotTo(BeNil())
+	})
+
+	It("warms a rootfs again once it has expired", func() {
+		request.CachedDependencies = nil
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+		Eventually(warmRootFSes).Should(HaveLen(1))
+
+		fakeClock.Increment(auctioncellrep.PrewarmExpiry)
+		Expect(warmRootFSes()).To(BeEmpty())
+
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+		Eventually(client.RunContainerCallCount).Should(Equal(2))
+		Eventually(warmRootFSes).Should(HaveLen(1))
+	})
+
+	It("stops advertising cached dependencies once they have expired", func() {
+		Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+		Eventually(warmRootFSes).Should(HaveLen(1))
+
+		fakeClock.Increment(auctioncellrep.PrewarmExpiry)
+
+		state, _, err := cellRep.State(logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.WarmCachedDependencies).To(BeEmpty())
+	})
+
+	Context("when more rootfses are requested than there are prewarm workers", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+			client.RunContainerStub = func(lager.Logger, *executor.RunRequest) error {
+				<-release
+				return nil
+			}
+
+			request.RootFSes = nil
+			for i := 0; i <= auctioncellrep.MaxPrewarmWorkers; i++ {
+				request.RootFSes = append(request.RootFSes, fmt.Sprintf("docker:///cloudfoundry/image-%d", i))
+			}
+		})
+
+		It("creates at most MaxPrewarmWorkers containers at once", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			Eventually(client.RunContainerCallCount).Should(Equal(auctioncellrep.MaxPrewarmWorkers))
+			Consistently(client.RunContainerCallCount).Should(Equal(auctioncellrep.MaxPrewarmWorkers))
+
+			close(release)
+			Eventually(client.RunContainerCallCount).Should(Equal(auctioncellrep.MaxPrewarmWorkers + 1))
+			Eventually(warmRootFSes).Should(HaveLen(auctioncellrep.MaxPrewarmWorkers + 1))
+		})
+	})
+
+	Context("when a prewarm container exists", func() {
+		BeforeEach(func() {
+			client.TotalResourcesReturns(executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 10}, nil)
+			client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 9}, nil)
+			client.ListContainersReturns([]executor.Container{
+				{
+					Guid:     "prewarm-guid",
+					State:    executor.StateCreated,
+					Resource: executor.NewResource(0, 0, 0),
+					RunInfo:  executor.RunInfo{RootFSPath: "docker:///cloudfoundry/grace"},
+					Tags:     executor.Tags{rep.LifecycleTag: rep.PrewarmLifecycle},
+				},
+			}, nil)
+		})
+
+		It("does not advertise it as an LRP or a task", func() {
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(state.StartingContainerCount).To(Equal(0))
+			Expect(state.LRPs).To(BeEmpty())
+			Expect(state.Tasks).To(BeEmpty())
+		})
+
+		It("advertises its slot as used, as the executor counts it", func() {
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(state.AvailableResources.Containers).To(Equal(9))
+		})
+
+		Context("and it holds the cell's last container slot", func() {
+			BeforeEach(func() {
+				client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 0}, nil)
+			})
+
+			It("advertises no room for the work that Perform cannot place", func() {
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				lrp := rep.NewLRP("ig-1", models.NewActualLRPKey("pg-1", 0, "domain"), rep.NewResource(10, 10, 10), rep.PlacementConstraint{RootFs: models.PreloadedRootFS(linuxStack)})
+				Expect(state.ResourceMatch(&lrp.Resource)).To(Equal(rep.InsufficientResourcesError{Problems: map[string]struct{}{"containers": {}}}))
+
+				failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(HaveLen(1))
+				Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonInsufficientResources))
+			})
+		})
+
+		It("reports its image as cached", func() {
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(state.CachedRootFSes).To(ConsistOf("docker:///cloudfoundry/grace"))
+		})
+	})
+
+	Context("when the container takes a while to be created", func() {
+		BeforeEach(func() {
+			client.GetContainerReturnsOnCall(0, executor.Container{State: executor.StateInitializing}, nil)
+		})
+
+		It("polls until the container has been created", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			fakeClock.WaitForWatcherAndIncrement(auctioncellrep.PrewarmPollInterval)
+			Eventually(warmRootFSes).Should(HaveLen(1))
+			Expect(client.GetContainerCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the container never gets created", func() {
+		BeforeEach(func() {
+			client.GetContainerReturns(executor.Container{State: executor.StateInitializing}, nil)
+		})
+
+		It("gives up after the timeout and deletes the container", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			fakeClock.WaitForWatcherAndIncrement(auctioncellrep.PrewarmTimeout)
+			Eventually(client.DeleteContainerCallCount).Should(Equal(1))
+			Expect(warmRootFSes()).To(BeEmpty())
+		})
+	})
+
+	Context("when the container fails", func() {
+		BeforeEach(func() {
+			client.GetContainerReturns(executor.Container{
+				State:     executor.StateCompleted,
+				RunResult: executor.ContainerRunResult{Failed: true, FailureReason: "failed to download"},
+			}, nil)
+		})
+
+		It("does not advertise the rootfs", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			Eventually(client.DeleteContainerCallCount).Should(Equal(1))
+			Expect(warmRootFSes()).To(BeEmpty())
+			Eventually(logger).Should(gbytes.Say("container-failed"))
+		})
+	})
+
+	Context("when the container cannot be allocated", func() {
+		BeforeEach(func() {
+			allocation := executor.NewAllocationRequest("guid", &executor.Resource{}, nil)
+			client.AllocateContainersReturns([]executor.AllocationFailure{
+				executor.NewAllocationFailure(&allocation, "insufficient resources"),
+			})
+		})
+
+		It("does not run or advertise the rootfs", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			Eventually(logger).Should(gbytes.Say("failed-to-prewarm"))
+			Expect(client.RunContainerCallCount()).To(Equal(0))
+			Expect(warmRootFSes()).To(BeEmpty())
+		})
+	})
+
+	Context("when running the container fails", func() {
+		BeforeEach(func() {
+			client.RunContainerReturns(errors.New("boom"))
+		})
+
+		It("deletes the container", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Succeed())
+
+			Eventually(client.DeleteContainerCallCount).Should(Equal(1))
+			Expect(warmRootFSes()).To(BeEmpty())
+		})
+	})
+
+	Context("when the request has no rootfses", func() {
+		BeforeEach(func() {
+			request.RootFSes = nil
+		})
+
+		It("returns ErrInvalidPrewarmRequest", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Equal(auctioncellrep.ErrInvalidPrewarmRequest))
+			Expect(client.AllocateContainersCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a preloaded rootfs is not on the cell", func() {
+		BeforeEach(func() {
+			request.RootFSes = append(request.RootFSes, models.PreloadedRootFS("windows"))
+		})
+
+		It("returns ErrInvalidPrewarmRequest without warming anything", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Equal(auctioncellrep.ErrInvalidPrewarmRequest))
+			Consistently(client.AllocateContainersCallCount).Should(Equal(0))
+		})
+	})
+
+	Context("when the cell is evacuating", func() {
+		BeforeEach(func() {
+			evacuationReporter.EvacuatingReturns(true)
+		})
+
+		It("returns ErrCellEvacuating", func() {
+			Expect(cellRep.Prewarm(logger, request)).To(Equal(auctioncellrep.ErrCellEvacuating))
+			Consistently(client.AllocateContainersCallCount).Should(Equal(0))
+		})
+	})
+})
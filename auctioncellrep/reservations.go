@@ -0,0 +1,131 @@
+package auctioncellrep
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+// DefaultReservationTTL is how long a reservation is held when the rep is
+// not configured with a reservation TTL.
+const DefaultReservationTTL = 5 * time.Second
+
+var ErrInvalidReservation = errors.New("reservation must request non-negative resources")
+var ErrInsufficientResourcesForReservation = errors.New("insufficient resources for reservation")
+var ErrCellEvacuating = errors.New("cell is evacuating")
+
+// Reserve holds the requested capacity for the reservation TTL. Held capacity
+// is subtracted from the available resources reported by State and is not
+// available to Perform unless the work names the reservation.
+func (a *AuctionCellRep) Reserve(logger lager.Logger, request rep.ReservationRequest) (rep.Reservation, error) {
+	logger = logger.Session("reserve", lager.Data{
+		"memory-mb":  request.MemoryMB,
+		"disk-mb":    request.DiskMB,
+		"containers": request.Containers,
+		"cell-id":    request.CellID,
+	})
+
+	if request.CellID != "" && request.CellID != a.cellID {
+		logger.Error("cell-id-mismatch", ErrCellIdMismatch)
+		return rep.Reservation{}, ErrCellIdMismatch
+	}
+
+	if request.MemoryMB < 0 || request.DiskMB < 0 || request.Containers < 0 {
+		logger.Error("invalid-reservation", ErrInvalidReservation)
+		return rep.Reservation{}, ErrInvalidReservation
+	}
+
+	if a.evacuationReporter.Evacuating() {
+		logger.Error("cell-evacuating", ErrCellEvacuating)
+		return rep.Reservation{}, ErrCellEvacuating
+	}
+
+	id, err := GenerateGuid()
+	if err != nil {
+		logger.Error("failed-generating-reservation-id", err)
+		return rep.Reservation{}, err
+	}
+
+	a.reservationsLock.Lock()
+	defer a.reservationsLock.Unlock()
+
+	remainingResources, err := a.client.RemainingResources(logger)
+	if err != nil {
+		logger.Error("failed-gathering-remaining-resources", err)
+		return rep.Reservation{}, err
+	}
+
+	available := a.convertResources(remainingResources)
+	subtractReserved(&available, a.reservedResources(""))
+
+	if request.MemoryMB > available.MemoryMB ||
+		request.DiskMB > available.DiskMB ||
+		request.Containers > available.Containers {
+		logger.Error("insufficient-resources", ErrInsufficientResourcesForReservation, lager.Data{"available-resources": available})
+		return rep.Reservation{}, ErrInsufficientResourcesForReservation
+	}
+
+	reservation := rep.Reservation{
+		ID:         id,
+		MemoryMB:   request.MemoryMB,
+		DiskMB:     request.DiskMB,
+		Containers: request.Containers,
+		ExpiresAt:  a.clock.Now().Add(a.reservationTTL).UnixNano(),
+	}
+	a.reservations[id] = reservation
+
+	logger.Info("reserved", lager.Data{"reservation-id": id, "expires-at": reservation.ExpiresAt})
+	return reservation, nil
+}
+
+// outstandingReservations returns the capacity held by every unexpired
+// reservation other than the one named by excludeID.
+func (a *AuctionCellRep) outstandingReservations(excludeID string) rep.Resources {
+	a.reservationsLock.Lock()
+	defer a.reservationsLock.Unlock()
+
+	return a.reservedResources(excludeID)
+}
+
+// takeReservation removes the reservation with the given ID and reports
+// whether it was held. It must be called with the reservations lock held.
+func (a *AuctionCellRep) takeReservation(id string) bool {
+	a.expireReservations()
+	_, found := a.reservations[id]
+	delete(a.reservations, id)
+	return found
+}
+
+// reservedResources must be called with the reservations lock held.
+func (a *AuctionCellRep) reservedResources(excludeID string) rep.Resources {
+	a.expireReservations()
+
+	reserved := rep.Resources{}
+	for id, reservation := range a.reservations {
+		if id == excludeID {
+			continue
+		}
+		reserved.MemoryMB += reservation.MemoryMB
+		reserved.DiskMB += reservation.DiskMB
+		reserved.Containers += reservation.Containers
+	}
+	return reserved
+}
+
+// expireReservations must be called with the reservations lock held.
+func (a *AuctionCellRep) expireReservations() {
+	now := a.clock.Now().UnixNano()
+	for id, reservation := range a.reservations {
+		if reservation.ExpiresAt <= now {
+			delete(a.reservations, id)
+		}
+	}
+}
+
+func subtractReserved(available *rep.Resources, reserved rep.Resources) {
+	available.MemoryMB -= reserved.MemoryMB
+	available.DiskMB -= reserved.DiskMB
+	available.Containers -= reserved.Containers
+}
@@ -0,0 +1,331 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reservations", func() {
+	const reservationTTL = 10 * time.Second
+
+	var (
+		cellRep                *auctioncellrep.AuctionCellRep
+		client                 *fake_client.FakeClient
+		logger                 *lagertest.TestLogger
+		evacuationReporter     *fake_evacuation_context.FakeEvacuationReporter
+		fakeContainerAllocator *fakes.FakeBatchContainerAllocator
+		fakeClock              *fakeclock.FakeClock
+
+		request rep.ReservationRequest
+	)
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		logger = lagertest.NewTestLogger("test")
+		evacuationReporter = &fake_evacuation_context.FakeEvacuationReporter{}
+		fakeContainerAllocator = new(fakes.FakeBatchContainerAllocator)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		resources := executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 4}
+		client.TotalResourcesReturns(resources, nil)
+		client.RemainingResourcesReturns(resources, nil)
+		client.HealthyReturns(true)
+
+		request = rep.ReservationRequest{MemoryMB: 512, DiskMB: 256, Containers: 1}
+
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       evacuationReporter,
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			Clock:                    fakeClock,
+			ReservationTTL:           reservationTTL,
+			Allocator:                fakeContainerAllocator,
+		})
+	})
+
+	Describe("Reserve", func() {
+		It("returns a reservation that expires after the TTL", func() {
+			reservation, err := cellRep.Reserve(logger, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reservation.ID).NotTo(BeEmpty())
+			Expect(reservation.MemoryMB).To(BeEquivalentTo(512))
+			Expect(reservation.DiskMB).To(BeEquivalentTo(256))
+			Expect(reservation.Containers).To(Equal(1))
+			Expect(reservation.ExpiresAt).To(Equal(fakeClock.Now().Add(reservationTTL).UnixNano()))
+		})
+
+		It("subtracts the reservation from the available resources in the state", func() {
+			_, err := cellRep.Reserve(logger, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.AvailableResources.MemoryMB).To(BeEquivalentTo(512))
+			Expect(state.AvailableResources.DiskMB).To(BeEquivalentTo(1792))
+			Expect(state.AvailableResources.Containers).To(Equal(3))
+			Expect(state.TotalResources.MemoryMB).To(BeEquivalentTo(1024))
+		})
+
+		It("releases the reservation once the TTL has passed", func() {
+			_, err := cellRep.Reserve(logger, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeClock.Increment(reservationTTL)
+
+			state, _, err := cellRep.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.AvailableResources.MemoryMB).To(BeEquivalentTo(1024))
+			Expect(state.AvailableResources.Containers).To(Equal(4))
+		})
+
+		It("rejects a reservation that does not fit alongside the outstanding reservations", func() {
+			_, err := cellRep.Reserve(logger, request)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cellRep.Reserve(logger, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cellRep.Reserve(logger, request)
+			Expect(err).To(Equal(auctioncellrep.ErrInsufficientResourcesForReservation))
+		})
+
+		Context("when the request asks for negative resources", func() {
+			BeforeEach(func() {
+				request.DiskMB = -1
+			})
+
+			It("rejects the reservation", func() {
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).To(Equal(auctioncellrep.ErrInvalidReservation))
+			})
+		})
+
+		Context("when the request is for another cell", func() {
+			BeforeEach(func() {
+				request.CellID = "other-cell"
+			})
+
+			It("rejects the reservation", func() {
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).To(Equal(auctioncellrep.ErrCellIdMismatch))
+			})
+		})
+
+		Context("when the cell is evacuating", func() {
+			BeforeEach(func() {
+				evacuationReporter.EvacuatingReturns(true)
+			})
+
+			It("rejects the reservation", func() {
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).To(Equal(auctioncellrep.ErrCellEvacuating))
+			})
+		})
+
+		Context("when fetching the remaining resources fails", func() {
+			BeforeEach(func() {
+				client.RemainingResourcesReturns(executor.ExecutorResources{}, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("Perform", func() {
+		var lrp rep.LRP
+
+		BeforeEach(func() {
+			lrp = rep.NewLRP(
+				"ig-1",
+				models.NewActualLRPKey("process-guid", 0, "domain"),
+				rep.NewResource(512, 256, 0),
+				rep.PlacementConstraint{},
+			)
+		})
+
+		Context("when another reservation holds the memory", func() {
+			BeforeEach(func() {
+				request.MemoryMB = 1024
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects work that does not name the reservation", func() {
+				failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(HaveLen(1))
+				Expect(failedWork.LRPs[0].PlacementFailure.Reason).To(Equal(rep.PlacementFailureReasonInsufficientResources))
+			})
+		})
+
+		Context("when another reservation holds the container slots", func() {
+			BeforeEach(func() {
+				request = rep.ReservationRequest{MemoryMB: 1, DiskMB: 1, Containers: 4}
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects LRPs and tasks alike", func() {
+				task := rep.NewTask("task-guid", "domain", rep.NewResource(1, 1, 0), rep.PlacementConstraint{})
+
+				failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, Tasks: []rep.Task{task}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(HaveLen(1))
+				Expect(failedWork.LRPs[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: containers")))
+				Expect(failedWork.Tasks).To(HaveLen(1))
+				Expect(failedWork.Tasks[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: containers")))
+
+				_, taskRequests := fakeContainerAllocator.BatchTaskAllocationRequestArgsForCall(0)
+				Expect(taskRequests).To(BeEmpty())
+			})
+		})
+
+		Context("when another reservation holds the disk", func() {
+			BeforeEach(func() {
+				request = rep.ReservationRequest{MemoryMB: 1, DiskMB: 2048, Containers: 1}
+				_, err := cellRep.Reserve(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects tasks that need disk", func() {
+				task := rep.NewTask("task-guid", "domain", rep.NewResource(1, 256, 0), rep.PlacementConstraint{})
+
+				failedWork, err := cellRep.Perform(logger, rep.Work{Tasks: []rep.Task{task}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.Tasks).To(HaveLen(1))
+				Expect(failedWork.Tasks[0].PlacementFailure).To(Equal(rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: disk")))
+			})
+		})
+
+		Context("when the work names a reservation", func() {
+			var reservation rep.Reservation
+
+			BeforeEach(func() {
+				request.MemoryMB = 1024
+				var err error
+				reservation, err = cellRep.Reserve(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("uses the reserved capacity for the work", func() {
+				failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, ReservationID: reservation.ID})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(BeEmpty())
+
+				Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(1))
+				_, _, _, lrpRequests := fakeContainerAllocator.BatchLRPAllocationRequestArgsForCall(0)
+				Expect(lrpRequests).To(ConsistOf(lrp))
+			})
+
+			It("consumes the reservation", func() {
+				_, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, ReservationID: reservation.ID})
+				Expect(err).NotTo(HaveOccurred())
+
+				state, _, err := cellRep.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.AvailableResources.MemoryMB).To(BeEquivalentTo(1024))
+			})
+
+			It("accounts for the reservation in a dry run", func() {
+				failedWork, err := cellRep.PerformDryRun(logger, rep.Work{LRPs: []rep.LRP{lrp}, ReservationID: reservation.ID})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(BeEmpty())
+			})
+		})
+
+		Context("when reservations and work race for the same capacity", func() {
+			BeforeEach(func() {
+				var lock sync.Mutex
+				remaining := executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 4}
+
+				client.RemainingResourcesStub = func(lager.Logger) (executor.ExecutorResources, error) {
+					lock.Lock()
+					defer lock.Unlock()
+					return remaining, nil
+				}
+				fakeContainerAllocator.BatchLRPAllocationRequestStub = func(_ lager.Logger, _ bool, _ int, lrps []rep.LRP) []rep.LRP {
+					time.Sleep(time.Millisecond)
+
+					lock.Lock()
+					defer lock.Unlock()
+					for _, lrp := range lrps {
+						remaining.MemoryMB -= int(lrp.MemoryMB)
+						remaining.DiskMB -= int(lrp.DiskMB)
+						remaining.Containers--
+					}
+					return nil
+				}
+			})
+
+			It("never hands out more capacity than the cell has", func() {
+				var (
+					wg        sync.WaitGroup
+					lock      sync.Mutex
+					successes int
+				)
+
+				for i := 0; i < 10; i++ {
+					wg.Add(2)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						if _, err := cellRep.Reserve(logger, request); err == nil {
+							lock.Lock()
+							successes++
+							lock.Unlock()
+						}
+					}()
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}})
+						Expect(err).NotTo(HaveOccurred())
+						if len(failedWork.LRPs) == 0 {
+							lock.Lock()
+							successes++
+							lock.Unlock()
+						}
+					}()
+				}
+				wg.Wait()
+
+				Expect(successes).To(Equal(2))
+			})
+		})
+
+		Context("when the work names an unknown reservation", func() {
+			It("places the work against the unreserved capacity", func() {
+				failedWork, err := cellRep.Perform(logger, rep.Work{LRPs: []rep.LRP{lrp}, ReservationID: "unknown"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failedWork.LRPs).To(BeEmpty())
+				Expect(fakeContainerAllocator.BatchLRPAllocationRequestCallCount()).To(Equal(1))
+			})
+		})
+	})
+})
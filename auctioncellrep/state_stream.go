@@ -0,0 +1,128 @@
+package auctioncellrep
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+)
+
+// StateStreamResyncInterval is how often a state stream recomputes the state
+// when no container events are received, so that changes without an event of
+// their own, such as reservations expiring, are eventually streamed.
+const StateStreamResyncInterval = 10 * time.Second
+
+// StateStreamCoalesceInterval is how long a state stream waits after a
+// container event before recomputing the state, so that the events emitted
+// meanwhile, such as those of a batch of containers being allocated, are
+// streamed from a single recomputation.
+const StateStreamCoalesceInterval = 250 * time.Millisecond
+
+// StreamState streams a snapshot of the cell state followed by the changes
+// to it. The state is recomputed once the executor's container events have
+// been coalesced, and when the cell starts evacuating. The returned channel
+// is closed once done is closed or the executor event stream ends.
+func (a *AuctionCellRep) StreamState(logger lager.Logger, done <-chan struct{}) (<-chan rep.StateEvent, error) {
+	logger = logger.Session("stream-state")
+
+	source, err := a.client.SubscribeToEvents(logger)
+	if err != nil {
+		logger.Error("failed-subscribing", err)
+		return nil, err
+	}
+
+	state, err := a.cellState(logger)
+	if err != nil {
+		logger.Error("failed-to-get-state", err)
+		source.Close()
+		return nil, err
+	}
+
+	changed := make(chan struct{}, 1)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			_, err := source.Next()
+			if err != nil {
+				return
+			}
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	var evacuating <-chan struct{}
+	if notifier, ok := a.evacuationReporter.(evacuation_context.EvacuationNotifier); ok && !state.Evacuating {
+		evacuating = notifier.EvacuateNotify()
+	}
+
+	events := make(chan rep.StateEvent)
+	go func() {
+		defer close(events)
+		defer source.Close()
+
+		ticker := a.clock.NewTicker(StateStreamResyncInterval)
+		defer ticker.Stop()
+
+		send := func(event rep.StateEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		if !send(rep.NewStateSnapshotEvent(state)) {
+			return
+		}
+
+		var coalesce clock.Timer
+		var coalesced <-chan time.Time
+		for {
+			select {
+			case <-done:
+				return
+			case <-closed:
+				logger.Info("event-stream-closed")
+				return
+			case <-changed:
+				if coalesce == nil {
+					coalesce = a.clock.NewTimer(StateStreamCoalesceInterval)
+					coalesced = coalesce.C()
+				}
+				continue
+			case <-coalesced:
+			case <-ticker.C():
+			case <-evacuating:
+				evacuating = nil
+			}
+
+			if coalesce != nil {
+				coalesce.Stop()
+				coalesce, coalesced = nil, nil
+			}
+
+			current, err := a.cellState(logger)
+			if err != nil {
+				logger.Error("failed-to-get-state", err)
+				continue
+			}
+			logger.Debug("recomputed-state", lager.Data{"generation": current.Generation})
+
+			for _, event := range rep.DiffCellStates(state, current) {
+				if !send(event) {
+					return
+				}
+			}
+			state = current
+		}
+	}()
+
+	return events, nil
+}
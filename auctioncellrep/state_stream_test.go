@@ -0,0 +1,236 @@
+package auctioncellrep_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/executor"
+	fake_client "code.cloudfoundry.org/executor/fakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+	fakes "code.cloudfoundry.org/rep/auctioncellrep/auctioncellrepfakes"
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamState", func() {
+	var (
+		cellRep     *auctioncellrep.AuctionCellRep
+		client      *fake_client.FakeClient
+		eventSource *fake_client.FakeEventSource
+		logger      *lagertest.TestLogger
+		evacuatable evacuation_context.Evacuatable
+		fakeClock   *fakeclock.FakeClock
+
+		executorEvents chan executor.Event
+		sourceClosed   chan struct{}
+		done           chan struct{}
+		events         <-chan rep.StateEvent
+		streamErr      error
+	)
+
+	nextEvent := func() rep.StateEvent {
+		var event rep.StateEvent
+		EventuallyWithOffset(1, events).Should(Receive(&event))
+		return event
+	}
+
+	// coalesceEvents lets the stream's coalescing timer, which runs alongside
+	// its resync ticker, fire.
+	coalesceEvents := func() {
+		EventuallyWithOffset(1, fakeClock.WatcherCount).Should(Equal(2))
+		fakeClock.Increment(auctioncellrep.StateStreamCoalesceInterval)
+	}
+
+	BeforeEach(func() {
+		client = new(fake_client.FakeClient)
+		eventSource = new(fake_client.FakeEventSource)
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		resources := executor.ExecutorResources{MemoryMB: 1024, DiskMB: 2048, Containers: 4}
+		client.TotalResourcesReturns(resources, nil)
+		client.RemainingResourcesReturns(resources, nil)
+		client.HealthyReturns(true)
+
+		var evacuationReporter evacuation_context.EvacuationReporter
+		evacuatable, evacuationReporter, _ = evacuation_context.New()
+
+		executorEvents = make(chan executor.Event)
+		sourceClosed = make(chan struct{})
+		var closeOnce sync.Once
+		eventSource.NextStub = func() (executor.Event, error) {
+			select {
+			case event, ok := <-executorEvents:
+				if !ok {
+					return nil, errors.New("stream ended")
+				}
+				return event, nil
+			case <-sourceClosed:
+				return nil, errors.New("closed")
+			}
+		}
+		eventSource.CloseStub = func() error {
+			closeOnce.Do(func() { close(sourceClosed) })
+			return nil
+		}
+		client.SubscribeToEventsReturns(eventSource, nil)
+
+		done = make(chan struct{})
+
+		cellRep = auctioncellrep.New(auctioncellrep.Config{
+			CellID:                   cellID,
+			CellIndex:                cellIndex,
+			RepURL:                   repURL,
+			Zone:                     "the-zone",
+			Stacks:                   rep.StackPathMap{linuxStack: linuxPath},
+			ContainerMetricsProvider: new(fakes.FakeContainerMetricsProvider),
+			ArbitraryRootFSes:        []string{"docker"},
+			Client:                   client,
+			EvacuationReporter:       evacuationReporter,
+			EvictionReporter:         new(fakes.FakeEvictionReporter),
+			Clock:                    fakeClock,
+			ReservationTTL:           auctioncellrep.DefaultReservationTTL,
+			Allocator:                new(fakes.FakeBatchContainerAllocator),
+		})
+	})
+
+	JustBeforeEach(func() {
+		events, streamErr = cellRep.StreamState(logger, done)
+	})
+
+	AfterEach(func() {
+		select {
+		case <-done:
+		default:
+			close(done)
+		}
+	})
+
+	It("starts with a snapshot of the state", func() {
+		Expect(streamErr).NotTo(HaveOccurred())
+
+		event := nextEvent()
+		Expect(event.Type).To(Equal(rep.StateEventSnapshot))
+		Expect(event.State.CellID).To(Equal(cellID))
+		Expect(event.State.AvailableResources.MemoryMB).To(BeEquivalentTo(1024))
+	})
+
+	It("streams the changes after a container event", func() {
+		nextEvent()
+
+		container := createContainer(executor.StateRunning, rep.LRPLifecycle)
+		client.ListContainersReturns([]executor.Container{container}, nil)
+		client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 1004, DiskMB: 2038, Containers: 3}, nil)
+		executorEvents <- executor.NewContainerRunningEvent(container)
+		coalesceEvents()
+
+		event := nextEvent()
+		Expect(event.Type).To(Equal(rep.StateEventResourcesChanged))
+		Expect(event.Resources.AvailableResources.MemoryMB).To(BeEquivalentTo(1004))
+
+		event = nextEvent()
+		Expect(event.Type).To(Equal(rep.StateEventLRPAdded))
+		Expect(event.LRP.InstanceGUID).To(Equal("some-instance-guid"))
+	})
+
+	It("streams the change when the cell starts evacuating", func() {
+		nextEvent()
+
+		evacuatable.Evacuate()
+
+		Expect(nextEvent()).To(Equal(rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true}))
+	})
+
+	It("recomputes the state periodically", func() {
+		nextEvent()
+
+		client.RemainingResourcesReturns(executor.ExecutorResources{MemoryMB: 512, DiskMB: 2048, Containers: 4}, nil)
+		fakeClock.WaitForWatcherAndIncrement(auctioncellrep.StateStreamResyncInterval)
+
+		event := nextEvent()
+		Expect(event.Type).To(Equal(rep.StateEventResourcesChanged))
+		Expect(event.Resources.AvailableResources.MemoryMB).To(BeEquivalentTo(512))
+	})
+
+	It("does not stream anything when the state is unchanged", func() {
+		nextEvent()
+
+		executorEvents <- executor.NewContainerReservedEvent(executor.Container{Guid: "some-guid"})
+		coalesceEvents()
+
+		Eventually(client.ListContainersCallCount).Should(Equal(2))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("recomputes the state once for the container events received while coalescing", func() {
+		nextEvent()
+
+		executorEvents <- executor.NewContainerReservedEvent(executor.Container{Guid: "some-guid"})
+		executorEvents <- executor.NewContainerReservedEvent(executor.Container{Guid: "other-guid"})
+		executorEvents <- executor.NewContainerReservedEvent(executor.Container{Guid: "third-guid"})
+		Consistently(client.ListContainersCallCount).Should(Equal(1))
+
+		coalesceEvents()
+		Eventually(client.ListContainersCallCount).Should(Equal(2))
+		Consistently(client.ListContainersCallCount).Should(Equal(2))
+	})
+
+	It("does not check the executor's health or log each recomputation at info", func() {
+		nextEvent()
+
+		executorEvents <- executor.NewContainerReservedEvent(executor.Container{Guid: "some-guid"})
+		coalesceEvents()
+		Eventually(client.ListContainersCallCount).Should(Equal(2))
+
+		Expect(client.HealthyCallCount()).To(Equal(0))
+		for _, log := range logger.Logs() {
+			Expect(log.LogLevel).NotTo(Equal(lager.INFO), log.Message)
+		}
+	})
+
+	Context("when done is closed", func() {
+		It("closes the stream and the executor event source", func() {
+			nextEvent()
+			close(done)
+
+			Eventually(events).Should(BeClosed())
+			Eventually(eventSource.CloseCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when the executor event stream ends", func() {
+		It("closes the stream", func() {
+			nextEvent()
+			close(executorEvents)
+
+			Eventually(events).Should(BeClosed())
+		})
+	})
+
+	Context("when subscribing to executor events fails", func() {
+		BeforeEach(func() {
+			client.SubscribeToEventsReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(streamErr).To(MatchError("boom"))
+		})
+	})
+
+	Context("when the state cannot be fetched", func() {
+		BeforeEach(func() {
+			client.ListContainersReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error and closes the executor event source", func() {
+			Expect(streamErr).To(MatchError("boom"))
+			Expect(eventSource.CloseCallCount()).To(Equal(1))
+		})
+	})
+})
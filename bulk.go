@@ -0,0 +1,37 @@
+package rep
+
+import "code.cloudfoundry.org/bbs/models"
+
+// LRPInstanceKey identifies an LRP instance to stop in a bulk request.
+type LRPInstanceKey struct {
+	ProcessGuid  string `json:"process_guid"`
+	InstanceGuid string `json:"instance_guid"`
+}
+
+func NewLRPInstanceKey(key models.ActualLRPKey, instanceKey models.ActualLRPInstanceKey) LRPInstanceKey {
+	return LRPInstanceKey{ProcessGuid: key.ProcessGuid, InstanceGuid: instanceKey.InstanceGuid}
+}
+
+type StopLRPInstancesRequest struct {
+	Instances []LRPInstanceKey `json:"instances"`
+}
+
+type CancelTasksRequest struct {
+	TaskGuids []string `json:"task_guids"`
+}
+
+// BulkResult is the outcome of stopping a single LRP instance or cancelling
+// a single task in a bulk request.
+type BulkResult struct {
+	Error string `json:"error,omitempty"`
+}
+
+func (r BulkResult) Succeeded() bool {
+	return r.Error == ""
+}
+
+// BulkResponse holds the result of each item of a bulk request, keyed by
+// instance guid for LRP instances and by task guid for tasks.
+type BulkResponse struct {
+	Results map[string]BulkResult `json:"results"`
+}
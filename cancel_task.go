@@ -0,0 +1,18 @@
+package rep
+
+import "code.cloudfoundry.org/executor"
+
+// CancelTaskStatus reports what happened to a task's container when the task
+// was cancelled.
+type CancelTaskStatus struct {
+	// Existed is false if the cell had no container for the task, which is
+	// then already gone.
+	Existed bool `json:"existed"`
+	// State is the state of the container when the task was cancelled.
+	State executor.State `json:"state,omitempty"`
+	// Deleted is true once the container is gone. Unless the cancel waited
+	// for the deletion, it is only set when there was nothing to delete.
+	Deleted bool `json:"deleted"`
+	// Error is set if the container could not be deleted.
+	Error string `json:"error,omitempty"`
+}
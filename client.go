@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/bbs/models"
@@ -140,9 +142,22 @@ func (factory *clientFactory) CreateClient(address, url string) (Client, error)
 
 type Client interface {
 	State(logger lager.Logger) (CellState, error)
+	QueryState(logger lager.Logger, query StateQuery) (CellState, error)
+	StreamState(logger lager.Logger) (StateEventSource, error)
 	Perform(logger lager.Logger, work Work) (Work, error)
+	PerformDryRun(logger lager.Logger, work Work) (Work, error)
+	Reserve(logger lager.Logger, request ReservationRequest) (Reservation, error)
+	Prewarm(logger lager.Logger, request PrewarmRequest) error
 	StopLRPInstance(logger lager.Logger, key models.ActualLRPKey, instanceKey models.ActualLRPInstanceKey) error
+	StopLRPInstanceWithOptions(logger lager.Logger, key models.ActualLRPKey, instanceKey models.ActualLRPInstanceKey, request StopLRPInstanceRequest) error
+	RestartLRPInstance(logger lager.Logger, key models.ActualLRPKey, instanceKey models.ActualLRPInstanceKey) error
 	CancelTask(logger lager.Logger, taskGuid string) error
+	CancelTaskWithStatus(logger lager.Logger, taskGuid string, waitTimeout time.Duration) (CancelTaskStatus, error)
+	StopLRPInstances(logger lager.Logger, instances []LRPInstanceKey) (map[string]BulkResult, error)
+	CancelTasks(logger lager.Logger, taskGuids []string) (map[string]BulkResult, error)
+	LRPInstanceDetail(logger lager.Logger, processGuid, instanceGuid string) (ContainerDetail, error)
+	TaskDetail(logger lager.Logger, taskGuid string) (ContainerDetail, error)
+	ContainerDiagnostics(logger lager.Logger, guid string, resultFileLines int) (io.ReadCloser, error)
 	SetStateClient(stateClient *http.Client)
 	StateClientTimeout() time.Duration
 }
@@ -159,8 +174,28 @@ type client struct {
 	stateClient      *http.Client
 	address          string
 	requestGenerator *rata.RequestGenerator
+
+	stateLock  sync.Mutex
+	stateCache map[string]cachedState
+
+	// protobuf is set once the cell has replied in protobuf, after which
+	// work is sent to it in protobuf as well.
+	protobufLock sync.Mutex
+	protobuf     bool
+}
+
+// cachedState is the last response to a state query, kept along with its
+// entity tag.
+type cachedState struct {
+	etag        string
+	body        []byte
+	contentType string
 }
 
+// maxCachedStates bounds the number of state queries whose responses are
+// cached.
+const maxCachedStates = 32
+
 func newClient(httpClient, stateClient *http.Client, address string) Client {
 	return &client{
 		client:           httpClient,
@@ -178,11 +213,33 @@ func (c *client) StateClientTimeout() time.Duration {
 	return c.stateClient.Timeout
 }
 
+// State fetches the cell's state. The last response is cached and its entity
+// tag is sent with the next request, so that the cell does not have to send
+// the state again if it has not changed. The protobuf encoding is preferred
+// if the cell supports it.
 func (c *client) State(logger lager.Logger) (CellState, error) {
+	return c.QueryState(logger, StateQuery{})
+}
+
+// QueryState fetches the part of the cell's state selected by the query, for
+// instance only a summary of its resources. Responses are cached per query,
+// as they are by State.
+func (c *client) QueryState(logger lager.Logger, query StateQuery) (CellState, error) {
 	req, err := c.requestGenerator.CreateRequest(StateRoute, nil, nil)
 	if err != nil {
 		return CellState{}, err
 	}
+	req.URL.RawQuery = query.Values().Encode()
+	req.Header.Set("Accept", acceptProtobuf)
+
+	cacheKey := req.URL.RawQuery
+	c.stateLock.Lock()
+	cached, isCached := c.stateCache[cacheKey]
+	c.stateLock.Unlock()
+
+	if isCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
 
 	resp, err := c.stateClient.Do(req)
 	if err != nil {
@@ -190,33 +247,191 @@ func (c *client) State(logger lager.Logger) (CellState, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	var bs []byte
+	var contentType string
+	switch resp.StatusCode {
+	case http.StatusOK:
+		bs, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return CellState{}, err
+		}
+		contentType = resp.Header.Get("Content-Type")
+	case http.StatusNotModified:
+		if !isCached {
+			return CellState{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		bs, contentType = cached.body, cached.contentType
+	default:
 		return CellState{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	var state CellState
-	bs, err := ioutil.ReadAll(resp.Body)
+	err = unmarshalBody(contentType, bs, &state)
 	if err != nil {
 		return CellState{}, err
 	}
-	err = json.Unmarshal(bs, &state)
-	if err != nil {
-		return CellState{}, err
+
+	if resp.StatusCode == http.StatusOK {
+		c.cacheState(cacheKey, cachedState{etag: resp.Header.Get("ETag"), body: bs, contentType: contentType})
+		c.setProtobuf(contentType)
 	}
 
 	return state, nil
 }
 
+// cacheState records the response to a state query. Responses without an
+// entity tag cannot be revalidated and are not kept.
+func (c *client) cacheState(key string, state cachedState) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	if state.etag == "" {
+		delete(c.stateCache, key)
+		return
+	}
+
+	if c.stateCache == nil {
+		c.stateCache = map[string]cachedState{}
+	}
+	if _, found := c.stateCache[key]; !found && len(c.stateCache) >= maxCachedStates {
+		for evicted := range c.stateCache {
+			delete(c.stateCache, evicted)
+			break
+		}
+	}
+	c.stateCache[key] = state
+}
+
+// StreamState subscribes to the cell's state stream. The first event is a
+// snapshot of the state, which later events update through CellState.Apply.
+// The stream is not subject to the state client's timeout; it lasts until it
+// is closed by the caller or by the cell.
+func (c *client) StreamState(logger lager.Logger) (StateEventSource, error) {
+	req, err := c.requestGenerator.CreateRequest(StateEventsRoute, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	streamClient := &http.Client{Transport: c.client.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return NewStateEventSource(resp.Body), nil
+}
+
+// Perform asks the cell to allocate containers for the given work. It returns
+// the work that could not be placed, each item annotated with the reason, along
+// with any lower priority containers evicted to make room for the work.
 func (c *client) Perform(logger lager.Logger, work Work) (Work, error) {
-	body, err := json.Marshal(work)
+	return c.postWork(PerformRoute, work)
+}
+
+// PerformDryRun asks the cell which of the given work would fail to be
+// placed, without allocating any containers.
+func (c *client) PerformDryRun(logger lager.Logger, work Work) (Work, error) {
+	return c.postWork(PerformDryRunRoute, work)
+}
+
+// Reserve asks the cell to hold capacity for a subsequent Perform. The
+// returned reservation ID should be set on the Work passed to Perform.
+func (c *client) Reserve(logger lager.Logger, request ReservationRequest) (Reservation, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	req, err := c.requestGenerator.CreateRequest(ReserveRoute, nil, bytes.NewReader(body))
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Reservation{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+	case http.StatusConflict:
+		return Reservation{}, ErrReservationRejected
+	default:
+		return Reservation{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var reservation Reservation
+	err = json.NewDecoder(resp.Body).Decode(&reservation)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Prewarm asks the cell to fetch rootfs images and cached dependencies in the
+// background. It returns once the cell has accepted the request, not once the
+// images are warm; warm images are advertised in the cell's State.
+func (c *client) Prewarm(logger lager.Logger, request PrewarmRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.requestGenerator.CreateRequest(PrewarmRoute, nil, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return nil
+	case http.StatusConflict:
+		return ErrPrewarmRejected
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// postWork sends the work in protobuf once the cell is known to support it,
+// and in JSON otherwise.
+func (c *client) postWork(route string, work Work) (Work, error) {
+	c.protobufLock.Lock()
+	useProtobuf := c.protobuf
+	c.protobufLock.Unlock()
+
+	var body []byte
+	var err error
+	contentType := JSONContentType
+	if useProtobuf {
+		body, err = work.MarshalProtobuf()
+		contentType = ProtobufContentType
+	} else {
+		body, err = json.Marshal(work)
+	}
 	if err != nil {
 		return Work{}, err
 	}
 
-	req, err := c.requestGenerator.CreateRequest(PerformRoute, nil, bytes.NewReader(body))
+	req, err := c.requestGenerator.CreateRequest(route, nil, bytes.NewReader(body))
 	if err != nil {
 		return Work{}, err
 	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", acceptProtobuf)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -228,15 +443,29 @@ func (c *client) Perform(logger lager.Logger, work Work) (Work, error) {
 		return Work{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Work{}, err
+	}
+
 	var failedWork Work
-	err = json.NewDecoder(resp.Body).Decode(&failedWork)
+	err = unmarshalBody(resp.Header.Get("Content-Type"), bs, &failedWork)
 	if err != nil {
 		return Work{}, err
 	}
+	c.setProtobuf(resp.Header.Get("Content-Type"))
 
 	return failedWork, nil
 }
 
+// setProtobuf records whether the cell replied in protobuf, so that a cell
+// that is rolled back to a version without protobuf is sent JSON again.
+func (c *client) setProtobuf(contentType string) {
+	c.protobufLock.Lock()
+	c.protobuf = IsProtobufContentType(contentType)
+	c.protobufLock.Unlock()
+}
+
 func (c *client) Reset() error {
 	req, err := c.requestGenerator.CreateRequest(SimResetRoute, nil, nil)
 	if err != nil {
@@ -260,6 +489,15 @@ func (c *client) StopLRPInstance(
 	logger lager.Logger,
 	key models.ActualLRPKey,
 	instanceKey models.ActualLRPInstanceKey,
+) error {
+	return c.StopLRPInstanceWithOptions(logger, key, instanceKey, StopLRPInstanceRequest{})
+}
+
+func (c *client) StopLRPInstanceWithOptions(
+	logger lager.Logger,
+	key models.ActualLRPKey,
+	instanceKey models.ActualLRPInstanceKey,
+	request StopLRPInstanceRequest,
 ) error {
 	start := time.Now()
 	logger = logger.Session("stop-lrp", lager.Data{"process-guid": key.ProcessGuid,
@@ -269,7 +507,17 @@ func (c *client) StopLRPInstance(
 	})
 	logger.Info("starting")
 
-	req, err := c.requestGenerator.CreateRequest(StopLRPInstanceRoute, stopParamsFromLRP(key, instanceKey), nil)
+	var body io.Reader
+	if request != (StopLRPInstanceRequest{}) {
+		payload, err := json.Marshal(request)
+		if err != nil {
+			logger.Error("failed-to-marshal-request", err)
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := c.requestGenerator.CreateRequest(StopLRPInstanceRoute, stopParamsFromLRP(key, instanceKey), body)
 	if err != nil {
 		logger.Error("connection-failed", err)
 		return err
@@ -284,6 +532,11 @@ func (c *client) StopLRPInstance(
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		logger.Error("stop-options-unsupported", ErrStopOptionsUnsupported)
+		return ErrStopOptionsUnsupported
+	}
+
 	if resp.StatusCode != http.StatusAccepted {
 		err := fmt.Errorf("http error: status code %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
 		logger.Error("failed-with-status", err, lager.Data{"status-code": resp.StatusCode, "msg": http.StatusText(resp.StatusCode)})
@@ -294,12 +547,20 @@ func (c *client) StopLRPInstance(
 	return nil
 }
 
-func (c *client) CancelTask(logger lager.Logger, taskGuid string) error {
+func (c *client) RestartLRPInstance(
+	logger lager.Logger,
+	key models.ActualLRPKey,
+	instanceKey models.ActualLRPInstanceKey,
+) error {
 	start := time.Now()
-	logger = logger.Session("cancel-task", lager.Data{"task-guid": taskGuid})
+	logger = logger.Session("restart-lrp", lager.Data{"process-guid": key.ProcessGuid,
+		"index":        key.Index,
+		"domain":       key.Domain,
+		"instance-key": instanceKey,
+	})
 	logger.Info("starting")
 
-	req, err := c.requestGenerator.CreateRequest(CancelTaskRoute, rata.Params{"task_guid": taskGuid}, nil)
+	req, err := c.requestGenerator.CreateRequest(RestartLRPInstanceRoute, stopParamsFromLRP(key, instanceKey), nil)
 	if err != nil {
 		logger.Error("connection-failed", err)
 		return err
@@ -310,10 +571,17 @@ func (c *client) CancelTask(logger lager.Logger, taskGuid string) error {
 		logger.Error("request-failed", err)
 		return err
 	}
-
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusAccepted {
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+	case http.StatusNotFound:
+		return ErrContainerNotFound
+	case http.StatusConflict:
+		return ErrLRPInstanceNotRunning
+	case http.StatusServiceUnavailable:
+		return ErrRestartRejected
+	default:
 		err := fmt.Errorf("http error: status code %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
 		logger.Error("failed-with-status", err, lager.Data{"status-code": resp.StatusCode, "msg": http.StatusText(resp.StatusCode)})
 		return err
@@ -323,6 +591,193 @@ func (c *client) CancelTask(logger lager.Logger, taskGuid string) error {
 	return nil
 }
 
+func (c *client) CancelTask(logger lager.Logger, taskGuid string) error {
+	_, err := c.CancelTaskWithStatus(logger, taskGuid, 0)
+	return err
+}
+
+// CancelTaskWithStatus cancels the task and reports what happened to its
+// container. With a positive waitTimeout, the cell waits up to that long for
+// the container to be deleted before responding.
+func (c *client) CancelTaskWithStatus(logger lager.Logger, taskGuid string, waitTimeout time.Duration) (CancelTaskStatus, error) {
+	start := time.Now()
+	logger = logger.Session("cancel-task", lager.Data{"task-guid": taskGuid})
+	logger.Info("starting")
+
+	req, err := c.requestGenerator.CreateRequest(CancelTaskRoute, rata.Params{"task_guid": taskGuid}, nil)
+	if err != nil {
+		logger.Error("connection-failed", err)
+		return CancelTaskStatus{}, err
+	}
+
+	if waitTimeout > 0 {
+		req.URL.RawQuery = url.Values{"wait": []string{"true"}, "timeout": []string{waitTimeout.String()}}.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		logger.Error("request-failed", err)
+		return CancelTaskStatus{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		err := fmt.Errorf("http error: status code %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		logger.Error("failed-with-status", err, lager.Data{"status-code": resp.StatusCode, "msg": http.StatusText(resp.StatusCode)})
+		return CancelTaskStatus{}, err
+	}
+
+	var status CancelTaskStatus
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("failed-to-read-response", err)
+		return CancelTaskStatus{}, err
+	}
+
+	// cells that predate the status respond with an empty body
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &status)
+		if err != nil {
+			logger.Error("failed-to-decode-response", err)
+			return CancelTaskStatus{}, err
+		}
+	}
+
+	logger.Info("completed", lager.Data{"duration": time.Since(start), "status": status})
+	return status, nil
+}
+
+func (c *client) StopLRPInstances(logger lager.Logger, instances []LRPInstanceKey) (map[string]BulkResult, error) {
+	logger = logger.Session("stop-lrps", lager.Data{"count": len(instances)})
+	return c.bulk(logger, StopLRPInstancesRoute, StopLRPInstancesRequest{Instances: instances})
+}
+
+func (c *client) CancelTasks(logger lager.Logger, taskGuids []string) (map[string]BulkResult, error) {
+	logger = logger.Session("cancel-tasks", lager.Data{"count": len(taskGuids)})
+	return c.bulk(logger, CancelTasksRoute, CancelTasksRequest{TaskGuids: taskGuids})
+}
+
+func (c *client) bulk(logger lager.Logger, route string, request interface{}) (map[string]BulkResult, error) {
+	start := time.Now()
+	logger.Info("starting")
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		logger.Error("failed-to-marshal-request", err)
+		return nil, err
+	}
+
+	req, err := c.requestGenerator.CreateRequest(route, nil, bytes.NewReader(body))
+	if err != nil {
+		logger.Error("connection-failed", err)
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		logger.Error("request-failed", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("http error: status code %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		logger.Error("failed-with-status", err, lager.Data{"status-code": resp.StatusCode, "msg": http.StatusText(resp.StatusCode)})
+		return nil, err
+	}
+
+	var response BulkResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		logger.Error("failed-to-decode-response", err)
+		return nil, err
+	}
+
+	logger.Info("completed", lager.Data{"duration": time.Since(start)})
+	return response.Results, nil
+}
+
+// LRPInstanceDetail fetches the state of a single LRP instance's container.
+// It returns ErrContainerNotFound if the cell is not running the instance.
+func (c *client) LRPInstanceDetail(logger lager.Logger, processGuid, instanceGuid string) (ContainerDetail, error) {
+	return c.containerDetail(LRPInstanceDetailRoute, rata.Params{
+		"process_guid":  processGuid,
+		"instance_guid": instanceGuid,
+	})
+}
+
+// TaskDetail fetches the state of a single task's container. It returns
+// ErrContainerNotFound if the cell is not running the task.
+func (c *client) TaskDetail(logger lager.Logger, taskGuid string) (ContainerDetail, error) {
+	return c.containerDetail(TaskDetailRoute, rata.Params{"task_guid": taskGuid})
+}
+
+func (c *client) containerDetail(route string, params rata.Params) (ContainerDetail, error) {
+	req, err := c.requestGenerator.CreateRequest(route, params, nil)
+	if err != nil {
+		return ContainerDetail{}, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return ContainerDetail{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ContainerDetail{}, ErrContainerNotFound
+	default:
+		return ContainerDetail{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var detail ContainerDetail
+	err = json.NewDecoder(resp.Body).Decode(&detail)
+	if err != nil {
+		return ContainerDetail{}, err
+	}
+
+	return detail, nil
+}
+
+// ContainerDiagnostics fetches the diagnostic bundle of a container as a tar
+// stream, including up to resultFileLines trailing lines of its result file.
+// The caller must close the stream. It returns ErrContainerNotFound if the
+// cell has no such container.
+func (c *client) ContainerDiagnostics(logger lager.Logger, guid string, resultFileLines int) (io.ReadCloser, error) {
+	logger = logger.Session("container-diagnostics", lager.Data{"guid": guid})
+
+	req, err := c.requestGenerator.CreateRequest(ContainerDiagnosticsRoute, rata.Params{"guid": guid}, nil)
+	if err != nil {
+		logger.Error("connection-failed", err)
+		return nil, err
+	}
+	req.URL.RawQuery = url.Values{"lines": []string{strconv.Itoa(resultFileLines)}}.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		logger.Error("request-failed", err)
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrContainerNotFound
+	default:
+		resp.Body.Close()
+		err := fmt.Errorf("http error: status code %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		logger.Error("failed-with-status", err, lager.Data{"status-code": resp.StatusCode})
+		return nil, err
+	}
+}
+
 func stopParamsFromLRP(
 	key models.ActualLRPKey,
 	instanceKey models.ActualLRPInstanceKey,
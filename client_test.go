@@ -1,6 +1,9 @@
 package rep_test
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
@@ -9,6 +12,7 @@ import (
 
 	"code.cloudfoundry.org/bbs/models"
 	cfhttp "code.cloudfoundry.org/cfhttp/v2"
+	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/lager/lagertest"
 	"code.cloudfoundry.org/rep"
 
@@ -118,6 +122,644 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("State caching", func() {
+		var (
+			logger = lagertest.NewTestLogger("test")
+			state  rep.CellState
+		)
+
+		BeforeEach(func() {
+			state = rep.CellState{CellID: "cell-id", Generation: 42}
+		})
+
+		Context("when the cell sends an entity tag", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/state"),
+						func(w http.ResponseWriter, req *http.Request) {
+							Expect(req.Header.Get("If-None-Match")).To(BeEmpty())
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusOK, state, http.Header{"ETag": []string{`"42"`}}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/state"),
+						ghttp.VerifyHeaderKV("If-None-Match", `"42"`),
+						ghttp.RespondWith(http.StatusNotModified, ""),
+					),
+				)
+			})
+
+			It("sends the tag with the next request and returns the cached state when it is not modified", func() {
+				actualState, err := client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualState).To(Equal(state))
+
+				actualState, err = client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualState).To(Equal(state))
+				Expect(fakeServer.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the cell does not send an entity tag", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+					ghttp.CombineHandlers(
+						func(w http.ResponseWriter, req *http.Request) {
+							Expect(req.Header.Get("If-None-Match")).To(BeEmpty())
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+					),
+				)
+			})
+
+			It("does not send a tag", func() {
+				_, err := client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the cell responds not modified without a cached state", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusNotModified, ""))
+			})
+
+			It("returns an error", func() {
+				_, err := client.State(logger)
+				Expect(err).To(MatchError("unexpected status code: 304"))
+			})
+		})
+	})
+
+	Describe("QueryState", func() {
+		var (
+			logger = lagertest.NewTestLogger("test")
+			query  rep.StateQuery
+			state  rep.CellState
+		)
+
+		BeforeEach(func() {
+			query = rep.StateQuery{Summary: true, Domain: "cf-apps"}
+			state = rep.CellState{CellID: "cell-id", Generation: 42}
+		})
+
+		It("sends the query parameters", func() {
+			fakeServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/state", "domain=cf-apps&summary=true"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+				),
+			)
+
+			actualState, err := client.QueryState(logger, query)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualState).To(Equal(state))
+		})
+
+		It("caches the responses to each query separately", func() {
+			fakeServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/state", "domain=cf-apps&summary=true"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, state, http.Header{"ETag": []string{query.ETag(42)}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/state"),
+					func(w http.ResponseWriter, req *http.Request) {
+						Expect(req.Header.Get("If-None-Match")).To(BeEmpty())
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, state, http.Header{"ETag": []string{`"42"`}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/state", "domain=cf-apps&summary=true"),
+					ghttp.VerifyHeaderKV("If-None-Match", query.ETag(42)),
+					ghttp.RespondWith(http.StatusNotModified, ""),
+				),
+			)
+
+			_, err := client.QueryState(logger, query)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = client.State(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			actualState, err := client.QueryState(logger, query)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualState).To(Equal(state))
+		})
+	})
+
+	Describe("Perform", func() {
+		var (
+			logger     = lagertest.NewTestLogger("test")
+			work       rep.Work
+			failedWork rep.Work
+		)
+
+		BeforeEach(func() {
+			lrp := rep.NewLRP("some-instance-guid", models.NewActualLRPKey("some-process-guid", 0, "domain"), rep.NewResource(10, 20, 30), rep.NewPlacementConstraint("rootfs", nil, nil))
+			work = rep.Work{LRPs: []rep.LRP{lrp}}
+
+			lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, "insufficient resources for container")
+			failedWork = rep.Work{LRPs: []rep.LRP{lrp}}
+
+			fakeServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/work"),
+					ghttp.VerifyJSONRepresenting(work),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, failedWork),
+				),
+			)
+		})
+
+		It("returns the failed work along with the reasons it failed", func() {
+			actualWork, err := client.Perform(logger, work)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualWork).To(Equal(failedWork))
+		})
+	})
+
+	Describe("protobuf negotiation", func() {
+		var (
+			logger     = lagertest.NewTestLogger("test")
+			state      rep.CellState
+			work       rep.Work
+			failedWork rep.Work
+		)
+
+		protobufFor := func(message interface{ MarshalProtobuf() ([]byte, error) }) string {
+			body, err := message.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+			return string(body)
+		}
+
+		protobufHeader := http.Header{"Content-Type": []string{rep.ProtobufContentType}}
+
+		BeforeEach(func() {
+			state = rep.CellState{CellID: "cell-id", Zone: "z1", Generation: 42}
+
+			lrp := rep.NewLRP("some-instance-guid", models.NewActualLRPKey("some-process-guid", 0, "domain"), rep.NewResource(10, 20, 30), rep.NewPlacementConstraint("rootfs", nil, nil))
+			work = rep.Work{LRPs: []rep.LRP{lrp}}
+
+			lrp.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonAllocationFailed, "insufficient resources for container")
+			failedWork = rep.Work{LRPs: []rep.LRP{lrp}}
+		})
+
+		Context("when the cell supports protobuf", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/state"),
+						ghttp.VerifyHeaderKV("Accept", "application/x-protobuf, application/json;q=0.9"),
+						ghttp.RespondWith(http.StatusOK, protobufFor(&state), http.Header{
+							"Content-Type": []string{rep.ProtobufContentType},
+							"ETag":         []string{`"42"`},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/state"),
+						ghttp.RespondWith(http.StatusNotModified, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/work"),
+						ghttp.VerifyContentType(rep.ProtobufContentType),
+						func(w http.ResponseWriter, req *http.Request) {
+							body, err := ioutil.ReadAll(req.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							var actualWork rep.Work
+							Expect(actualWork.UnmarshalProtobuf(body)).To(Succeed())
+							Expect(actualWork).To(Equal(work))
+						},
+						ghttp.RespondWith(http.StatusOK, protobufFor(&failedWork), protobufHeader),
+					),
+				)
+			})
+
+			It("decodes the state and sends work in protobuf", func() {
+				actualState, err := client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualState).To(Equal(state))
+
+				actualState, err = client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualState).To(Equal(state))
+
+				actualWork, err := client.Perform(logger, work)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualWork).To(Equal(failedWork))
+			})
+		})
+
+		Context("when the cell does not support protobuf", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/state"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, state),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/work"),
+						ghttp.VerifyJSONRepresenting(work),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, failedWork),
+					),
+				)
+			})
+
+			It("keeps using JSON", func() {
+				actualState, err := client.State(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualState).To(Equal(state))
+
+				actualWork, err := client.Perform(logger, work)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualWork).To(Equal(failedWork))
+			})
+		})
+	})
+
+	Describe("PerformDryRun", func() {
+		var (
+			logger     = lagertest.NewTestLogger("test")
+			work       rep.Work
+			failedWork rep.Work
+			dryRunErr  error
+		)
+
+		BeforeEach(func() {
+			task := rep.NewTask("some-task-guid", "domain", rep.NewResource(10, 20, 30), rep.NewPlacementConstraint("rootfs", nil, nil))
+			work = rep.Work{Tasks: []rep.Task{task}}
+
+			task.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonRootFSNotFound, "preloaded rootfs path not found")
+			failedWork = rep.Work{Tasks: []rep.Task{task}}
+		})
+
+		JustBeforeEach(func() {
+			var actualWork rep.Work
+			actualWork, dryRunErr = client.PerformDryRun(logger, work)
+			if dryRunErr == nil {
+				Expect(actualWork).To(Equal(failedWork))
+			}
+		})
+
+		Context("when the request is successful", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/work/dry_run"),
+						ghttp.VerifyJSONRepresenting(work),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, failedWork),
+					),
+				)
+			})
+
+			It("returns the work that would fail along with the reasons", func() {
+				Expect(dryRunErr).NotTo(HaveOccurred())
+				Expect(fakeServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/work/dry_run"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(dryRunErr).To(MatchError("unexpected status code: 500"))
+			})
+		})
+	})
+
+	Describe("Reserve", func() {
+		var (
+			logger      = lagertest.NewTestLogger("test")
+			request     rep.ReservationRequest
+			reservation rep.Reservation
+		)
+
+		BeforeEach(func() {
+			request = rep.ReservationRequest{CellID: "some-cell-id", MemoryMB: 512, DiskMB: 1024, Containers: 1}
+			reservation = rep.Reservation{ID: "some-reservation-id", MemoryMB: 512, DiskMB: 1024, Containers: 1, ExpiresAt: 1000}
+		})
+
+		Context("when the cell accepts the reservation", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/reservations"),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, reservation),
+					),
+				)
+			})
+
+			It("returns the reservation", func() {
+				actualReservation, err := client.Reserve(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualReservation).To(Equal(reservation))
+			})
+		})
+
+		Context("when the cell rejects the reservation", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/reservations"),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns ErrReservationRejected", func() {
+				_, err := client.Reserve(logger, request)
+				Expect(err).To(Equal(rep.ErrReservationRejected))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/reservations"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Reserve(logger, request)
+				Expect(err).To(MatchError("unexpected status code: 500"))
+			})
+		})
+	})
+
+	Describe("StreamState", func() {
+		var logger = lagertest.NewTestLogger("test")
+
+		Context("when the cell streams its state", func() {
+			BeforeEach(func() {
+				body := &bytes.Buffer{}
+				Expect(rep.WriteStateEvent(body, rep.NewStateSnapshotEvent(rep.CellState{CellID: "cell-id"}))).To(Succeed())
+				Expect(rep.WriteStateEvent(body, rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true})).To(Succeed())
+
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/state/events"),
+						ghttp.VerifyHeaderKV("Accept", "text/event-stream"),
+						ghttp.RespondWith(http.StatusOK, body.String(), http.Header{"Content-Type": []string{"text/event-stream"}}),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				source, err := client.StreamState(logger)
+				Expect(err).NotTo(HaveOccurred())
+				defer source.Close()
+
+				event, err := source.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(Equal(rep.NewStateSnapshotEvent(rep.CellState{CellID: "cell-id"})))
+
+				event, err = source.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(Equal(rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true}))
+
+				_, err = source.Next()
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/state/events"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.StreamState(logger)
+				Expect(err).To(MatchError("unexpected status code: 500"))
+			})
+		})
+	})
+
+	Describe("Prewarm", func() {
+		var (
+			logger  = lagertest.NewTestLogger("test")
+			request rep.PrewarmRequest
+		)
+
+		BeforeEach(func() {
+			request = rep.PrewarmRequest{
+				RootFSes: []string{"preloaded:linux"},
+				CachedDependencies: []*models.CachedDependency{
+					{From: "http://example.com/buildpack.zip", To: "/tmp/buildpack", CacheKey: "buildpack"},
+				},
+			}
+		})
+
+		Context("when the cell accepts the request", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/prewarm"),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("succeeds", func() {
+				err := client.Prewarm(logger, request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the cell rejects the request", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/prewarm"),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns ErrPrewarmRejected", func() {
+				err := client.Prewarm(logger, request)
+				Expect(err).To(Equal(rep.ErrPrewarmRejected))
+			})
+		})
+
+		Context("when the request returns 400", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/prewarm"),
+						ghttp.RespondWith(http.StatusBadRequest, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				err := client.Prewarm(logger, request)
+				Expect(err).To(MatchError("unexpected status code: 400"))
+			})
+		})
+	})
+
+	Describe("LRPInstanceDetail", func() {
+		var (
+			logger = lagertest.NewTestLogger("test")
+			detail rep.ContainerDetail
+		)
+
+		BeforeEach(func() {
+			detail = rep.ContainerDetail{
+				Guid:         "some-instance-guid",
+				Lifecycle:    rep.LRPLifecycle,
+				ProcessGuid:  "some-process-guid",
+				InstanceGuid: "some-instance-guid",
+				State:        executor.StateRunning,
+			}
+		})
+
+		Context("when the cell has the container", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/lrps/some-process-guid/instances/some-instance-guid"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, detail),
+					),
+				)
+			})
+
+			It("returns the container detail", func() {
+				actualDetail, err := client.LRPInstanceDetail(logger, "some-process-guid", "some-instance-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualDetail).To(Equal(detail))
+			})
+		})
+
+		Context("when the cell does not have the container", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+			})
+
+			It("returns ErrContainerNotFound", func() {
+				_, err := client.LRPInstanceDetail(logger, "some-process-guid", "some-instance-guid")
+				Expect(err).To(Equal(rep.ErrContainerNotFound))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+			})
+
+			It("returns an error", func() {
+				_, err := client.LRPInstanceDetail(logger, "some-process-guid", "some-instance-guid")
+				Expect(err).To(MatchError("unexpected status code: 500"))
+			})
+		})
+	})
+
+	Describe("TaskDetail", func() {
+		var logger = lagertest.NewTestLogger("test")
+
+		Context("when the cell has the container", func() {
+			var detail rep.ContainerDetail
+
+			BeforeEach(func() {
+				detail = rep.ContainerDetail{
+					Guid:      "some-task-guid",
+					Lifecycle: rep.TaskLifecycle,
+					TaskGuid:  "some-task-guid",
+					State:     executor.StateCompleted,
+				}
+
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/tasks/some-task-guid"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, detail),
+					),
+				)
+			})
+
+			It("returns the container detail", func() {
+				actualDetail, err := client.TaskDetail(logger, "some-task-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualDetail).To(Equal(detail))
+			})
+		})
+
+		Context("when the cell does not have the container", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+			})
+
+			It("returns ErrContainerNotFound", func() {
+				_, err := client.TaskDetail(logger, "some-task-guid")
+				Expect(err).To(Equal(rep.ErrContainerNotFound))
+			})
+		})
+	})
+
+	Describe("ContainerDiagnostics", func() {
+		var logger = lagertest.NewTestLogger("test")
+
+		Context("when the cell has the container", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v1/containers/some-guid/diagnostics", "lines=25"),
+						ghttp.RespondWith(http.StatusOK, "some-tar-stream"),
+					),
+				)
+			})
+
+			It("returns the diagnostics stream", func() {
+				stream, err := client.ContainerDiagnostics(logger, "some-guid", 25)
+				Expect(err).NotTo(HaveOccurred())
+				defer stream.Close()
+
+				Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-tar-stream")))
+			})
+		})
+
+		Context("when the cell does not have the container", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+			})
+
+			It("returns ErrContainerNotFound", func() {
+				_, err := client.ContainerDiagnostics(logger, "some-guid", 25)
+				Expect(err).To(Equal(rep.ErrContainerNotFound))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+			})
+
+			It("returns an error", func() {
+				_, err := client.ContainerDiagnostics(logger, "some-guid", 25)
+				Expect(err).To(MatchError("http error: status code 500 (Internal Server Error)"))
+			})
+		})
+	})
+
 	Describe("StopLRPInstance", func() {
 		const cellAddr = "cell.example.com"
 		var (
@@ -219,6 +861,172 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("StopLRPInstanceWithOptions", func() {
+		var (
+			logger    = lagertest.NewTestLogger("test")
+			request   rep.StopLRPInstanceRequest
+			stopErr   error
+			actualLRP = models.ActualLRP{
+				ActualLRPKey:         models.NewActualLRPKey("some-process-guid", 2, "test-domain"),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey("some-instance-guid", "some-cell-id"),
+			}
+		)
+
+		BeforeEach(func() {
+			request = rep.StopLRPInstanceRequest{Reason: "draining"}
+		})
+
+		JustBeforeEach(func() {
+			stopErr = client.StopLRPInstanceWithOptions(logger, actualLRP.ActualLRPKey, actualLRP.ActualLRPInstanceKey, request)
+		})
+
+		Context("when the request is successful", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/stop"),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("sends the reason and does not return an error", func() {
+				Expect(stopErr).NotTo(HaveOccurred())
+				Expect(fakeServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the request is empty", func() {
+			BeforeEach(func() {
+				request = rep.StopLRPInstanceRequest{}
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/stop"),
+						func(w http.ResponseWriter, r *http.Request) {
+							body, err := ioutil.ReadAll(r.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(body).To(BeEmpty())
+						},
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("sends no body", func() {
+				Expect(stopErr).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the cell cannot honour the grace period or signal", func() {
+			BeforeEach(func() {
+				request = rep.StopLRPInstanceRequest{GracePeriodInSeconds: 30, Signal: rep.StopSignalQuit}
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/stop"),
+						ghttp.VerifyJSONRepresenting(request),
+						ghttp.RespondWith(http.StatusUnprocessableEntity, rep.ErrStopOptionsUnsupported.Error()),
+					),
+				)
+			})
+
+			It("returns ErrStopOptionsUnsupported", func() {
+				Expect(stopErr).To(Equal(rep.ErrStopOptionsUnsupported))
+			})
+		})
+	})
+
+	Describe("RestartLRPInstance", func() {
+		var (
+			logger     = lagertest.NewTestLogger("test")
+			restartErr error
+			actualLRP  = models.ActualLRP{
+				ActualLRPKey:         models.NewActualLRPKey("some-process-guid", 2, "test-domain"),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey("some-instance-guid", "some-cell-id"),
+			}
+		)
+
+		JustBeforeEach(func() {
+			restartErr = client.RestartLRPInstance(logger, actualLRP.ActualLRPKey, actualLRP.ActualLRPInstanceKey)
+		})
+
+		Context("when the request is successful", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/restart"),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("makes the request and does not return an error", func() {
+				Expect(restartErr).NotTo(HaveOccurred())
+				Expect(fakeServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the instance is not on the cell", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/restart"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns ErrContainerNotFound", func() {
+				Expect(restartErr).To(Equal(rep.ErrContainerNotFound))
+			})
+		})
+
+		Context("when the instance is not running", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/restart"),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns ErrLRPInstanceNotRunning", func() {
+				Expect(restartErr).To(Equal(rep.ErrLRPInstanceNotRunning))
+			})
+		})
+
+		Context("when the cell is evacuating", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/restart"),
+						ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+					),
+				)
+			})
+
+			It("returns ErrRestartRejected", func() {
+				Expect(restartErr).To(Equal(rep.ErrRestartRejected))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/some-process-guid/instances/some-instance-guid/restart"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(restartErr).To(MatchError("http error: status code 500 (Internal Server Error)"))
+			})
+		})
+	})
+
 	Describe("CancelTask", func() {
 		const cellAddr = "cell.example.com"
 		var (
@@ -316,4 +1124,196 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("CancelTaskWithStatus", func() {
+		var (
+			logger      = lagertest.NewTestLogger("test")
+			waitTimeout time.Duration
+			status      rep.CancelTaskStatus
+			cancelErr   error
+		)
+
+		BeforeEach(func() {
+			waitTimeout = 0
+		})
+
+		JustBeforeEach(func() {
+			status, cancelErr = client.CancelTaskWithStatus(logger, "some-task-guid", waitTimeout)
+		})
+
+		Context("when not waiting", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/some-task-guid/cancel", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusAccepted, rep.CancelTaskStatus{Existed: true, State: executor.StateRunning}),
+					),
+				)
+			})
+
+			It("returns the status of the container", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(status).To(Equal(rep.CancelTaskStatus{Existed: true, State: executor.StateRunning}))
+			})
+		})
+
+		Context("when waiting", func() {
+			BeforeEach(func() {
+				waitTimeout = 10 * time.Second
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/some-task-guid/cancel", "timeout=10s&wait=true"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, rep.CancelTaskStatus{Existed: true, State: executor.StateRunning, Deleted: true}),
+					),
+				)
+			})
+
+			It("asks the cell to wait for the deletion", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(status.Deleted).To(BeTrue())
+			})
+		})
+
+		Context("when the cell predates the status", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/some-task-guid/cancel"),
+						ghttp.RespondWith(http.StatusAccepted, ""),
+					),
+				)
+			})
+
+			It("returns an empty status", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(status).To(Equal(rep.CancelTaskStatus{}))
+			})
+		})
+
+		Context("when the deletion fails", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/some-task-guid/cancel"),
+						ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, rep.CancelTaskStatus{Existed: true, Error: "boom"}),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(cancelErr).To(MatchError("http error: status code 500 (Internal Server Error)"))
+			})
+		})
+	})
+
+	Describe("StopLRPInstances", func() {
+		var (
+			logger    = lagertest.NewTestLogger("test")
+			instances []rep.LRPInstanceKey
+			results   map[string]rep.BulkResult
+			stopErr   error
+		)
+
+		BeforeEach(func() {
+			instances = []rep.LRPInstanceKey{
+				rep.NewLRPInstanceKey(models.NewActualLRPKey("pg-1", 0, "domain"), models.NewActualLRPInstanceKey("ig-1", "cell-id")),
+				rep.NewLRPInstanceKey(models.NewActualLRPKey("pg-1", 1, "domain"), models.NewActualLRPInstanceKey("ig-2", "cell-id")),
+			}
+		})
+
+		JustBeforeEach(func() {
+			results, stopErr = client.StopLRPInstances(logger, instances)
+		})
+
+		Context("when the request is successful", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/stop"),
+						ghttp.VerifyJSONRepresenting(rep.StopLRPInstancesRequest{Instances: []rep.LRPInstanceKey{
+							{ProcessGuid: "pg-1", InstanceGuid: "ig-1"},
+							{ProcessGuid: "pg-1", InstanceGuid: "ig-2"},
+						}}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, rep.BulkResponse{Results: map[string]rep.BulkResult{
+							"ig-1": {},
+							"ig-2": {Error: "boom"},
+						}}),
+					),
+				)
+			})
+
+			It("returns the result of each instance", func() {
+				Expect(stopErr).NotTo(HaveOccurred())
+				Expect(results).To(Equal(map[string]rep.BulkResult{
+					"ig-1": {},
+					"ig-2": {Error: "boom"},
+				}))
+				Expect(results["ig-1"].Succeeded()).To(BeTrue())
+				Expect(results["ig-2"].Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("when the request returns 400", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/lrps/stop"),
+						ghttp.RespondWith(http.StatusBadRequest, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(stopErr).To(MatchError("http error: status code 400 (Bad Request)"))
+				Expect(results).To(BeNil())
+			})
+		})
+	})
+
+	Describe("CancelTasks", func() {
+		var (
+			logger    = lagertest.NewTestLogger("test")
+			results   map[string]rep.BulkResult
+			cancelErr error
+		)
+
+		JustBeforeEach(func() {
+			results, cancelErr = client.CancelTasks(logger, []string{"task-1", "task-2"})
+		})
+
+		Context("when the request is successful", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/cancel"),
+						ghttp.VerifyJSONRepresenting(rep.CancelTasksRequest{TaskGuids: []string{"task-1", "task-2"}}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, rep.BulkResponse{Results: map[string]rep.BulkResult{
+							"task-1": {},
+							"task-2": {},
+						}}),
+					),
+				)
+			})
+
+			It("returns the result of each task", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(results).To(Equal(map[string]rep.BulkResult{"task-1": {}, "task-2": {}}))
+			})
+		})
+
+		Context("when the request returns 500", func() {
+			BeforeEach(func() {
+				fakeServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v1/tasks/cancel"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(cancelErr).To(MatchError("http error: status code 500 (Internal Server Error)"))
+			})
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -66,6 +67,23 @@ func (rootFSes RootFSes) StackPathMap() rep.StackPathMap {
 	return m
 }
 
+// LoadStacksManifest reads preloaded rootfses from a JSON file containing a
+// list of 'stack-name:path' values, in the same format as preloaded_root_fs.
+func LoadStacksManifest(manifestPath string) (RootFSes, error) {
+	payload, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rootFSes RootFSes
+	err = json.Unmarshal(payload, &rootFSes)
+	if err != nil {
+		return nil, err
+	}
+
+	return rootFSes, nil
+}
+
 func (m RootFSes) MarshalJSON() (b []byte, err error) {
 	arr := make([]string, len(m))
 	for i, rootFS := range m {
@@ -86,10 +104,12 @@ type RepConfig struct {
 	CellID                          string                `json:"cell_id"`
 	CellIndex                       int                   `json:"cell_index"`
 	CommunicationTimeout            durationjson.Duration `json:"communication_timeout,omitempty"`
+	CPUWeightCapacity               int                   `json:"cpu_weight_capacity,omitempty"`
 	ConsulCACert                    string                `json:"consul_ca_cert"`
 	ConsulClientCert                string                `json:"consul_client_cert"`
 	ConsulClientKey                 string                `json:"consul_client_key"`
 	ConsulCluster                   string                `json:"consul_cluster"`
+	DomainQuotas                    rep.DomainQuotas      `json:"domain_quotas,omitempty"`
 	EnableConsulServiceRegistration bool                  `json:"enable_consul_service_registration,omitempty"`
 	EvacuationPollingInterval       durationjson.Duration `json:"evacuation_polling_interval,omitempty"`
 	EvacuationTimeout               durationjson.Duration `json:"evacuation_timeout,omitempty"`
@@ -99,17 +119,27 @@ type RepConfig struct {
 	LockRetryInterval               durationjson.Duration `json:"lock_retry_interval,omitempty"`
 	LockTTL                         durationjson.Duration `json:"lock_ttl,omitempty"`
 	OptionalPlacementTags           []string              `json:"optional_placement_tags"`
+	PidCapacity                     int                   `json:"pid_capacity,omitempty"`
+	PlacementLabels                 map[string]string     `json:"placement_labels,omitempty"`
 	PlacementTags                   []string              `json:"placement_tags"`
 	PollingInterval                 durationjson.Duration `json:"polling_interval,omitempty"`
 	PreloadedRootFS                 RootFSes              `json:"preloaded_root_fs"`
+	PreloadedRootFSVersionRanges    map[string]string     `json:"preloaded_root_fs_version_ranges,omitempty"`
+	RootFSProviders                 rep.RootFSProviders   `json:"root_fs_providers,omitempty"`
+	ScoringStrategy                 string                `json:"scoring_strategy,omitempty"`
+	ScoringWeights                  *rep.ScoringWeights   `json:"scoring_weights,omitempty"`
 	ServerCertFile                  string                `json:"server_cert_file"` // DEPRECATED. Kept around for dusts compatability
 	ServerKeyFile                   string                `json:"server_key_file"`  // DEPRECATED. Kept around for dusts compatability
 	CertFile                        string                `json:"cert_file"`
 	KeyFile                         string                `json:"key_file"`
 	SessionName                     string                `json:"session_name,omitempty"`
+	StacksManifestFile              string                `json:"stacks_manifest_file,omitempty"`
+	StacksPollInterval              durationjson.Duration `json:"stacks_poll_interval,omitempty"`
+	StacksVerifyInterval            durationjson.Duration `json:"stacks_verify_interval,omitempty"`
 	SupportedProviders              []string              `json:"supported_providers"`
 	Zone                            string                `json:"zone"`
 	ReportInterval                  durationjson.Duration `json:"report_interval,omitempty"`
+	ReservationTTL                  durationjson.Duration `json:"reservation_ttl,omitempty"`
 	LoggregatorConfig               loggingclient.Config  `json:"loggregator"`
 	CellRegistrationsLocketEnabled  bool                  `json:"cell_registrations_locket_enabled"`
 	debugserver.DebugServerConfig
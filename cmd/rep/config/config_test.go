@@ -12,6 +12,7 @@ import (
 	executorinit "code.cloudfoundry.org/executor/initializer"
 	"code.cloudfoundry.org/lager/lagerflags"
 	"code.cloudfoundry.org/locket"
+	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/cmd/rep/config"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -37,6 +38,7 @@ var _ = Describe("RepConfig", func() {
 			"consul_client_key": "/tmp/consul_client_key",
 			"consul_cluster": "test cluster",
 			"container_inode_limit": 1000,
+			"cpu_weight_capacity": 800,
 			"container_max_cpu_shares": 4,
 			"container_metrics_report_interval": "16s",
 			"container_owner_name": "vcap",
@@ -45,6 +47,7 @@ var _ = Describe("RepConfig", func() {
 			"debug_address": "5.5.5.5:9090",
 			"delete_work_pool_size": 10,
 			"disk_mb": "20000",
+			"domain_quotas": {"cf-tasks": {"memory_mb": 4096, "disk_mb": 8192, "containers": 20}},
 			"enable_declarative_healthcheck": true,
 			"declarative_healthcheck_path": "/var/vcap/packages/healthcheck",
 			"enable_consul_service_registration": true,
@@ -79,6 +82,9 @@ var _ = Describe("RepConfig", func() {
 			"listen_addr_securable": "0.0.0.0:8081",
 			"lock_retry_interval": "5s",
 			"lock_ttl": "5s",
+			"scoring_strategy": "bin_pack",
+			"scoring_weights": {"memory_mb": 2, "disk_mb": 1, "containers": 1, "cpu_weight": 0.5, "max_pids": 0},
+			"pid_capacity": 4096,
 			"cell_registrations_locket_enabled": true,
 			"locket_address": "0.0.0.0:909090909",
 			"locket_ca_cert_file": "locket-ca-cert",
@@ -105,16 +111,22 @@ var _ = Describe("RepConfig", func() {
 			"metrics_work_pool_size": 5,
 			"optional_placement_tags": ["otag1", "otag2"],
 			"path_to_ca_certs_for_downloads": "/tmp/ca-certs",
+			"placement_labels": {"gpu": "false", "tier": "edge"},
 			"placement_tags": ["tag1", "tag2"],
 			"polling_interval": "10s",
 			"post_setup_hook": "post_setup_hook",
 			"post_setup_user": "post_setup_user",
 			"preloaded_root_fs": ["test:value", "test2:value2"],
+			"preloaded_root_fs_version_ranges": {"test": ">=1.50"},
+			"root_fs_providers": {"docker": {"type": "pattern", "glob": "registry.example.com/*"}},
 			"read_work_pool_size": 15,
 			"reserved_expiration_time": "10s",
 			"cert_file": "/tmp/server_cert",
 			"key_file": "/tmp/server_key",
 			"session_name": "test",
+			"stacks_manifest_file": "/var/vcap/data/rep/stacks.json",
+			"stacks_poll_interval": "30s",
+			"stacks_verify_interval": "1m",
 			"skip_cert_verify": true,
 			"supported_providers": ["provider1", "provider2"],
 			"temp_dir": "/tmp/test",
@@ -122,7 +134,8 @@ var _ = Describe("RepConfig", func() {
 			"unhealthy_monitoring_interval": "10s",
 			"volman_driver_paths": "/tmp/volman1:/tmp/volman2",
 			"zone": "test-zone",
-			"report_interval": "2m"
+			"report_interval": "2m",
+			"reservation_ttl": "10s"
 		}`
 	})
 
@@ -169,9 +182,11 @@ var _ = Describe("RepConfig", func() {
 			ConsulClientCert:     "/tmp/consul_client_cert",
 			ConsulClientKey:      "/tmp/consul_client_key",
 			ConsulCluster:        "test cluster",
+			CPUWeightCapacity:    800,
 			DebugServerConfig: debugserver.DebugServerConfig{
 				DebugAddress: "5.5.5.5:9090",
 			},
+			DomainQuotas:                    rep.DomainQuotas{"cf-tasks": {MemoryMB: 4096, DiskMB: 8192, Containers: 20}},
 			EnableConsulServiceRegistration: true,
 			EvacuationPollingInterval:       durationjson.Duration(13 * time.Second),
 			EvacuationTimeout:               durationjson.Duration(12 * time.Second),
@@ -229,21 +244,33 @@ var _ = Describe("RepConfig", func() {
 			LagerConfig: lagerflags.LagerConfig{
 				LogLevel: lagerflags.DEBUG,
 			},
-			LayeringMode:          "single-layer",
-			ListenAddr:            "0.0.0.0:8080",
-			ListenAddrSecurable:   "0.0.0.0:8081",
-			LockRetryInterval:     durationjson.Duration(5 * time.Second),
-			LockTTL:               durationjson.Duration(5 * time.Second),
-			OptionalPlacementTags: []string{"otag1", "otag2"},
-			PlacementTags:         []string{"tag1", "tag2"},
-			PollingInterval:       durationjson.Duration(10 * time.Second),
-			PreloadedRootFS:       []config.RootFS{{"test", "value"}, {"test2", "value2"}},
-			CertFile:              "/tmp/server_cert",
-			KeyFile:               "/tmp/server_key",
-			SessionName:           "test",
-			SupportedProviders:    []string{"provider1", "provider2"},
-			Zone:                  "test-zone",
-			ReportInterval:        durationjson.Duration(2 * time.Minute),
+			LayeringMode:                 "single-layer",
+			ListenAddr:                   "0.0.0.0:8080",
+			ListenAddrSecurable:          "0.0.0.0:8081",
+			LockRetryInterval:            durationjson.Duration(5 * time.Second),
+			LockTTL:                      durationjson.Duration(5 * time.Second),
+			OptionalPlacementTags:        []string{"otag1", "otag2"},
+			PidCapacity:                  4096,
+			PlacementLabels:              map[string]string{"gpu": "false", "tier": "edge"},
+			PlacementTags:                []string{"tag1", "tag2"},
+			PollingInterval:              durationjson.Duration(10 * time.Second),
+			PreloadedRootFS:              []config.RootFS{{"test", "value"}, {"test2", "value2"}},
+			PreloadedRootFSVersionRanges: map[string]string{"test": ">=1.50"},
+			RootFSProviders: rep.RootFSProviders{
+				"docker": rep.PatternRootFSProvider{Glob: "registry.example.com/*"},
+			},
+			ScoringStrategy:      "bin_pack",
+			ScoringWeights:       &rep.ScoringWeights{MemoryMB: 2, DiskMB: 1, Containers: 1, CPUWeight: 0.5},
+			CertFile:             "/tmp/server_cert",
+			KeyFile:              "/tmp/server_key",
+			SessionName:          "test",
+			StacksManifestFile:   "/var/vcap/data/rep/stacks.json",
+			StacksPollInterval:   durationjson.Duration(30 * time.Second),
+			StacksVerifyInterval: durationjson.Duration(time.Minute),
+			SupportedProviders:   []string{"provider1", "provider2"},
+			Zone:                 "test-zone",
+			ReportInterval:       durationjson.Duration(2 * time.Minute),
+			ReservationTTL:       durationjson.Duration(10 * time.Second),
 			LoggregatorConfig: loggingclient.Config{
 				UseV2API:      true,
 				APIPort:       1234,
@@ -259,6 +286,52 @@ var _ = Describe("RepConfig", func() {
 		}))
 	})
 
+	Describe("LoadStacksManifest", func() {
+		var manifestPath string
+
+		BeforeEach(func() {
+			manifestFile, err := ioutil.TempFile("", "stacks-manifest")
+			Expect(err).NotTo(HaveOccurred())
+			defer manifestFile.Close()
+
+			_, err = manifestFile.WriteString(`["cflinuxfs3:/path/to/cflinuxfs3", "cflinuxfs4:/path/to/cflinuxfs4"]`)
+			Expect(err).NotTo(HaveOccurred())
+
+			manifestPath = manifestFile.Name()
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(manifestPath)).To(Succeed())
+		})
+
+		It("parses the preloaded rootfses", func() {
+			rootFSes, err := config.LoadStacksManifest(manifestPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rootFSes).To(Equal(config.RootFSes{
+				{"cflinuxfs3", "/path/to/cflinuxfs3"},
+				{"cflinuxfs4", "/path/to/cflinuxfs4"},
+			}))
+		})
+
+		Context("when the manifest does not exist", func() {
+			It("returns an error", func() {
+				_, err := config.LoadStacksManifest("foobar")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the manifest contains an invalid rootfs", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(manifestPath, []byte(`["cflinuxfs3"]`), 0644)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				_, err := config.LoadStacksManifest(manifestPath)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Context("when the file does not exist", func() {
 		It("returns an error", func() {
 			_, err := config.NewRepConfig("foobar")
@@ -3,7 +3,6 @@ package main
 import (
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"flag"
@@ -12,7 +11,9 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/bbs"
@@ -23,15 +24,17 @@ import (
 	"code.cloudfoundry.org/debugserver"
 	loggingclient "code.cloudfoundry.org/diego-logging-client"
 	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/guidgen"
 	executorinit "code.cloudfoundry.org/executor/initializer"
+	"code.cloudfoundry.org/executor/initializer/configuration"
+	GardenClient "code.cloudfoundry.org/garden/client"
+	GardenConnection "code.cloudfoundry.org/garden/client/connection"
 	"code.cloudfoundry.org/go-loggregator/v8/runtimeemitter"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
 	"code.cloudfoundry.org/localip"
 	"code.cloudfoundry.org/locket"
-	"code.cloudfoundry.org/locket/lock"
 	"code.cloudfoundry.org/locket/metrics/helpers"
-	locketmodels "code.cloudfoundry.org/locket/models"
 	"code.cloudfoundry.org/operationq"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/auctioncellrep"
@@ -39,9 +42,11 @@ import (
 	"code.cloudfoundry.org/rep/evacuation"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
 	"code.cloudfoundry.org/rep/generator"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 	"code.cloudfoundry.org/rep/handlers"
 	"code.cloudfoundry.org/rep/harmonizer"
 	"code.cloudfoundry.org/rep/maintain"
+	"code.cloudfoundry.org/rep/stacks"
 	"code.cloudfoundry.org/tlsconfig"
 	"github.com/hashicorp/consul/api"
 	uuid "github.com/nu7hatch/gouuid"
@@ -96,7 +101,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	loadStacks := stacksLoader(*configFilePath, repConfig.StacksManifestFile)
 	rootFSMap := repConfig.PreloadedRootFS.StackPathMap()
+	if repConfig.StacksManifestFile != "" {
+		rootFSMap, err = loadStacks()
+		if err != nil {
+			logger.Error("failed-to-load-stacks-manifest", err)
+			os.Exit(1)
+		}
+	}
+	// the executor is only started with the initial stacks, so the rootfses
+	// of stacks with other paths are measured by the rep when they are added
+	stackMap := stacks.NewExecutorMap(rootFSMap, stacks.VerifyPath, rootFSSizer(logger, repConfig.ExecutorConfig))
+	for name, health := range stackMap.StackHealth() {
+		if !health.Healthy {
+			logger.Error("unhealthy-stack", nil, lager.Data{"stack": name, "path": health.Path, "error": health.Error})
+		}
+	}
+
+	if _, err := rep.NewVersionedSetRootFSProvider(repConfig.PreloadedRootFSVersionRanges); err != nil {
+		logger.Error("invalid-preloaded-root-fs-version-ranges", err)
+		os.Exit(1)
+	}
+
+	scoring := rep.ScoringConfig{
+		Strategy: repConfig.ScoringStrategy,
+		Weights:  repConfig.ScoringWeights,
+	}
+	if _, err := rep.NewScoringStrategy(scoring); err != nil {
+		logger.Error("invalid-scoring-strategy", err)
+		os.Exit(1)
+	}
 
 	executorClient, containerMetricsProvider, executorMembers, err := executorinit.Initialize(logger, repConfig.ExecutorConfig, repConfig.CellID, repConfig.Zone, rootFSMap, metronClient, clock)
 	if err != nil {
@@ -110,6 +145,7 @@ func main() {
 	serviceClient := maintain.NewCellPresenceClient(consulClient, clock)
 
 	evacuatable, evacuationReporter, evacuationNotifier := evacuation_context.New()
+	restartRequester, restartClaimer := restart_context.New(evacuationReporter)
 
 	// only one outstanding operation per container is necessary
 	queue := operationq.NewSlidingQueue(1)
@@ -127,40 +163,56 @@ func main() {
 	bbsClient := initializeBBSClient(logger, repConfig)
 	url := repURL(repConfig)
 	address := repAddress(logger, repConfig)
-	cellPresence := initializeCellPresence(address, serviceClient, executorClient, logger, repConfig, repConfig.PreloadedRootFS.Names(), url)
-	batchContainerAllocator := auctioncellrep.NewContainerAllocator(auctioncellrep.GenerateGuid, rootFSMap, executorClient)
-	auctionCellRep := auctioncellrep.New(
-		repConfig.CellID,
-		repConfig.CellIndex,
-		url,
-		rootFSMap,
-		containerMetricsProvider,
-		repConfig.SupportedProviders,
-		repConfig.Zone,
-		executorClient,
-		evacuationReporter,
-		repConfig.PlacementTags,
-		repConfig.OptionalPlacementTags,
-		repConfig.ProxyMemoryAllocationMB,
-		repConfig.EnableContainerProxy,
-		batchContainerAllocator,
+	cellPresence := maintain.NewReloadingPresence(
+		logger,
+		stackMap.Subscribe(),
+		initializeCellPresence(address, serviceClient, executorClient, logger, repConfig, stackMap.Stacks().Names(), url),
+		func() []string { return stackMap.Stacks().Names() },
 	)
+	batchContainerAllocator := auctioncellrep.NewContainerAllocator(auctioncellrep.GenerateGuid, stackMap, executorClient)
+	auctionCellRep := auctioncellrep.New(auctioncellrep.Config{
+		CellID:                   repConfig.CellID,
+		CellIndex:                repConfig.CellIndex,
+		RepURL:                   url,
+		Zone:                     repConfig.Zone,
+		Stacks:                   stackMap,
+		ContainerMetricsProvider: containerMetricsProvider,
+		ArbitraryRootFSes:        repConfig.SupportedProviders,
+		RootFSProviders:          repConfig.RootFSProviders,
+		PreloadedVersionRanges:   repConfig.PreloadedRootFSVersionRanges,
+		Client:                   executorClient,
+		EvacuationReporter:       evacuationReporter,
+		EvictionReporter:         auctioncellrep.NewEvictionReporter(bbsClient, repConfig.CellID),
+		PlacementTags:            repConfig.PlacementTags,
+		OptionalPlacementTags:    repConfig.OptionalPlacementTags,
+		PlacementLabels:          repConfig.PlacementLabels,
+		ProxyMemoryAllocation:    repConfig.ProxyMemoryAllocationMB,
+		EnableContainerProxy:     repConfig.EnableContainerProxy,
+		CPUWeightCapacity:        repConfig.CPUWeightCapacity,
+		PidCapacity:              repConfig.PidCapacity,
+		Scoring:                  scoring,
+		DomainQuotas:             repConfig.DomainQuotas,
+		Clock:                    clock,
+		ReservationTTL:           reservationTTL(repConfig),
+		Allocator:                batchContainerAllocator,
+	})
 
 	requestTypes := []string{
-		"State", "ContainerMetrics", "Perform", "Reset", "StopLRPInstance", "CancelTask", //over https only
+		"State", "StateEvents", "ContainerMetrics", "Perform", "PerformDryRun", "Reserve", "Prewarm", "Reset", "StopLRPInstance", "RestartLRPInstance", "CancelTask", "StopLRPInstances", "CancelTasks", "LRPInstanceDetail", "TaskDetail", "ContainerDiagnostics", //over https only
 	}
 	requestMetrics := helpers.NewRequestMetricsNotifier(logger, clock, metronClient, time.Duration(repConfig.ReportInterval), requestTypes)
-	httpServer := initializeServer(auctionCellRep, executorClient, evacuatable, requestMetrics, logger, repConfig, false)
-	httpsServer := initializeServer(auctionCellRep, executorClient, evacuatable, requestMetrics, logger, repConfig, true)
+	httpServer := initializeServer(auctionCellRep, executorClient, metronClient, evacuatable, restartRequester, requestMetrics, logger, repConfig, false)
+	httpsServer := initializeServer(auctionCellRep, executorClient, metronClient, evacuatable, restartRequester, requestMetrics, logger, repConfig, true)
 
 	opGenerator := generator.New(
 		repConfig.CellID,
-		rootFSMap,
+		stackMap,
 		repConfig.LayeringMode,
 		bbsClient,
 		executorClient,
 		metronClient,
 		evacuationReporter,
+		restartClaimer,
 	)
 
 	cleanup := evacuation.NewEvacuationCleanup(
@@ -194,7 +246,22 @@ func main() {
 		metronClient,
 	)
 
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+	stacksReloader := stacks.NewReloader(
+		logger,
+		stackMap,
+		loadStacks,
+		clock,
+		time.Duration(repConfig.StacksPollInterval),
+		reloadSignals,
+	)
+
+	stacksVerifier := stacks.NewVerifier(logger, stackMap, clock, stacksVerifyInterval(repConfig))
+
 	members := grouper.Members{
+		{"stacks-reloader", stacksReloader},
+		{"stacks-verifier", stacksVerifier},
 		{"presence", cellPresence},
 		{"http_server", httpServer},
 		{"https_server", httpsServer},
@@ -233,6 +300,41 @@ func main() {
 	logger.Info("exited")
 }
 
+// stacksLoader loads the preloaded stacks from the stacks manifest if one is
+// configured, and otherwise from the preloaded_root_fs of the config file, so
+// that either can be edited and reloaded while the rep is running.
+func stacksLoader(configFilePath, manifestFile string) stacks.LoadFunc {
+	return func() (rep.StackPathMap, error) {
+		if manifestFile != "" {
+			rootFSes, err := config.LoadStacksManifest(manifestFile)
+			if err != nil {
+				return nil, err
+			}
+			return rootFSes.StackPathMap(), nil
+		}
+
+		repConfig, err := config.NewRepConfig(configFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return repConfig.PreloadedRootFS.StackPathMap(), nil
+	}
+}
+
+// rootFSSizer measures a rootfs the same way the executor measures the
+// rootfses it is started with, from the disk usage of a garden container
+// created from it.
+func rootFSSizer(logger lager.Logger, executorConfig executorinit.ExecutorConfig) stacks.SizeFunc {
+	gardenClient := GardenClient.New(GardenConnection.New(executorConfig.GardenNetwork, executorConfig.GardenAddr))
+	return func(path string) (uint64, error) {
+		sizer, err := configuration.GetRootFSSizes(logger.Session("measure-rootfs"), gardenClient, guidgen.DefaultGenerator, executorConfig.ContainerOwnerName, map[string]string{"": path})
+		if err != nil {
+			return 0, err
+		}
+		return sizer.RootFSSizeFromPath(path), nil
+	}
+}
+
 func initializeCellPresence(
 	address string,
 	serviceClient maintain.CellPresenceClient,
@@ -241,7 +343,19 @@ func initializeCellPresence(
 	repConfig config.RepConfig,
 	preloadedRootFSes []string,
 	repUrl string,
-) ifrit.Runner {
+) maintain.CellPresence {
+	config := maintain.Config{
+		CellID:                repConfig.CellID,
+		RepAddress:            address,
+		RepUrl:                repUrl,
+		Zone:                  repConfig.Zone,
+		RetryInterval:         time.Duration(repConfig.LockRetryInterval),
+		RootFSProviders:       repConfig.SupportedProviders,
+		PreloadedRootFSes:     preloadedRootFSes,
+		PlacementTags:         repConfig.PlacementTags,
+		OptionalPlacementTags: repConfig.OptionalPlacementTags,
+	}
+
 	if repConfig.CellRegistrationsLocketEnabled {
 		locketClient, err := locket.NewClient(logger, repConfig.ClientLocketConfig)
 		if err != nil {
@@ -258,45 +372,22 @@ func initializeCellPresence(
 			logger.Fatal("failed-to-get-total-resources", err)
 		}
 		cellCapacity := models.NewCellCapacity(int32(resources.MemoryMB), int32(resources.DiskMB), int32(resources.Containers))
-		cellPresence := models.NewCellPresence(repConfig.CellID, address, repUrl,
-			repConfig.Zone, cellCapacity, repConfig.SupportedProviders,
-			preloadedRootFSes, repConfig.PlacementTags, repConfig.OptionalPlacementTags)
 
-		payload, err := json.Marshal(cellPresence)
-		if err != nil {
-			logger.Fatal("failed-to-encode-cell-presence", err)
-		}
-
-		lockPayload := &locketmodels.Resource{
-			Key:      repConfig.CellID,
-			Owner:    guid.String(),
-			Value:    string(payload),
-			TypeCode: locketmodels.PRESENCE,
-			Type:     locketmodels.PresenceType,
-		}
-
-		logger.Debug("presence-payload", lager.Data{"payload": lockPayload})
-		return lock.NewPresenceRunner(
+		config.RetryInterval = locket.RetryInterval
+		presence, err := maintain.NewLocketCellPresence(
 			logger,
+			config,
+			cellCapacity,
 			locketClient,
-			lockPayload,
+			guid.String(),
 			int64(time.Duration(repConfig.LockTTL)/time.Second),
 			clock.NewClock(),
-			locket.RetryInterval,
 		)
-	} else {
-		config := maintain.Config{
-			CellID:                repConfig.CellID,
-			RepAddress:            address,
-			RepUrl:                repUrl,
-			Zone:                  repConfig.Zone,
-			RetryInterval:         time.Duration(repConfig.LockRetryInterval),
-			RootFSProviders:       repConfig.SupportedProviders,
-			PreloadedRootFSes:     preloadedRootFSes,
-			PlacementTags:         repConfig.PlacementTags,
-			OptionalPlacementTags: repConfig.OptionalPlacementTags,
+		if err != nil {
+			logger.Fatal("failed-to-encode-cell-presence", err)
 		}
-
+		return presence
+	} else {
 		return maintain.New(
 			logger,
 			config,
@@ -311,13 +402,15 @@ func initializeCellPresence(
 func initializeServer(
 	auctionCellRep *auctioncellrep.AuctionCellRep,
 	executorClient executor.Client,
+	metronClient loggingclient.IngressClient,
 	evacuatable evacuation_context.Evacuatable,
+	restartRequester restart_context.RestartRequester,
 	requestMetrics helpers.RequestMetrics,
 	logger lager.Logger,
 	repConfig config.RepConfig,
 	networkAccessible bool,
 ) ifrit.Runner {
-	handlers := handlers.New(auctionCellRep, auctionCellRep, executorClient, evacuatable, requestMetrics, logger, networkAccessible)
+	handlers := handlers.New(auctionCellRep, auctionCellRep, executorClient, metronClient, evacuatable, restartRequester, requestMetrics, logger, networkAccessible)
 	routes := rep.NewRoutes(networkAccessible)
 	router, err := rata.NewRouter(routes, handlers)
 
@@ -434,6 +527,20 @@ func repAddress(logger lager.Logger, config config.RepConfig) string {
 	return fmt.Sprintf("http://%s:%s", ip, port)
 }
 
+func reservationTTL(config config.RepConfig) time.Duration {
+	if config.ReservationTTL == 0 {
+		return auctioncellrep.DefaultReservationTTL
+	}
+	return time.Duration(config.ReservationTTL)
+}
+
+func stacksVerifyInterval(config config.RepConfig) time.Duration {
+	if config.StacksVerifyInterval == 0 {
+		return stacks.DefaultVerifyInterval
+	}
+	return time.Duration(config.StacksVerifyInterval)
+}
+
 func initializeRegistrationRunner(
 	logger lager.Logger,
 	consulClient consuladapter.Client,
@@ -0,0 +1,40 @@
+package rep
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/containermetrics"
+)
+
+// ErrContainerNotFound is returned by Client.LRPInstanceDetail,
+// Client.TaskDetail and Client.ContainerDiagnostics when the cell has no
+// container for the LRP instance, task or guid.
+var ErrContainerNotFound = errors.New("container not found")
+
+// ContainerDetail describes a single LRP instance or task container on a
+// cell, as reported by its executor.
+type ContainerDetail struct {
+	Guid         string                                   `json:"guid"`
+	Lifecycle    string                                   `json:"lifecycle"`
+	Domain       string                                   `json:"domain,omitempty"`
+	ProcessGuid  string                                   `json:"process_guid,omitempty"`
+	InstanceGuid string                                   `json:"instance_guid,omitempty"`
+	Index        int32                                    `json:"index,omitempty"`
+	TaskGuid     string                                   `json:"task_guid,omitempty"`
+	State        executor.State                           `json:"state"`
+	RunResult    executor.ContainerRunResult              `json:"run_result"`
+	Resource     Resource                                 `json:"resource"`
+	MemoryLimit  uint64                                   `json:"memory_limit"`
+	DiskLimit    uint64                                   `json:"disk_limit"`
+	Tags         executor.Tags                            `json:"tags,omitempty"`
+	NetInfo      ContainerNetInfo                         `json:"net_info"`
+	AllocatedAt  int64                                    `json:"allocated_at"`
+	Metrics      *containermetrics.CachedContainerMetrics `json:"metrics,omitempty"`
+}
+
+type ContainerNetInfo struct {
+	ExternalIP string                 `json:"external_ip,omitempty"`
+	InternalIP string                 `json:"internal_ip,omitempty"`
+	Ports      []executor.PortMapping `json:"ports,omitempty"`
+}
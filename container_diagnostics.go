@@ -0,0 +1,32 @@
+package rep
+
+import (
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/containermetrics"
+)
+
+const (
+	// DefaultDiagnosticsResultFileLines is the number of trailing result file
+	// lines included in a diagnostic bundle when the request does not ask for
+	// a specific number.
+	DefaultDiagnosticsResultFileLines = 100
+	// MaxDiagnosticsResultFileLines is the largest number of trailing result
+	// file lines a diagnostic bundle can include.
+	MaxDiagnosticsResultFileLines = 1000
+)
+
+// ContainerDiagnostics is what a cell knows about a single container, as
+// bundled by the container diagnostics route.
+//
+// The container record is redacted: its environment variables, image
+// password and actions, which carry the app's environment, are removed.
+type ContainerDiagnostics struct {
+	Container executor.Container
+	Metrics   *containermetrics.CachedContainerMetrics
+	// ResultFileTail holds the last lines of the container's result file, if
+	// it has one and it could be read.
+	ResultFileTail string
+	// ResultFileError explains why the result file could not be read, for
+	// instance because the task has not written it yet.
+	ResultFileError string
+}
@@ -19,8 +19,9 @@ const (
 	ResultFileTag = "result-file"
 	DomainTag     = "domain"
 
-	TaskLifecycle = "task"
-	LRPLifecycle  = "lrp"
+	TaskLifecycle    = "task"
+	LRPLifecycle     = "lrp"
+	PrewarmLifecycle = "prewarm"
 
 	ProcessGuidTag  = "process-guid"
 	InstanceGuidTag = "instance-guid"
@@ -28,6 +29,9 @@ const (
 
 	VolumeDriversTag = "volume-drivers"
 	PlacementTagsTag = "placement-tags"
+
+	CPUWeightTag = "cpu-weight"
+	PriorityTag  = "priority"
 )
 
 var (
@@ -13,9 +13,20 @@ import (
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
 	"code.cloudfoundry.org/rep/generator/internal"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+// ContainerDelegate wraps the executor calls the generator makes for a
+// container, logging their outcome.
+type ContainerDelegate = internal.ContainerDelegate
+
+// NewContainerDelegate returns the ContainerDelegate the generator uses, so
+// that the rest of the rep can read containers the same way.
+func NewContainerDelegate(client executor.Client) ContainerDelegate {
+	return internal.NewContainerDelegate(client)
+}
+
 //go:generate counterfeiter -o fake_generator/fake_generator.go . Generator
 
 // Generator encapsulates operation creation in the Rep.
@@ -38,16 +49,17 @@ type generator struct {
 
 func New(
 	cellID string,
-	stackPathMap rep.StackPathMap,
+	stacks rep.StackSource,
 	layeringMode string,
 	bbs bbs.InternalClient,
 	executorClient executor.Client,
 	metronClient loggingclient.IngressClient,
 	evacuationReporter evacuation_context.EvacuationReporter,
+	restartClaimer restart_context.RestartClaimer,
 ) Generator {
 	containerDelegate := internal.NewContainerDelegate(executorClient)
-	lrpProcessor := internal.NewLRPProcessor(bbs, containerDelegate, metronClient, cellID, stackPathMap, layeringMode, evacuationReporter)
-	taskProcessor := internal.NewTaskProcessor(bbs, containerDelegate, cellID, stackPathMap, layeringMode)
+	lrpProcessor := internal.NewLRPProcessor(bbs, containerDelegate, metronClient, cellID, stacks, layeringMode, evacuationReporter, restartClaimer)
+	taskProcessor := internal.NewTaskProcessor(bbs, containerDelegate, cellID, stacks, layeringMode)
 
 	return &generator{
 		cellID:            cellID,
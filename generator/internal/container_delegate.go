@@ -20,6 +20,7 @@ var ErrResultFileTooLarge = errors.New(
 
 type ContainerDelegate interface {
 	GetContainer(logger lager.Logger, guid string) (executor.Container, bool)
+	AllocateContainer(logger lager.Logger, req *executor.AllocationRequest) bool
 	RunContainer(logger lager.Logger, req *executor.RunRequest) bool
 	StopContainer(logger lager.Logger, guid string) bool
 	DeleteContainer(logger lager.Logger, guid string) bool
@@ -47,6 +48,17 @@ func (d *containerDelegate) GetContainer(logger lager.Logger, guid string) (exec
 	return container, true
 }
 
+func (d *containerDelegate) AllocateContainer(logger lager.Logger, req *executor.AllocationRequest) bool {
+	logger.Info("allocating-container")
+	failures := d.client.AllocateContainers(logger, []executor.AllocationRequest{*req})
+	if len(failures) > 0 {
+		logger.Error("failed-allocating-container", &failures[0])
+		return false
+	}
+	logger.Info("succeeded-allocating-container")
+	return true
+}
+
 func (d *containerDelegate) RunContainer(logger lager.Logger, req *executor.RunRequest) bool {
 	logger.Info("running-container")
 	err := d.client.RunContainer(logger, req)
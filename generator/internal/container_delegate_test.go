@@ -133,6 +133,55 @@ var _ = Describe("ContainerDelegate", func() {
 		})
 	})
 
+	Describe("AllocateContainer", func() {
+		var (
+			request executor.AllocationRequest
+			result  bool
+		)
+
+		BeforeEach(func() {
+			resource := executor.NewResource(128, 256, 10)
+			request = executor.NewAllocationRequest(expectedGuid, &resource, executor.Tags{"some": "tag"})
+		})
+
+		JustBeforeEach(func() {
+			result = containerDelegate.AllocateContainer(logger, &request)
+		})
+
+		It("allocates the container", func() {
+			Expect(executorClient.AllocateContainersCallCount()).To(Equal(1))
+			_, requests := executorClient.AllocateContainersArgsForCall(0)
+			Expect(requests).To(Equal([]executor.AllocationRequest{request}))
+		})
+
+		Context("when allocating succeeds", func() {
+			It("returns true", func() {
+				Expect(result).To(BeTrue())
+			})
+
+			It("logs the allocating", func() {
+				Expect(logger).To(gbytes.Say(sessionPrefix + ".allocating-container"))
+				Expect(logger).To(gbytes.Say(sessionPrefix + ".succeeded-allocating-container"))
+			})
+		})
+
+		Context("when allocating fails", func() {
+			BeforeEach(func() {
+				executorClient.AllocateContainersReturns([]executor.AllocationFailure{
+					executor.NewAllocationFailure(&request, "insufficient resources"),
+				})
+			})
+
+			It("returns false", func() {
+				Expect(result).To(BeFalse())
+			})
+
+			It("logs the failure", func() {
+				Expect(logger).To(gbytes.Say(sessionPrefix + ".failed-allocating-container"))
+			})
+		})
+	})
+
 	Describe("DeleteContainer", func() {
 		var result bool
 
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
 	"code.cloudfoundry.org/rep/generator/internal"
 	"code.cloudfoundry.org/rep/generator/internal/fake_internal"
+	"code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -30,6 +31,7 @@ var _ = Describe("EvacuationLrpProcessor", func() {
 			fakeBBS                *fake_bbs.FakeInternalClient
 			fakeContainerDelegate  *fake_internal.FakeContainerDelegate
 			fakeEvacuationReporter *fake_evacuation_context.FakeEvacuationReporter
+			fakeRestartClaimer     *fake_restart_context.FakeRestartClaimer
 			fakeMetronClient       *mfakes.FakeIngressClient
 
 			lrpProcessor internal.LRPProcessor
@@ -56,7 +58,9 @@ var _ = Describe("EvacuationLrpProcessor", func() {
 
 			fakeMetronClient = new(mfakes.FakeIngressClient)
 
-			lrpProcessor = internal.NewLRPProcessor(fakeBBS, fakeContainerDelegate, fakeMetronClient, localCellID, rep.StackPathMap{}, "", fakeEvacuationReporter)
+			fakeRestartClaimer = &fake_restart_context.FakeRestartClaimer{}
+
+			lrpProcessor = internal.NewLRPProcessor(fakeBBS, fakeContainerDelegate, fakeMetronClient, localCellID, rep.StackPathMap{}, "", fakeEvacuationReporter, fakeRestartClaimer)
 
 			processGuid = "process-guid"
 			desiredLRP = models.DesiredLRP{
@@ -94,6 +98,11 @@ var _ = Describe("EvacuationLrpProcessor", func() {
 			lrpProcessor.Process(logger, container)
 		})
 
+		It("cancels the pending restarts", func() {
+			Expect(fakeRestartClaimer.CancelRestartsCallCount()).To(Equal(1))
+			Expect(fakeRestartClaimer.ClaimRestartCallCount()).To(Equal(0))
+		})
+
 		Context("when the container is Reserved", func() {
 			BeforeEach(func() {
 				container.State = executor.StateReserved
@@ -10,6 +10,18 @@ import (
 )
 
 type FakeContainerDelegate struct {
+	AllocateContainerStub        func(lager.Logger, *executor.AllocationRequest) bool
+	allocateContainerMutex       sync.RWMutex
+	allocateContainerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *executor.AllocationRequest
+	}
+	allocateContainerReturns struct {
+		result1 bool
+	}
+	allocateContainerReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	DeleteContainerStub        func(lager.Logger, string) bool
 	deleteContainerMutex       sync.RWMutex
 	deleteContainerArgsForCall []struct {
@@ -79,6 +91,68 @@ type FakeContainerDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeContainerDelegate) AllocateContainer(arg1 lager.Logger, arg2 *executor.AllocationRequest) bool {
+	fake.allocateContainerMutex.Lock()
+	ret, specificReturn := fake.allocateContainerReturnsOnCall[len(fake.allocateContainerArgsForCall)]
+	fake.allocateContainerArgsForCall = append(fake.allocateContainerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *executor.AllocationRequest
+	}{arg1, arg2})
+	fake.recordInvocation("AllocateContainer", []interface{}{arg1, arg2})
+	allocateContainerStubCopy := fake.AllocateContainerStub
+	fake.allocateContainerMutex.Unlock()
+	if allocateContainerStubCopy != nil {
+		return allocateContainerStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.allocateContainerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainerDelegate) AllocateContainerCallCount() int {
+	fake.allocateContainerMutex.RLock()
+	defer fake.allocateContainerMutex.RUnlock()
+	return len(fake.allocateContainerArgsForCall)
+}
+
+func (fake *FakeContainerDelegate) AllocateContainerCalls(stub func(lager.Logger, *executor.AllocationRequest) bool) {
+	fake.allocateContainerMutex.Lock()
+	defer fake.allocateContainerMutex.Unlock()
+	fake.AllocateContainerStub = stub
+}
+
+func (fake *FakeContainerDelegate) AllocateContainerArgsForCall(i int) (lager.Logger, *executor.AllocationRequest) {
+	fake.allocateContainerMutex.RLock()
+	defer fake.allocateContainerMutex.RUnlock()
+	argsForCall := fake.allocateContainerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeContainerDelegate) AllocateContainerReturns(result1 bool) {
+	fake.allocateContainerMutex.Lock()
+	defer fake.allocateContainerMutex.Unlock()
+	fake.AllocateContainerStub = nil
+	fake.allocateContainerReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeContainerDelegate) AllocateContainerReturnsOnCall(i int, result1 bool) {
+	fake.allocateContainerMutex.Lock()
+	defer fake.allocateContainerMutex.Unlock()
+	fake.AllocateContainerStub = nil
+	if fake.allocateContainerReturnsOnCall == nil {
+		fake.allocateContainerReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.allocateContainerReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeContainerDelegate) DeleteContainer(arg1 lager.Logger, arg2 string) bool {
 	fake.deleteContainerMutex.Lock()
 	ret, specificReturn := fake.deleteContainerReturnsOnCall[len(fake.deleteContainerArgsForCall)]
@@ -399,6 +473,8 @@ func (fake *FakeContainerDelegate) StopContainerReturnsOnCall(i int, result1 boo
 func (fake *FakeContainerDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allocateContainerMutex.RLock()
+	defer fake.allocateContainerMutex.RUnlock()
 	fake.deleteContainerMutex.RLock()
 	defer fake.deleteContainerMutex.RUnlock()
 	fake.fetchContainerResultFileMutex.RLock()
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 )
 
 type lrpContainer struct {
@@ -32,6 +33,7 @@ type LRPProcessor interface {
 
 type lrpProcessor struct {
 	evacuationReporter  evacuation_context.EvacuationReporter
+	restartClaimer      restart_context.RestartClaimer
 	ordinaryProcessor   LRPProcessor
 	evacuationProcessor LRPProcessor
 }
@@ -41,14 +43,16 @@ func NewLRPProcessor(
 	containerDelegate ContainerDelegate,
 	metronClient loggingclient.IngressClient,
 	cellID string,
-	stackPathMap rep.StackPathMap,
+	stacks rep.StackSource,
 	layeringMode string,
 	evacuationReporter evacuation_context.EvacuationReporter,
+	restartClaimer restart_context.RestartClaimer,
 ) LRPProcessor {
-	ordinaryProcessor := newOrdinaryLRPProcessor(bbsClient, containerDelegate, cellID, stackPathMap, layeringMode)
+	ordinaryProcessor := newOrdinaryLRPProcessor(bbsClient, containerDelegate, cellID, stacks, layeringMode, restartClaimer)
 	evacuationProcessor := newEvacuationLRPProcessor(bbsClient, containerDelegate, metronClient, cellID)
 	return &lrpProcessor{
 		evacuationReporter:  evacuationReporter,
+		restartClaimer:      restartClaimer,
 		ordinaryProcessor:   ordinaryProcessor,
 		evacuationProcessor: evacuationProcessor,
 	}
@@ -56,6 +60,8 @@ func NewLRPProcessor(
 
 func (p *lrpProcessor) Process(logger lager.Logger, container executor.Container) {
 	if p.evacuationReporter.Evacuating() {
+		// Evacuated instances are not restarted on this cell.
+		p.restartClaimer.CancelRestarts()
 		p.evacuationProcessor.Process(logger, container)
 	} else {
 		p.ordinaryProcessor.Process(logger, container)
@@ -7,14 +7,18 @@ import (
 	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 )
 
+const restartFailureReason = "failed to restart in place"
+
 type ordinaryLRPProcessor struct {
 	bbsClient                  bbs.InternalClient
 	containerDelegate          ContainerDelegate
 	cellID                     string
-	stackPathMap               rep.StackPathMap
+	stacks                     rep.StackSource
 	layeringMode               string
+	restartClaimer             restart_context.RestartClaimer
 	runRequestConversionHelper rep.RunRequestConversionHelper
 }
 
@@ -22,8 +26,9 @@ func newOrdinaryLRPProcessor(
 	bbsClient bbs.InternalClient,
 	containerDelegate ContainerDelegate,
 	cellID string,
-	stackPathMap rep.StackPathMap,
+	stacks rep.StackSource,
 	layeringMode string,
+	restartClaimer restart_context.RestartClaimer,
 ) LRPProcessor {
 	runRequestConversionHelper := rep.RunRequestConversionHelper{ECRHelper: ecrhelper.NewECRHelper()}
 
@@ -31,8 +36,9 @@ func newOrdinaryLRPProcessor(
 		bbsClient:                  bbsClient,
 		containerDelegate:          containerDelegate,
 		cellID:                     cellID,
-		stackPathMap:               stackPathMap,
+		stacks:                     stacks,
 		layeringMode:               layeringMode,
+		restartClaimer:             restartClaimer,
 		runRequestConversionHelper: runRequestConversionHelper,
 	}
 }
@@ -89,7 +95,7 @@ func (p *ordinaryLRPProcessor) processReservedContainer(logger lager.Logger, lrp
 		return
 	}
 
-	runReq, err := p.runRequestConversionHelper.NewRunRequestFromDesiredLRP(lrpContainer.Guid, desired, lrpContainer.ActualLRPKey, lrpContainer.ActualLRPInstanceKey, p.stackPathMap, p.layeringMode)
+	runReq, err := p.runRequestConversionHelper.NewRunRequestFromDesiredLRP(lrpContainer.Guid, desired, lrpContainer.ActualLRPKey, lrpContainer.ActualLRPInstanceKey, p.stacks.Stacks(), p.layeringMode)
 	if err != nil {
 		logger.Error("failed-to-construct-run-request", err)
 		return
@@ -126,6 +132,7 @@ func (p *ordinaryLRPProcessor) processRunningContainer(logger lager.Logger, lrpC
 	err = p.bbsClient.StartActualLRP(logger, lrpContainer.ActualLRPKey, lrpContainer.ActualLRPInstanceKey, netInfo)
 	bbsErr := models.ConvertError(err)
 	if bbsErr != nil && bbsErr.Type == models.Error_ActualLRPCannotBeStarted {
+		p.restartClaimer.CancelRestart(lrpContainer.Guid)
 		p.containerDelegate.StopContainer(logger, lrpContainer.Guid)
 	}
 }
@@ -133,6 +140,11 @@ func (p *ordinaryLRPProcessor) processRunningContainer(logger lager.Logger, lrpC
 func (p *ordinaryLRPProcessor) processCompletedContainer(logger lager.Logger, lrpContainer *lrpContainer) {
 	logger = logger.Session("process-completed-container")
 
+	if lrpContainer.RunResult.Stopped && p.restartClaimer.ClaimRestart(lrpContainer.Guid) {
+		p.restartContainer(logger, lrpContainer)
+		return
+	}
+
 	if lrpContainer.RunResult.Stopped {
 		err := p.bbsClient.RemoveActualLRP(logger, lrpContainer.ActualLRPKey, lrpContainer.ActualLRPInstanceKey)
 		if err != nil {
@@ -148,6 +160,31 @@ func (p *ordinaryLRPProcessor) processCompletedContainer(logger lager.Logger, lr
 	p.containerDelegate.DeleteContainer(logger, lrpContainer.Guid)
 }
 
+// restartContainer replaces a container that was stopped to be restarted with
+// a new reservation under the same guid and tags. The reserved container is
+// then claimed and run like any other, so that the BBS sees the instance go
+// from RUNNING to CLAIMED and back on the same cell.
+func (p *ordinaryLRPProcessor) restartContainer(logger lager.Logger, lrpContainer *lrpContainer) {
+	logger = logger.Session("restart-container")
+
+	ok := p.containerDelegate.DeleteContainer(logger, lrpContainer.Guid)
+	if ok {
+		resource := lrpContainer.Container.Resource
+		allocationRequest := executor.NewAllocationRequest(lrpContainer.Guid, &resource, lrpContainer.Container.Tags)
+		ok = p.containerDelegate.AllocateContainer(logger, &allocationRequest)
+	}
+
+	if !ok {
+		err := p.bbsClient.CrashActualLRP(logger, lrpContainer.ActualLRPKey, lrpContainer.ActualLRPInstanceKey, restartFailureReason)
+		if err != nil {
+			logger.Info("failed-to-crash-actual-lrp", lager.Data{"error": err})
+		}
+		return
+	}
+
+	logger.Info("reallocated-container")
+}
+
 func (p *ordinaryLRPProcessor) processInvalidContainer(logger lager.Logger, lrpContainer *lrpContainer) {
 	logger = logger.Session("process-invalid-container")
 	logger.Error("not-processing-container-in-invalid-state", nil)
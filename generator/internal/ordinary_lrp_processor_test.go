@@ -15,6 +15,7 @@ import (
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
 	"code.cloudfoundry.org/rep/generator/internal"
 	"code.cloudfoundry.org/rep/generator/internal/fake_internal"
+	"code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -29,6 +30,7 @@ var _ = Describe("OrdinaryLRPProcessor", func() {
 		bbsClient          *fake_bbs.FakeInternalClient
 		containerDelegate  *fake_internal.FakeContainerDelegate
 		evacuationReporter *fake_evacuation_context.FakeEvacuationReporter
+		restartClaimer     *fake_restart_context.FakeRestartClaimer
 	)
 
 	BeforeEach(func() {
@@ -36,7 +38,8 @@ var _ = Describe("OrdinaryLRPProcessor", func() {
 		containerDelegate = new(fake_internal.FakeContainerDelegate)
 		evacuationReporter = &fake_evacuation_context.FakeEvacuationReporter{}
 		evacuationReporter.EvacuatingReturns(false)
-		processor = internal.NewLRPProcessor(bbsClient, containerDelegate, nil, expectedCellID, rep.StackPathMap{}, "", evacuationReporter)
+		restartClaimer = &fake_restart_context.FakeRestartClaimer{}
+		processor = internal.NewLRPProcessor(bbsClient, containerDelegate, nil, expectedCellID, rep.StackPathMap{}, "", evacuationReporter, restartClaimer)
 		logger = lagertest.NewTestLogger("test")
 	})
 
@@ -247,6 +250,11 @@ var _ = Describe("OrdinaryLRPProcessor", func() {
 							Expect(containerGuid).To(Equal(container.Guid))
 							Expect(delegateLogger.SessionName()).To(Equal(expectedSessionName))
 						})
+
+						It("drops any pending restart of the container", func() {
+							Expect(restartClaimer.CancelRestartCallCount()).To(Equal(1))
+							Expect(restartClaimer.CancelRestartArgsForCall(0)).To(Equal(container.Guid))
+						})
 					})
 
 					Context("when starting fails for an unknown reason", func() {
@@ -301,6 +309,64 @@ var _ = Describe("OrdinaryLRPProcessor", func() {
 								Expect(delegateLogger.SessionName()).To(Equal(expectedSessionName))
 							})
 						})
+
+						Context("and the container was stopped to be restarted", func() {
+							BeforeEach(func() {
+								container.Resource = executor.NewResource(128, 256, 10)
+								restartClaimer.ClaimRestartReturns(true)
+								containerDelegate.DeleteContainerReturns(true)
+								containerDelegate.AllocateContainerReturns(true)
+							})
+
+							It("claims the restart of the container", func() {
+								Expect(restartClaimer.ClaimRestartCallCount()).To(Equal(1))
+								Expect(restartClaimer.ClaimRestartArgsForCall(0)).To(Equal(container.Guid))
+							})
+
+							It("does not remove or crash the actual LRP", func() {
+								Expect(bbsClient.RemoveActualLRPCallCount()).To(Equal(0))
+								Expect(bbsClient.CrashActualLRPCallCount()).To(Equal(0))
+							})
+
+							It("replaces the container with a reservation for the same instance", func() {
+								Expect(containerDelegate.DeleteContainerCallCount()).To(Equal(1))
+								_, containerGuid := containerDelegate.DeleteContainerArgsForCall(0)
+								Expect(containerGuid).To(Equal(container.Guid))
+
+								Expect(containerDelegate.AllocateContainerCallCount()).To(Equal(1))
+								delegateLogger, request := containerDelegate.AllocateContainerArgsForCall(0)
+								Expect(delegateLogger.SessionName()).To(Equal(expectedSessionName + ".restart-container"))
+								Expect(*request).To(Equal(executor.NewAllocationRequest(container.Guid, &container.Resource, container.Tags)))
+							})
+
+							Context("when the container cannot be deleted", func() {
+								BeforeEach(func() {
+									containerDelegate.DeleteContainerReturns(false)
+								})
+
+								It("crashes the actual LRP without reallocating", func() {
+									Expect(containerDelegate.AllocateContainerCallCount()).To(Equal(0))
+
+									Expect(bbsClient.CrashActualLRPCallCount()).To(Equal(1))
+									_, lrpKey, instanceKey, reason := bbsClient.CrashActualLRPArgsForCall(0)
+									Expect(*lrpKey).To(Equal(expectedLrpKey))
+									Expect(*instanceKey).To(Equal(expectedInstanceKey))
+									Expect(reason).To(Equal("failed to restart in place"))
+								})
+							})
+
+							Context("when the container cannot be reallocated", func() {
+								BeforeEach(func() {
+									containerDelegate.AllocateContainerReturns(false)
+								})
+
+								It("crashes the actual LRP", func() {
+									Expect(bbsClient.CrashActualLRPCallCount()).To(Equal(1))
+									_, _, _, reason := bbsClient.CrashActualLRPArgsForCall(0)
+									Expect(reason).To(Equal("failed to restart in place"))
+								})
+							})
+						})
 					})
 
 					Context("and the container was not requested to stop", func() {
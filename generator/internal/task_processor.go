@@ -24,19 +24,19 @@ type taskProcessor struct {
 	bbsClient                  bbs.InternalClient
 	containerDelegate          ContainerDelegate
 	cellID                     string
-	stackPathMap               rep.StackPathMap
+	stacks                     rep.StackSource
 	layeringMode               string
 	runRequestConversionHelper rep.RunRequestConversionHelper
 }
 
-func NewTaskProcessor(bbs bbs.InternalClient, containerDelegate ContainerDelegate, cellID string, stackPathMap rep.StackPathMap, layeringMode string) TaskProcessor {
+func NewTaskProcessor(bbs bbs.InternalClient, containerDelegate ContainerDelegate, cellID string, stacks rep.StackSource, layeringMode string) TaskProcessor {
 	runRequestConversionHelper := rep.RunRequestConversionHelper{ECRHelper: ecrhelper.NewECRHelper()}
 
 	return &taskProcessor{
 		bbsClient:                  bbs,
 		containerDelegate:          containerDelegate,
 		cellID:                     cellID,
-		stackPathMap:               stackPathMap,
+		stacks:                     stacks,
 		layeringMode:               layeringMode,
 		runRequestConversionHelper: runRequestConversionHelper,
 	}
@@ -82,7 +82,7 @@ func (p *taskProcessor) processActiveContainer(logger lager.Logger, container ex
 		return
 	}
 
-	runReq, err := p.runRequestConversionHelper.NewRunRequestFromTask(task, p.stackPathMap, p.layeringMode)
+	runReq, err := p.runRequestConversionHelper.NewRunRequestFromTask(task, p.stacks.Stacks(), p.layeringMode)
 	if err != nil {
 		logger.Error("failed-to-construct-run-request", err)
 		return
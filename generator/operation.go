@@ -257,6 +257,10 @@ func (o *ContainerOperation) Execute() {
 		o.taskProcessor.Process(logger, container)
 		return
 
+	case rep.PrewarmLifecycle:
+		logger.Debug("skipped-prewarm-container")
+		return
+
 	default:
 		logger.Error("failed-to-process-container-with-unknown-lifecycle", fmt.Errorf("unknown lifecycle: %s", lifecycle))
 		return
@@ -434,6 +434,26 @@ var _ = Describe("Operation", func() {
 					})
 				})
 
+				Context("when the container has a prewarm lifecycle tag", func() {
+					BeforeEach(func() {
+						container = executor.Container{
+							Tags: executor.Tags{
+								rep.LifecycleTag: rep.PrewarmLifecycle,
+							},
+						}
+						containerDelegate.GetContainerReturns(container, true)
+					})
+
+					It("does not farm the container out to any processor", func() {
+						Expect(lrpProcessor.ProcessCallCount()).To(Equal(0))
+						Expect(taskProcessor.ProcessCallCount()).To(Equal(0))
+					})
+
+					It("does not log an unknown lifecycle", func() {
+						Expect(logger).NotTo(Say("failed-to-process-container-with-unknown-lifecycle"))
+					})
+				})
+
 				Context("when the container has an unknown lifecycle tag", func() {
 					BeforeEach(func() {
 						container = executor.Container{
@@ -0,0 +1 @@
+package fake_restart_context // import "code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
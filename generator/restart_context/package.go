@@ -0,0 +1 @@
+package restart_context // import "code.cloudfoundry.org/rep/generator/restart_context"
@@ -0,0 +1,81 @@
+package restart_context
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+)
+
+//go:generate counterfeiter -o fake_restart_context/fake_restart_requester.go . RestartRequester
+
+// RestartRequester is used by the restart handler to ask for an LRP
+// instance's container to be run again in place once it has stopped.
+// RequestRestart returns false when the cell is evacuating, and the stop
+// handlers cancel the request when the instance is stopped for good.
+type RestartRequester interface {
+	RequestRestart(guid string) bool
+	CancelRestart(guid string)
+}
+
+//go:generate counterfeiter -o fake_restart_context/fake_restart_claimer.go . RestartClaimer
+
+// RestartClaimer is used by the LRP processor to find out whether a stopped
+// container should be run again rather than removed. ClaimRestart returns
+// true at most once per request. CancelRestart drops the request when the
+// processor stops the container itself, and CancelRestarts drops every
+// pending request once the cell starts evacuating.
+type RestartClaimer interface {
+	ClaimRestart(guid string) bool
+	CancelRestart(guid string)
+	CancelRestarts()
+}
+
+type restartContext struct {
+	evacuationReporter evacuation_context.EvacuationReporter
+	requested          map[string]struct{}
+	mu                 sync.Mutex
+}
+
+func New(evacuationReporter evacuation_context.EvacuationReporter) (RestartRequester, RestartClaimer) {
+	restartContext := &restartContext{
+		evacuationReporter: evacuationReporter,
+		requested:          make(map[string]struct{}),
+	}
+
+	return restartContext, restartContext
+}
+
+func (r *restartContext) RequestRestart(guid string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.evacuationReporter.Evacuating() {
+		return false
+	}
+
+	r.requested[guid] = struct{}{}
+	return true
+}
+
+func (r *restartContext) CancelRestart(guid string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.requested, guid)
+}
+
+func (r *restartContext) ClaimRestart(guid string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.requested[guid]
+	delete(r.requested, guid)
+	return ok
+}
+
+func (r *restartContext) CancelRestarts() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requested = make(map[string]struct{})
+}
@@ -0,0 +1,13 @@
+package restart_context_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRestartContext(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RestartContext Suite")
+}
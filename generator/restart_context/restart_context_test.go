@@ -0,0 +1,83 @@
+package restart_context_test
+
+import (
+	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	"code.cloudfoundry.org/rep/generator/restart_context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RestartContext", func() {
+	var (
+		evacuationReporter *fake_evacuation_context.FakeEvacuationReporter
+		restartRequester   restart_context.RestartRequester
+		restartClaimer     restart_context.RestartClaimer
+	)
+
+	BeforeEach(func() {
+		evacuationReporter = &fake_evacuation_context.FakeEvacuationReporter{}
+		restartRequester, restartClaimer = restart_context.New(evacuationReporter)
+	})
+
+	Context("when no restart has been requested", func() {
+		It("does not let the claimer claim one", func() {
+			Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+		})
+	})
+
+	Context("when a restart has been requested", func() {
+		BeforeEach(func() {
+			Expect(restartRequester.RequestRestart("some-guid")).To(BeTrue())
+		})
+
+		It("lets the claimer claim it once", func() {
+			Expect(restartClaimer.ClaimRestart("other-guid")).To(BeFalse())
+			Expect(restartClaimer.ClaimRestart("some-guid")).To(BeTrue())
+			Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+		})
+
+		Context("and then cancelled", func() {
+			BeforeEach(func() {
+				restartRequester.CancelRestart("some-guid")
+			})
+
+			It("does not let the claimer claim it", func() {
+				Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+			})
+		})
+
+		Context("and then cancelled by the claimer", func() {
+			BeforeEach(func() {
+				restartClaimer.CancelRestart("some-guid")
+			})
+
+			It("does not let the claimer claim it", func() {
+				Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+			})
+		})
+
+		Context("and then every restart is cancelled", func() {
+			BeforeEach(func() {
+				restartRequester.RequestRestart("other-guid")
+				restartClaimer.CancelRestarts()
+			})
+
+			It("does not let the claimer claim any", func() {
+				Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+				Expect(restartClaimer.ClaimRestart("other-guid")).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the cell is evacuating", func() {
+		BeforeEach(func() {
+			evacuationReporter.EvacuatingReturns(true)
+		})
+
+		It("rejects restart requests", func() {
+			Expect(restartRequester.RequestRestart("some-guid")).To(BeFalse())
+			Expect(restartClaimer.ClaimRestart("some-guid")).To(BeFalse())
+		})
+	})
+})
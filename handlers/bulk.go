@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/rep"
+)
+
+// maxBulkWorkers bounds the number of concurrent executor calls made for a
+// single bulk request.
+const maxBulkWorkers = 16
+
+// runBulk calls op once for each distinct guid, with at most maxBulkWorkers
+// calls in flight, and returns the result of each call keyed by guid.
+func runBulk(guids []string, op func(guid string) error) map[string]rep.BulkResult {
+	results := make(map[string]rep.BulkResult, len(guids))
+	var resultsLock sync.Mutex
+
+	seen := make(map[string]struct{}, len(guids))
+	unique := make([]string, 0, len(guids))
+	for _, guid := range guids {
+		if _, ok := seen[guid]; !ok {
+			seen[guid] = struct{}{}
+			unique = append(unique, guid)
+		}
+	}
+	guids = unique
+
+	workers := maxBulkWorkers
+	if len(guids) < workers {
+		workers = len(guids)
+	}
+
+	work := make(chan string)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for guid := range work {
+				var result rep.BulkResult
+				if err := op(guid); err != nil {
+					result.Error = err.Error()
+				}
+
+				resultsLock.Lock()
+				results[guid] = result
+				resultsLock.Unlock()
+			}
+		}()
+	}
+
+	for _, guid := range guids {
+		work <- guid
+	}
+	close(work)
+	wg.Wait()
+
+	return results
+}
@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+)
+
+const (
+	defaultCancelTaskWaitTimeout = 30 * time.Second
+	maxCancelTaskWaitTimeout     = 5 * time.Minute
 )
 
 type cancelTaskHandler struct {
@@ -21,6 +30,11 @@ func newCancelTaskHandler(executorClient executor.Client, requestMetrics helpers
 	}
 }
 
+// ServeHTTP deletes the task's container in the background and responds with
+// 202 Accepted. With ?wait=true, it waits for the deletion for up to
+// ?timeout (30s by default), responding with 200 OK once the container is
+// gone, or with 202 Accepted if it is still being deleted at the deadline.
+// The body always describes the container in a rep.CancelTaskStatus.
 func (h *cancelTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
 	start := time.Now()
 	requestType := "CancelTask"
@@ -33,22 +47,111 @@ func (h *cancelTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, lo
 	taskGuid := r.FormValue(":task_guid")
 	logger = logger.Session("cancel-task", lager.Data{"instance-guid": taskGuid})
 
-	w.WriteHeader(http.StatusAccepted)
+	wait, timeout, err := parseCancelTaskWait(r)
+	if err != nil {
+		logger.Error("invalid-request", err)
+		h.metrics.IncrementRequestsFailedCounter(requestType, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	deletedStatus := http.StatusAccepted
+	if wait {
+		deletedStatus = http.StatusOK
+	}
+
+	container, err := h.executorClient.GetContainer(logger, taskGuid)
+	switch err {
+	case nil:
+	case executor.ErrContainerNotFound:
+		logger.Info("container-not-found")
+		h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
+		writeCancelTaskStatus(w, deletedStatus, rep.CancelTaskStatus{Existed: false, Deleted: true})
+		return
+	default:
+		logger.Error("failed-getting-container", err)
+		h.metrics.IncrementRequestsFailedCounter(requestType, 1)
+		writeCancelTaskStatus(w, http.StatusInternalServerError, rep.CancelTaskStatus{Error: err.Error()})
+		return
+	}
+
+	status := rep.CancelTaskStatus{Existed: true, State: container.State}
 
+	deleted := make(chan error, 1)
 	go func() {
-		logger.Info("deleting-container")
-
-		err := h.executorClient.DeleteContainer(logger, taskGuid)
-		switch err {
-		case nil:
-			logger.Info("succeeded-deleting-container")
-			h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
-		case executor.ErrContainerNotFound:
-			logger.Info("container-not-found")
-			h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
-		default:
-			logger.Error("failed-deleting-container", err)
-			h.metrics.IncrementRequestsFailedCounter(requestType, 1)
-		}
+		deleted <- h.deleteContainer(logger, taskGuid, requestType)
 	}()
+
+	if !wait {
+		writeCancelTaskStatus(w, http.StatusAccepted, status)
+		return
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-deleted:
+		if err != nil {
+			status.Error = err.Error()
+			writeCancelTaskStatus(w, http.StatusInternalServerError, status)
+			return
+		}
+		status.Deleted = true
+		writeCancelTaskStatus(w, http.StatusOK, status)
+	case <-timer.C:
+		logger.Info("timed-out-waiting-for-deletion", lager.Data{"timeout": timeout})
+		writeCancelTaskStatus(w, http.StatusAccepted, status)
+	}
+}
+
+func (h *cancelTaskHandler) deleteContainer(logger lager.Logger, taskGuid, requestType string) error {
+	logger.Info("deleting-container")
+
+	err := h.executorClient.DeleteContainer(logger, taskGuid)
+	switch err {
+	case nil:
+		logger.Info("succeeded-deleting-container")
+		h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
+		return nil
+	case executor.ErrContainerNotFound:
+		logger.Info("container-not-found")
+		h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
+		return nil
+	default:
+		logger.Error("failed-deleting-container", err)
+		h.metrics.IncrementRequestsFailedCounter(requestType, 1)
+		return err
+	}
+}
+
+func parseCancelTaskWait(r *http.Request) (bool, time.Duration, error) {
+	value := r.URL.Query().Get("wait")
+	if value == "" {
+		return false, 0, nil
+	}
+
+	wait, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid wait: %q", value)
+	}
+
+	timeout := defaultCancelTaskWaitTimeout
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		timeout, err = time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return false, 0, fmt.Errorf("invalid timeout: %q", value)
+		}
+		if timeout > maxCancelTaskWaitTimeout {
+			timeout = maxCancelTaskWaitTimeout
+		}
+	}
+
+	return wait, timeout, nil
+}
+
+func writeCancelTaskStatus(w http.ResponseWriter, statusCode int, status rep.CancelTaskStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(status)
 }
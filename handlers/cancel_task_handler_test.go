@@ -2,10 +2,13 @@ package handlers_test
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 
 	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/rep"
+	"github.com/tedsuo/rata"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,6 +35,17 @@ var _ = Describe("CancelTask", func() {
 			Expect(taskGuidArg).To(Equal("some-guid"))
 		})
 
+		It("reports the state of the container", func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{Guid: "some-guid", State: executor.StateRunning}, nil)
+
+			_, body := Request(rep.CancelTaskRoute, params, nil)
+			Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: true, State: executor.StateRunning})))
+
+			Expect(fakeExecutorClient.GetContainerCallCount()).To(Equal(1))
+			_, guid := fakeExecutorClient.GetContainerArgsForCall(0)
+			Expect(guid).To(Equal("some-guid"))
+		})
+
 		It("emits request metrics", func() {
 			Request(rep.CancelTaskRoute, params, nil)
 
@@ -114,4 +128,128 @@ var _ = Describe("CancelTask", func() {
 			Expect(calledRequestType).To(Equal("CancelTask"))
 		})
 	})
+
+	Context("when the task's container is already gone", func() {
+		BeforeEach(func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{}, executor.ErrContainerNotFound)
+		})
+
+		It("reports that it did not exist, without deleting anything", func() {
+			status, body := Request(rep.CancelTaskRoute, params, nil)
+			Expect(status).To(Equal(http.StatusAccepted))
+			Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: false, Deleted: true})))
+			Consistently(fakeExecutorClient.DeleteContainerCallCount).Should(Equal(0))
+		})
+
+		It("emits success request metric", func() {
+			Request(rep.CancelTaskRoute, params, nil)
+
+			Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+			Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the container cannot be fetched", func() {
+		BeforeEach(func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{}, errors.New("boom"))
+		})
+
+		It("responds with Internal Server Error, without deleting anything", func() {
+			status, body := Request(rep.CancelTaskRoute, params, nil)
+			Expect(status).To(Equal(http.StatusInternalServerError))
+			Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Error: "boom"})))
+			Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when waiting for the deletion", func() {
+		var cancelRequest func() (int, []byte)
+
+		BeforeEach(func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{Guid: "some-guid", State: executor.StateRunning}, nil)
+
+			cancelRequest = func() (int, []byte) {
+				request, err := requestGenerator.CreateRequest(rep.CancelTaskRoute, rata.Params(params), nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.URL.RawQuery = "wait=true&timeout=100ms"
+
+				response, err := client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				defer response.Body.Close()
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				return response.StatusCode, body
+			}
+		})
+
+		Context("when the container is deleted", func() {
+			It("responds with OK once it is gone", func() {
+				status, body := cancelRequest()
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: true, State: executor.StateRunning, Deleted: true})))
+				Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the container is already gone", func() {
+			BeforeEach(func() {
+				fakeExecutorClient.GetContainerReturns(executor.Container{}, executor.ErrContainerNotFound)
+			})
+
+			It("responds with OK", func() {
+				status, body := cancelRequest()
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: false, Deleted: true})))
+			})
+		})
+
+		Context("when the deletion fails", func() {
+			BeforeEach(func() {
+				fakeExecutorClient.DeleteContainerReturns(errors.New("uh-oh"))
+			})
+
+			It("responds with Internal Server Error", func() {
+				status, body := cancelRequest()
+				Expect(status).To(Equal(http.StatusInternalServerError))
+				Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: true, State: executor.StateRunning, Error: "uh-oh"})))
+			})
+		})
+
+		Context("when the deletion outlasts the timeout", func() {
+			var release chan struct{}
+
+			BeforeEach(func() {
+				release = make(chan struct{})
+				fakeExecutorClient.DeleteContainerStub = func(lager.Logger, string) error {
+					<-release
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("responds with Accepted while the container is still being deleted", func() {
+				status, body := cancelRequest()
+				Expect(status).To(Equal(http.StatusAccepted))
+				Expect(body).To(MatchJSON(JSONFor(rep.CancelTaskStatus{Existed: true, State: executor.StateRunning})))
+			})
+		})
+
+		Context("when the timeout is invalid", func() {
+			It("responds with Bad Request", func() {
+				request, err := requestGenerator.CreateRequest(rep.CancelTaskRoute, rata.Params(params), nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.URL.RawQuery = "wait=true&timeout=soon"
+
+				response, err := client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				response.Body.Close()
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+)
+
+type cancelTasksHandler struct {
+	executorClient executor.Client
+	metrics        helpers.RequestMetrics
+}
+
+func newCancelTasksHandler(executorClient executor.Client, metrics helpers.RequestMetrics) *cancelTasksHandler {
+	return &cancelTasksHandler{executorClient: executorClient, metrics: metrics}
+}
+
+func (h *cancelTasksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "CancelTasks"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("cancel-tasks")
+
+	var request rep.CancelTasksRequest
+	deferErr = json.NewDecoder(r.Body).Decode(&request)
+	if deferErr != nil {
+		logger.Error("failed-to-unmarshal", deferErr)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	for _, taskGuid := range request.TaskGuids {
+		if taskGuid == "" {
+			deferErr = errors.New("task guids must not be empty")
+			logger.Error("invalid-task-guid", deferErr)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	logger.Info("deleting-containers", lager.Data{"count": len(request.TaskGuids)})
+	results := runBulk(request.TaskGuids, func(taskGuid string) error {
+		err := h.executorClient.DeleteContainer(logger, taskGuid)
+		switch err {
+		case nil:
+			return nil
+		case executor.ErrContainerNotFound:
+			logger.Info("container-not-found", lager.Data{"task-guid": taskGuid})
+			return nil
+		default:
+			logger.Error("failed-deleting-container", err, lager.Data{"task-guid": taskGuid})
+			return err
+		}
+	})
+	logger.Info("finished-deleting-containers", lager.Data{"count": len(results)})
+
+	json.NewEncoder(w).Encode(rep.BulkResponse{Results: results})
+}
@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CancelTasks", func() {
+	var request rep.CancelTasksRequest
+
+	BeforeEach(func() {
+		request = rep.CancelTasksRequest{TaskGuids: []string{"task-1", "task-2", "task-3"}}
+		fakeExecutorClient.DeleteContainerStub = func(_ lager.Logger, guid string) error {
+			switch guid {
+			case "task-2":
+				return executor.ErrContainerNotFound
+			case "task-3":
+				return errors.New("boom")
+			}
+			return nil
+		}
+	})
+
+	It("deletes every task's container and returns the result of each", func() {
+		status, body := Request(rep.CancelTasksRoute, nil, JSONReaderFor(request))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(JSONFor(rep.BulkResponse{Results: map[string]rep.BulkResult{
+			"task-1": {},
+			"task-2": {},
+			"task-3": {Error: "boom"},
+		}})))
+
+		Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(3))
+		guids := []string{}
+		for i := 0; i < 3; i++ {
+			_, guid := fakeExecutorClient.DeleteContainerArgsForCall(i)
+			guids = append(guids, guid)
+		}
+		Expect(guids).To(ConsistOf("task-1", "task-2", "task-3"))
+	})
+
+	It("emits the request metrics", func() {
+		Request(rep.CancelTasksRoute, nil, JSONReaderFor(request))
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("CancelTasks"))
+	})
+
+	Context("when a task guid is empty", func() {
+		BeforeEach(func() {
+			request.TaskGuids = append(request.TaskGuids, "")
+		})
+
+		It("responds with bad request and cancels nothing", func() {
+			status, _ := Request(rep.CancelTasksRoute, nil, JSONReaderFor(request))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the request is malformed", func() {
+		It("responds with bad request", func() {
+			status, _ := Request(rep.CancelTasksRoute, nil, strings.NewReader("foo"))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(fakeExecutorClient.DeleteContainerCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+const tarContentType = "application/x-tar"
+
+type containerDiagnostics struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newContainerDiagnosticsHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *containerDiagnostics {
+	return &containerDiagnostics{rep: rep, metrics: metrics}
+}
+
+func (h *containerDiagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "ContainerDiagnostics"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	guid := r.FormValue(":guid")
+	logger = logger.Session("container-diagnostics", lager.Data{"guid": guid})
+
+	lines := rep.DefaultDiagnosticsResultFileLines
+	if value := r.URL.Query().Get("lines"); value != "" {
+		lines, deferErr = strconv.Atoi(value)
+		if deferErr == nil && (lines < 0 || lines > rep.MaxDiagnosticsResultFileLines) {
+			deferErr = fmt.Errorf("lines must be between 0 and %d", rep.MaxDiagnosticsResultFileLines)
+		}
+		if deferErr != nil {
+			logger.Error("invalid-lines", deferErr)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	var diagnostics rep.ContainerDiagnostics
+	diagnostics, deferErr = h.rep.ContainerDiagnostics(logger, guid, lines)
+	if deferErr == executor.ErrContainerNotFound {
+		deferErr = nil
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-fetch-container-diagnostics", deferErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", tarContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", guid+".tar"))
+
+	deferErr = writeDiagnosticsTar(w, guid, start, diagnostics)
+	if deferErr != nil {
+		logger.Error("failed-to-write-diagnostics", deferErr)
+	}
+}
+
+type diagnosticsFile struct {
+	name  string
+	value interface{}
+}
+
+// writeDiagnosticsTar writes each part of the diagnostics as a file in a
+// directory named after the container.
+func writeDiagnosticsTar(w io.Writer, guid string, modTime time.Time, diagnostics rep.ContainerDiagnostics) error {
+	tarWriter := tar.NewWriter(w)
+
+	files := []diagnosticsFile{
+		{"container.json", diagnostics.Container},
+		{"run_result.json", diagnostics.Container.RunResult},
+		{"tags.json", diagnostics.Container.Tags},
+	}
+	if diagnostics.Metrics != nil {
+		files = append(files, diagnosticsFile{"metrics.json", diagnostics.Metrics})
+	}
+
+	for _, file := range files {
+		body, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		err = writeTarFile(tarWriter, path.Join(guid, file.name), modTime, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if diagnostics.ResultFileTail != "" {
+		err := writeTarFile(tarWriter, path.Join(guid, "result_file_tail"), modTime, []byte(diagnostics.ResultFileTail+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if diagnostics.ResultFileError != "" {
+		err := writeTarFile(tarWriter, path.Join(guid, "result_file_error"), modTime, []byte(diagnostics.ResultFileError+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, modTime time.Time, body []byte) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(body)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tarWriter.Write(body)
+	return err
+}
@@ -0,0 +1,184 @@
+package handlers_test
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/containermetrics"
+	"code.cloudfoundry.org/rep"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerDiagnostics", func() {
+	var (
+		params      rata.Params
+		query       string
+		diagnostics rep.ContainerDiagnostics
+	)
+
+	diagnosticsRequest := func() *http.Response {
+		request, err := requestGenerator.CreateRequest(rep.ContainerDiagnosticsRoute, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.URL.RawQuery = query
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		return response
+	}
+
+	readTar := func(r io.Reader) map[string]string {
+		files := map[string]string{}
+		tarReader := tar.NewReader(r)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				return files
+			}
+			Expect(err).NotTo(HaveOccurred())
+
+			body, err := ioutil.ReadAll(tarReader)
+			Expect(err).NotTo(HaveOccurred())
+			files[header.Name] = string(body)
+		}
+	}
+
+	BeforeEach(func() {
+		params = rata.Params{"guid": "some-guid"}
+		query = ""
+		diagnostics = rep.ContainerDiagnostics{
+			Container: executor.Container{
+				Guid:      "some-guid",
+				State:     executor.StateCompleted,
+				Tags:      executor.Tags{rep.LifecycleTag: rep.TaskLifecycle},
+				RunResult: executor.ContainerRunResult{Failed: true, FailureReason: "exit status 1"},
+			},
+			Metrics:        &containermetrics.CachedContainerMetrics{MetricGUID: "some-metric-guid", MemoryUsageBytes: 1024},
+			ResultFileTail: "line 1\nline 2",
+		}
+		fakeLocalRep.ContainerDiagnosticsReturns(diagnostics, nil)
+	})
+
+	It("returns the diagnostics as a tar stream", func() {
+		response := diagnosticsRequest()
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Type")).To(Equal("application/x-tar"))
+
+		files := readTar(response.Body)
+		Expect(files).To(HaveLen(5))
+		Expect(files["some-guid/container.json"]).To(MatchJSON(JSONFor(diagnostics.Container)))
+		Expect(files["some-guid/run_result.json"]).To(MatchJSON(JSONFor(diagnostics.Container.RunResult)))
+		Expect(files["some-guid/tags.json"]).To(MatchJSON(JSONFor(diagnostics.Container.Tags)))
+		Expect(files["some-guid/metrics.json"]).To(MatchJSON(JSONFor(diagnostics.Metrics)))
+		Expect(files["some-guid/result_file_tail"]).To(Equal("line 1\nline 2\n"))
+	})
+
+	It("asks for the default number of result file lines", func() {
+		diagnosticsRequest().Body.Close()
+
+		Expect(fakeLocalRep.ContainerDiagnosticsCallCount()).To(Equal(1))
+		_, guid, lines := fakeLocalRep.ContainerDiagnosticsArgsForCall(0)
+		Expect(guid).To(Equal("some-guid"))
+		Expect(lines).To(Equal(rep.DefaultDiagnosticsResultFileLines))
+	})
+
+	It("emits the request metrics", func() {
+		diagnosticsRequest().Body.Close()
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("ContainerDiagnostics"))
+	})
+
+	Context("when a number of result file lines is requested", func() {
+		BeforeEach(func() {
+			query = "lines=10"
+		})
+
+		It("passes it through", func() {
+			diagnosticsRequest().Body.Close()
+
+			_, _, lines := fakeLocalRep.ContainerDiagnosticsArgsForCall(0)
+			Expect(lines).To(Equal(10))
+		})
+	})
+
+	Context("when too many result file lines are requested", func() {
+		BeforeEach(func() {
+			query = "lines=1000000"
+		})
+
+		It("responds with bad request", func() {
+			response := diagnosticsRequest()
+			response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(fakeLocalRep.ContainerDiagnosticsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the number of result file lines is not a number", func() {
+		BeforeEach(func() {
+			query = "lines=all"
+		})
+
+		It("responds with bad request", func() {
+			response := diagnosticsRequest()
+			response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the result file could not be read", func() {
+		BeforeEach(func() {
+			diagnostics.Metrics = nil
+			diagnostics.ResultFileTail = ""
+			diagnostics.ResultFileError = "no such file"
+			fakeLocalRep.ContainerDiagnosticsReturns(diagnostics, nil)
+		})
+
+		It("includes the error instead of the tail", func() {
+			response := diagnosticsRequest()
+			defer response.Body.Close()
+
+			files := readTar(response.Body)
+			Expect(files).NotTo(HaveKey("some-guid/metrics.json"))
+			Expect(files).NotTo(HaveKey("some-guid/result_file_tail"))
+			Expect(files["some-guid/result_file_error"]).To(Equal("no such file\n"))
+		})
+	})
+
+	Context("when the container does not exist", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDiagnosticsReturns(rep.ContainerDiagnostics{}, executor.ErrContainerNotFound)
+		})
+
+		It("responds with not found", func() {
+			status, body := Request(rep.ContainerDiagnosticsRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+			Expect(body).To(BeEmpty())
+		})
+	})
+
+	Context("when the diagnostics cannot be gathered", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDiagnosticsReturns(rep.ContainerDiagnostics{}, errors.New("boom"))
+		})
+
+		It("fails, returning nothing", func() {
+			status, body := Request(rep.ContainerDiagnosticsRoute, params, nil)
+			Expect(status).To(Equal(http.StatusInternalServerError))
+			Expect(body).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/rep"
+)
+
+type protobufMessage interface {
+	MarshalProtobuf() ([]byte, error)
+	UnmarshalProtobuf(data []byte) error
+}
+
+// responseContentType picks the encoding of the response from the request's
+// Accept header. Protobuf is only used when the client asks for it and does
+// not prefer JSON; JSON is the default.
+func responseContentType(r *http.Request) string {
+	var protobufQ, jsonQ float64
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case rep.ProtobufContentType:
+			protobufQ = q
+		case rep.JSONContentType, "application/*", "*/*":
+			if q > jsonQ {
+				jsonQ = q
+			}
+		}
+	}
+
+	if protobufQ > 0 && protobufQ >= jsonQ {
+		return rep.ProtobufContentType
+	}
+	return rep.JSONContentType
+}
+
+// contentETag returns the entity tag of the response in the given content
+// type. Each encoding gets its own tag so that a cached JSON body is never
+// revalidated by a protobuf request, or the other way round.
+func contentETag(etag, contentType string) string {
+	if contentType != rep.ProtobufContentType {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + `-protobuf"`
+}
+
+// decodeRequest decodes the request body in the encoding named by its
+// Content-Type, which defaults to JSON.
+func decodeRequest(r *http.Request, v protobufMessage) error {
+	if !rep.IsProtobufContentType(r.Header.Get("Content-Type")) {
+		return json.NewDecoder(r.Body).Decode(v)
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalProtobuf(body)
+}
+
+// writeResponse encodes v in the given content type. The Content-Type header
+// must already be set.
+func writeResponse(w http.ResponseWriter, contentType string, v protobufMessage) error {
+	if contentType != rep.ProtobufContentType {
+		return json.NewEncoder(w).Encode(v)
+	}
+
+	body, err := v.MarshalProtobuf()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
@@ -3,12 +3,14 @@ package handlers
 import (
 	"net/http"
 
+	loggingclient "code.cloudfoundry.org/diego-logging-client"
 	"code.cloudfoundry.org/executor"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/locket/metrics/helpers"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/auctioncellrep"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 	"github.com/tedsuo/rata"
 )
 
@@ -16,7 +18,9 @@ func New(
 	localCellClient auctioncellrep.AuctionCellClient,
 	localMetricCollector MetricCollector,
 	executorClient executor.Client,
+	metronClient loggingclient.IngressClient,
 	evacuatable evacuation_context.Evacuatable,
+	restartRequester restart_context.RestartRequester,
 	requestMetrics helpers.RequestMetrics,
 	logger lager.Logger,
 	secure bool,
@@ -25,19 +29,39 @@ func New(
 	handlers := rata.Handlers{}
 	if secure {
 		stateHandler := newStateHandler(localCellClient, requestMetrics)
+		stateEventsHandler := newStateEventsHandler(localCellClient, requestMetrics)
 		containerMetricsHandler := newContainerMetricsHandler(localMetricCollector, requestMetrics)
 		performHandler := newPerformHandler(localCellClient, requestMetrics)
+		performDryRunHandler := newPerformDryRunHandler(localCellClient, requestMetrics)
+		reserveHandler := newReserveHandler(localCellClient, requestMetrics)
+		prewarmHandler := newPrewarmHandler(localCellClient, requestMetrics)
 		resetHandler := newResetHandler(localCellClient, requestMetrics)
-		stopLrpHandler := NewStopLRPInstanceHandler(executorClient, requestMetrics)
+		stopLrpHandler := NewStopLRPInstanceHandler(executorClient, restartRequester, metronClient, requestMetrics)
+		restartLrpHandler := newRestartLRPInstanceHandler(executorClient, restartRequester, requestMetrics)
 		cancelTaskHandler := newCancelTaskHandler(executorClient, requestMetrics)
+		stopLrpsHandler := newStopLRPInstancesHandler(executorClient, restartRequester, requestMetrics)
+		cancelTasksHandler := newCancelTasksHandler(executorClient, requestMetrics)
+		lrpInstanceDetailHandler := newLRPInstanceDetailHandler(localCellClient, requestMetrics)
+		taskDetailHandler := newTaskDetailHandler(localCellClient, requestMetrics)
+		containerDiagnosticsHandler := newContainerDiagnosticsHandler(localCellClient, requestMetrics)
 
 		handlers[rep.StateRoute] = logWrap(stateHandler.ServeHTTP, logger)
+		handlers[rep.StateEventsRoute] = logWrap(stateEventsHandler.ServeHTTP, logger)
 		handlers[rep.ContainerMetricsRoute] = logWrap(containerMetricsHandler.ServeHTTP, logger)
 		handlers[rep.PerformRoute] = logWrap(performHandler.ServeHTTP, logger)
+		handlers[rep.PerformDryRunRoute] = logWrap(performDryRunHandler.ServeHTTP, logger)
+		handlers[rep.ReserveRoute] = logWrap(reserveHandler.ServeHTTP, logger)
+		handlers[rep.PrewarmRoute] = logWrap(prewarmHandler.ServeHTTP, logger)
 		handlers[rep.SimResetRoute] = logWrap(resetHandler.ServeHTTP, logger)
 
 		handlers[rep.StopLRPInstanceRoute] = logWrap(stopLrpHandler.ServeHTTP, logger)
+		handlers[rep.RestartLRPInstanceRoute] = logWrap(restartLrpHandler.ServeHTTP, logger)
 		handlers[rep.CancelTaskRoute] = logWrap(cancelTaskHandler.ServeHTTP, logger)
+		handlers[rep.StopLRPInstancesRoute] = logWrap(stopLrpsHandler.ServeHTTP, logger)
+		handlers[rep.CancelTasksRoute] = logWrap(cancelTasksHandler.ServeHTTP, logger)
+		handlers[rep.LRPInstanceDetailRoute] = logWrap(lrpInstanceDetailHandler.ServeHTTP, logger)
+		handlers[rep.TaskDetailRoute] = logWrap(taskDetailHandler.ServeHTTP, logger)
+		handlers[rep.ContainerDiagnosticsRoute] = logWrap(containerDiagnosticsHandler.ServeHTTP, logger)
 	} else {
 		pingHandler := newPingHandler(requestMetrics)
 		evacuationHandler := newEvacuationHandler(evacuatable, requestMetrics)
@@ -52,7 +76,9 @@ func New(
 // this isn't being used in the Rep anymore. It is used in tests that run a
 // fake cell. Without this function those tests will have to replicate the code
 // below. Those places are auctioneer fake_cell_test.go and rep's
-// handlers_suite_test.go
+// handlers_suite_test.go. Stop reasons are not written to the app's log
+// stream, since there is no metron client, and restarted instances are only
+// stopped, since there is no LRP processor to run them again.
 func NewLegacy(
 	localCellClient auctioncellrep.AuctionCellClient,
 	localMetricCollector MetricCollector,
@@ -61,14 +87,20 @@ func NewLegacy(
 	requestMetrics helpers.RequestMetrics,
 	logger lager.Logger,
 ) rata.Handlers {
-	insecureHandlers := New(localCellClient, localMetricCollector, executorClient, evacuatable, requestMetrics, logger, false)
-	secureHandlers := New(localCellClient, localMetricCollector, executorClient, evacuatable, requestMetrics, logger, true)
+	restartRequester, _ := restart_context.New(neverEvacuating{})
+	insecureHandlers := New(localCellClient, localMetricCollector, executorClient, nil, evacuatable, restartRequester, requestMetrics, logger, false)
+	secureHandlers := New(localCellClient, localMetricCollector, executorClient, nil, evacuatable, restartRequester, requestMetrics, logger, true)
 	for name, handler := range secureHandlers {
 		insecureHandlers[name] = handler
 	}
 	return insecureHandlers
 }
 
+// neverEvacuating lets the legacy handlers accept every restart request.
+type neverEvacuating struct{}
+
+func (neverEvacuating) Evacuating() bool { return false }
+
 func logWrap(loggable func(http.ResponseWriter, *http.Request, lager.Logger), logger lager.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requestLog := logger.Session("request", lager.Data{
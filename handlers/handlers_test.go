@@ -4,6 +4,7 @@ import (
 	"code.cloudfoundry.org/locket/metrics/helpers/helpersfakes"
 	"code.cloudfoundry.org/rep"
 	"code.cloudfoundry.org/rep/evacuation/evacuation_context/fake_evacuation_context"
+	"code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
 	"code.cloudfoundry.org/rep/handlers"
 
 	executorfakes "code.cloudfoundry.org/executor/fakes"
@@ -21,7 +22,7 @@ var _ = Describe("New", func() {
 			fakeExecutorClient := new(executorfakes.FakeClient)
 			fakeEvacuatable := new(fake_evacuation_context.FakeEvacuatable)
 			fakeRequestMetrics := new(helpersfakes.FakeRequestMetrics)
-			test_handlers = handlers.New(fakeLocalRep, fakeMetricCollector, fakeExecutorClient, fakeEvacuatable, fakeRequestMetrics, logger, false)
+			test_handlers = handlers.New(fakeLocalRep, fakeMetricCollector, fakeExecutorClient, nil, fakeEvacuatable, new(fake_restart_context.FakeRestartRequester), fakeRequestMetrics, logger, false)
 		})
 
 		It("has no secure routes", func() {
@@ -42,7 +43,7 @@ var _ = Describe("New", func() {
 			fakeExecutorClient := new(executorfakes.FakeClient)
 			fakeEvacuatable := new(fake_evacuation_context.FakeEvacuatable)
 			fakeRequestMetrics := new(helpersfakes.FakeRequestMetrics)
-			test_handlers = handlers.New(fakeLocalRep, fakeMetricCollector, fakeExecutorClient, fakeEvacuatable, fakeRequestMetrics, logger, true)
+			test_handlers = handlers.New(fakeLocalRep, fakeMetricCollector, fakeExecutorClient, nil, fakeEvacuatable, new(fake_restart_context.FakeRestartRequester), fakeRequestMetrics, logger, true)
 		})
 
 		It("has all the secure routes", func() {
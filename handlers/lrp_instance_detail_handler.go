@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+type lrpInstanceDetail struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newLRPInstanceDetailHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *lrpInstanceDetail {
+	return &lrpInstanceDetail{rep: rep, metrics: metrics}
+}
+
+func (h *lrpInstanceDetail) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "LRPInstanceDetail"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	processGuid := r.FormValue(":process_guid")
+	instanceGuid := r.FormValue(":instance_guid")
+
+	logger = logger.Session("lrp-instance-detail", lager.Data{
+		"process-guid":  processGuid,
+		"instance-guid": instanceGuid,
+	})
+
+	var detail rep.ContainerDetail
+	detail, deferErr = h.rep.ContainerDetail(logger, rep.LRPContainerGuid(processGuid, instanceGuid))
+	if deferErr == executor.ErrContainerNotFound {
+		deferErr = nil
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-fetch-container-detail", deferErr)
+		return
+	}
+
+	if detail.Lifecycle != rep.LRPLifecycle || detail.ProcessGuid != processGuid {
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", rep.JSONContentType)
+	json.NewEncoder(w).Encode(detail)
+}
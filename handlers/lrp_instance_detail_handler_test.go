@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/rep"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LRPInstanceDetail", func() {
+	var (
+		params rata.Params
+		detail rep.ContainerDetail
+	)
+
+	BeforeEach(func() {
+		params = rata.Params{"process_guid": "some-process-guid", "instance_guid": "some-instance-guid"}
+		detail = rep.ContainerDetail{
+			Guid:         "some-instance-guid",
+			Lifecycle:    rep.LRPLifecycle,
+			ProcessGuid:  "some-process-guid",
+			InstanceGuid: "some-instance-guid",
+			State:        executor.StateRunning,
+		}
+		fakeLocalRep.ContainerDetailReturns(detail, nil)
+	})
+
+	It("returns the container detail", func() {
+		status, body := Request(rep.LRPInstanceDetailRoute, params, nil)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(JSONFor(detail)))
+
+		Expect(fakeLocalRep.ContainerDetailCallCount()).To(Equal(1))
+		_, guid := fakeLocalRep.ContainerDetailArgsForCall(0)
+		Expect(guid).To(Equal(rep.LRPContainerGuid("some-process-guid", "some-instance-guid")))
+	})
+
+	It("emits the request metrics", func() {
+		Request(rep.LRPInstanceDetailRoute, params, nil)
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("LRPInstanceDetail"))
+	})
+
+	Context("when the container does not exist", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{}, executor.ErrContainerNotFound)
+		})
+
+		It("responds with not found", func() {
+			status, body := Request(rep.LRPInstanceDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+			Expect(body).To(BeEmpty())
+		})
+	})
+
+	Context("when the container belongs to another process", func() {
+		BeforeEach(func() {
+			detail.ProcessGuid = "other-process-guid"
+			fakeLocalRep.ContainerDetailReturns(detail, nil)
+		})
+
+		It("responds with not found", func() {
+			status, _ := Request(rep.LRPInstanceDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the container is not an LRP instance", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{Lifecycle: rep.TaskLifecycle}, nil)
+		})
+
+		It("responds with not found", func() {
+			status, _ := Request(rep.LRPInstanceDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the container cannot be fetched", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{}, errors.New("boom"))
+		})
+
+		It("fails, returning nothing", func() {
+			status, body := Request(rep.LRPInstanceDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusInternalServerError))
+			Expect(body).To(BeEmpty())
+		})
+	})
+})
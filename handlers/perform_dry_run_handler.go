@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+type performDryRun struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newPerformDryRunHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *performDryRun {
+	return &performDryRun{rep: rep, metrics: metrics}
+}
+
+func (h *performDryRun) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "PerformDryRun"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("auction-perform-work-dry-run")
+	var work rep.Work
+	deferErr = decodeRequest(r, &work)
+	if deferErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		logger.Error("failed-to-unmarshal", deferErr)
+		return
+	}
+
+	var failedWork rep.Work
+	failedWork, deferErr = h.rep.PerformDryRun(logger, work)
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-perform-work-dry-run", deferErr)
+		return
+	}
+
+	contentType := responseContentType(r)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Vary", "Accept")
+	err := writeResponse(w, contentType, &failedWork)
+	if err != nil {
+		logger.Error("failed-to-encode-work", err)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PerformDryRun", func() {
+	Context("with valid JSON", func() {
+		var requestedWork, failedWork rep.Work
+
+		BeforeEach(func() {
+			resourceA := rep.NewResource(128, 256, 256)
+			resourceB := rep.NewResource(256, 512, 256)
+			placementConstraint := rep.NewPlacementConstraint("some-rootfs", nil, nil)
+
+			requestedWork = rep.Work{
+				Tasks: []rep.Task{
+					rep.NewTask("a", "domain", resourceA, placementConstraint),
+					rep.NewTask("b", "domain", resourceB, placementConstraint),
+				},
+			}
+
+			failedTask := rep.NewTask("b", "domain", resourceB, placementConstraint)
+			failedTask.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: memory")
+			failedWork = rep.Work{Tasks: []rep.Task{failedTask}}
+		})
+
+		Context("and no error", func() {
+			BeforeEach(func() {
+				fakeLocalRep.PerformDryRunReturns(failedWork, nil)
+			})
+
+			It("succeeds, returning the work that would fail", func() {
+				status, body := Request(rep.PerformDryRunRoute, nil, JSONReaderFor(requestedWork))
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body).To(MatchJSON(JSONFor(failedWork)))
+
+				Expect(fakeLocalRep.PerformDryRunCallCount()).To(Equal(1))
+				_, actualWork := fakeLocalRep.PerformDryRunArgsForCall(0)
+				Expect(actualWork).To(Equal(requestedWork))
+			})
+
+			It("does not perform the work", func() {
+				Request(rep.PerformDryRunRoute, nil, JSONReaderFor(requestedWork))
+				Expect(fakeLocalRep.PerformCallCount()).To(Equal(0))
+			})
+
+			It("emits the request metrics", func() {
+				Request(rep.PerformDryRunRoute, nil, JSONReaderFor(requestedWork))
+
+				Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+				calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+				Expect(delta).To(Equal(1))
+				Expect(calledRequestType).To(Equal("PerformDryRun"))
+			})
+		})
+
+		Context("and an error", func() {
+			BeforeEach(func() {
+				fakeLocalRep.PerformDryRunReturns(rep.Work{}, errors.New("kaboom"))
+			})
+
+			It("fails, returning nothing", func() {
+				status, body := Request(rep.PerformDryRunRoute, nil, JSONReaderFor(requestedWork))
+				Expect(status).To(Equal(http.StatusInternalServerError))
+				Expect(body).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("with invalid JSON", func() {
+		It("fails", func() {
+			status, body := Request(rep.PerformDryRunRoute, nil, bytes.NewBufferString("∆"))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(BeEmpty())
+
+			Expect(fakeLocalRep.PerformDryRunCallCount()).To(Equal(0))
+		})
+	})
+})
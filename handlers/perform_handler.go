@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -30,7 +29,7 @@ func (h *perform) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager
 
 	logger = logger.Session("auction-perform-work")
 	var work rep.Work
-	deferErr = json.NewDecoder(r.Body).Decode(&work)
+	deferErr = decodeRequest(r, &work)
 	if deferErr != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		logger.Error("failed-to-unmarshal", deferErr)
@@ -45,5 +44,11 @@ func (h *perform) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager
 		return
 	}
 
-	json.NewEncoder(w).Encode(failedWork)
+	contentType := responseContentType(r)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Vary", "Accept")
+	err := writeResponse(w, contentType, &failedWork)
+	if err != nil {
+		logger.Error("failed-to-encode-work", err)
+	}
 }
@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"bytes"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"time"
 
@@ -62,6 +63,33 @@ var _ = Describe("Perform", func() {
 				Expect(actualWork).To(Equal(requestedWork))
 			})
 
+			It("accepts and returns protobuf", func() {
+				body, err := requestedWork.MarshalProtobuf()
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err := requestGenerator.CreateRequest(rep.PerformRoute, nil, bytes.NewReader(body))
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Content-Type", rep.ProtobufContentType)
+				request.Header.Set("Accept", rep.ProtobufContentType)
+
+				response, err := client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal(rep.ProtobufContentType))
+
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				var actualFailedWork rep.Work
+				Expect(actualFailedWork.UnmarshalProtobuf(responseBody)).To(Succeed())
+				Expect(actualFailedWork).To(Equal(failedWork))
+
+				_, actualWork := fakeLocalRep.PerformArgsForCall(0)
+				Expect(actualWork).To(Equal(requestedWork))
+			})
+
 			It("emits the request metrics", func() {
 				Request(rep.PerformRoute, nil, JSONReaderFor(requestedWork))
 
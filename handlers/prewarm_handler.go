@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+type prewarm struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newPrewarmHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *prewarm {
+	return &prewarm{rep: rep, metrics: metrics}
+}
+
+func (h *prewarm) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "Prewarm"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("auction-prewarm")
+	var request rep.PrewarmRequest
+	deferErr = json.NewDecoder(r.Body).Decode(&request)
+	if deferErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		logger.Error("failed-to-unmarshal", deferErr)
+		return
+	}
+
+	deferErr = h.rep.Prewarm(logger, request)
+	if deferErr != nil {
+		switch deferErr {
+		case auctioncellrep.ErrInvalidPrewarmRequest:
+			w.WriteHeader(http.StatusBadRequest)
+		case auctioncellrep.ErrCellEvacuating:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		logger.Error("failed-to-prewarm", deferErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
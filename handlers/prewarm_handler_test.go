@@ -0,0 +1,88 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prewarm", func() {
+	Context("with valid JSON", func() {
+		var request rep.PrewarmRequest
+
+		BeforeEach(func() {
+			request = rep.PrewarmRequest{RootFSes: []string{"preloaded:linux", "docker:///busybox"}}
+		})
+
+		Context("and no error", func() {
+			It("accepts the request", func() {
+				status, body := Request(rep.PrewarmRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusAccepted))
+				Expect(body).To(BeEmpty())
+
+				Expect(fakeLocalRep.PrewarmCallCount()).To(Equal(1))
+				_, actualRequest := fakeLocalRep.PrewarmArgsForCall(0)
+				Expect(actualRequest).To(Equal(request))
+			})
+
+			It("emits the request metrics", func() {
+				Request(rep.PrewarmRoute, nil, JSONReaderFor(request))
+
+				Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+				calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+				Expect(delta).To(Equal(1))
+				Expect(calledRequestType).To(Equal("Prewarm"))
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				fakeLocalRep.PrewarmReturns(auctioncellrep.ErrInvalidPrewarmRequest)
+			})
+
+			It("responds with a bad request", func() {
+				status, _ := Request(rep.PrewarmRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the cell is evacuating", func() {
+			BeforeEach(func() {
+				fakeLocalRep.PrewarmReturns(auctioncellrep.ErrCellEvacuating)
+			})
+
+			It("responds with a conflict", func() {
+				status, _ := Request(rep.PrewarmRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("and an unexpected error", func() {
+			BeforeEach(func() {
+				fakeLocalRep.PrewarmReturns(errors.New("kaboom"))
+			})
+
+			It("fails, returning nothing", func() {
+				status, body := Request(rep.PrewarmRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusInternalServerError))
+				Expect(body).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("with invalid JSON", func() {
+		It("fails", func() {
+			status, body := Request(rep.PrewarmRoute, nil, bytes.NewBufferString("∆"))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(BeEmpty())
+
+			Expect(fakeLocalRep.PrewarmCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+type reserve struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newReserveHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *reserve {
+	return &reserve{rep: rep, metrics: metrics}
+}
+
+func (h *reserve) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "Reserve"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("auction-reserve")
+	var request rep.ReservationRequest
+	deferErr = json.NewDecoder(r.Body).Decode(&request)
+	if deferErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		logger.Error("failed-to-unmarshal", deferErr)
+		return
+	}
+
+	var reservation rep.Reservation
+	reservation, deferErr = h.rep.Reserve(logger, request)
+	if deferErr != nil {
+		switch deferErr {
+		case auctioncellrep.ErrInvalidReservation:
+			w.WriteHeader(http.StatusBadRequest)
+		case auctioncellrep.ErrInsufficientResourcesForReservation, auctioncellrep.ErrCellEvacuating:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		logger.Error("failed-to-reserve", deferErr)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reservation)
+}
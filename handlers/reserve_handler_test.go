@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reserve", func() {
+	Context("with valid JSON", func() {
+		var (
+			request     rep.ReservationRequest
+			reservation rep.Reservation
+		)
+
+		BeforeEach(func() {
+			request = rep.ReservationRequest{MemoryMB: 512, DiskMB: 1024, Containers: 1}
+			reservation = rep.Reservation{ID: "some-reservation-id", MemoryMB: 512, DiskMB: 1024, Containers: 1, ExpiresAt: 1000}
+		})
+
+		Context("and no error", func() {
+			BeforeEach(func() {
+				fakeLocalRep.ReserveReturns(reservation, nil)
+			})
+
+			It("succeeds, returning the reservation", func() {
+				status, body := Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusCreated))
+				Expect(body).To(MatchJSON(JSONFor(reservation)))
+
+				Expect(fakeLocalRep.ReserveCallCount()).To(Equal(1))
+				_, actualRequest := fakeLocalRep.ReserveArgsForCall(0)
+				Expect(actualRequest).To(Equal(request))
+			})
+
+			It("emits the request metrics", func() {
+				Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+
+				Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+				calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+				Expect(delta).To(Equal(1))
+				Expect(calledRequestType).To(Equal("Reserve"))
+			})
+		})
+
+		Context("when the cell does not have enough resources", func() {
+			BeforeEach(func() {
+				fakeLocalRep.ReserveReturns(rep.Reservation{}, auctioncellrep.ErrInsufficientResourcesForReservation)
+			})
+
+			It("responds with a conflict", func() {
+				status, body := Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusConflict))
+				Expect(body).To(BeEmpty())
+			})
+		})
+
+		Context("when the cell is evacuating", func() {
+			BeforeEach(func() {
+				fakeLocalRep.ReserveReturns(rep.Reservation{}, auctioncellrep.ErrCellEvacuating)
+			})
+
+			It("responds with a conflict", func() {
+				status, _ := Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the reservation is invalid", func() {
+			BeforeEach(func() {
+				fakeLocalRep.ReserveReturns(rep.Reservation{}, auctioncellrep.ErrInvalidReservation)
+			})
+
+			It("responds with a bad request", func() {
+				status, _ := Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("and an unexpected error", func() {
+			BeforeEach(func() {
+				fakeLocalRep.ReserveReturns(rep.Reservation{}, errors.New("kaboom"))
+			})
+
+			It("fails, returning nothing", func() {
+				status, body := Request(rep.ReserveRoute, nil, JSONReaderFor(request))
+				Expect(status).To(Equal(http.StatusInternalServerError))
+				Expect(body).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("with invalid JSON", func() {
+		It("fails", func() {
+			status, body := Request(rep.ReserveRoute, nil, bytes.NewBufferString("∆"))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(BeEmpty())
+
+			Expect(fakeLocalRep.ReserveCallCount()).To(Equal(0))
+		})
+	})
+})
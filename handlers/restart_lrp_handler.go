@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context"
+)
+
+type restartLRPInstanceHandler struct {
+	client           executor.Client
+	restartRequester restart_context.RestartRequester
+	metrics          helpers.RequestMetrics
+}
+
+func newRestartLRPInstanceHandler(client executor.Client, restartRequester restart_context.RestartRequester, metrics helpers.RequestMetrics) *restartLRPInstanceHandler {
+	return &restartLRPInstanceHandler{
+		client:           client,
+		restartRequester: restartRequester,
+		metrics:          metrics,
+	}
+}
+
+// ServeHTTP stops a running LRP instance's container after asking for it to
+// be restarted, so that the LRP processor runs it again on this cell under
+// the same instance key instead of removing it.
+func (h *restartLRPInstanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "RestartLRPInstance"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	processGuid := r.FormValue(":process_guid")
+	instanceGuid := r.FormValue(":instance_guid")
+
+	logger = logger.Session("handling-restart-lrp-instance", lager.Data{
+		"process-guid":  processGuid,
+		"instance-guid": instanceGuid,
+	})
+
+	containerGuid := rep.LRPContainerGuid(processGuid, instanceGuid)
+
+	var container executor.Container
+	container, deferErr = h.client.GetContainer(logger, containerGuid)
+	if deferErr == executor.ErrContainerNotFound {
+		deferErr = nil
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-get-container", deferErr)
+		return
+	}
+
+	if container.Tags[rep.LifecycleTag] != rep.LRPLifecycle || container.Tags[rep.ProcessGuidTag] != processGuid {
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if container.State != executor.StateRunning {
+		logger.Info("container-not-running", lager.Data{"state": container.State})
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if !h.restartRequester.RequestRestart(containerGuid) {
+		logger.Info("cell-evacuating")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	deferErr = h.client.StopContainer(logger, containerGuid)
+	if deferErr != nil {
+		h.restartRequester.CancelRestart(containerGuid)
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-stop-container", deferErr)
+		return
+	}
+
+	logger.Info("restarting")
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
+	"code.cloudfoundry.org/rep/handlers"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RestartLRPInstance", func() {
+	var (
+		restartServer        *httptest.Server
+		restartRequests      *rata.RequestGenerator
+		fakeRestartRequester *fake_restart_context.FakeRestartRequester
+		container            executor.Container
+		params               rata.Params
+	)
+
+	restart := func() int {
+		request, err := restartRequests.CreateRequest(rep.RestartLRPInstanceRoute, params, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	BeforeEach(func() {
+		fakeRestartRequester = new(fake_restart_context.FakeRestartRequester)
+		fakeRestartRequester.RequestRestartReturns(true)
+
+		routes := rep.NewRoutes(true)
+		handler, err := rata.NewRouter(routes, handlers.New(fakeLocalRep, fakeMetricCollector, fakeExecutorClient, nil, fakeEvacuatable, fakeRestartRequester, fakeRequestMetrics, logger, true))
+		Expect(err).NotTo(HaveOccurred())
+		restartServer = httptest.NewServer(handler)
+		restartRequests = rata.NewRequestGenerator(restartServer.URL, routes)
+
+		params = rata.Params{"process_guid": "some-process-guid", "instance_guid": "some-instance-guid"}
+		container = executor.Container{
+			Guid:  "some-instance-guid",
+			State: executor.StateRunning,
+			Tags: executor.Tags{
+				rep.LifecycleTag:   rep.LRPLifecycle,
+				rep.ProcessGuidTag: "some-process-guid",
+			},
+		}
+		fakeExecutorClient.GetContainerReturns(container, nil)
+	})
+
+	AfterEach(func() {
+		restartServer.Close()
+	})
+
+	It("asks for the container to be restarted, then stops it", func() {
+		Expect(restart()).To(Equal(http.StatusAccepted))
+
+		Expect(fakeRestartRequester.RequestRestartCallCount()).To(Equal(1))
+		Expect(fakeRestartRequester.RequestRestartArgsForCall(0)).To(Equal("some-instance-guid"))
+
+		Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(1))
+		_, guid := fakeExecutorClient.StopContainerArgsForCall(0)
+		Expect(guid).To(Equal("some-instance-guid"))
+
+		Expect(fakeRestartRequester.CancelRestartCallCount()).To(Equal(0))
+	})
+
+	It("emits the request metrics", func() {
+		restart()
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("RestartLRPInstance"))
+	})
+
+	Context("when stopping the container fails", func() {
+		BeforeEach(func() {
+			fakeExecutorClient.StopContainerReturns(errors.New("boom"))
+		})
+
+		It("cancels the restart and responds with internal server error", func() {
+			Expect(restart()).To(Equal(http.StatusInternalServerError))
+
+			Expect(fakeRestartRequester.CancelRestartCallCount()).To(Equal(1))
+			Expect(fakeRestartRequester.CancelRestartArgsForCall(0)).To(Equal("some-instance-guid"))
+		})
+	})
+
+	Context("when the cell is evacuating", func() {
+		BeforeEach(func() {
+			fakeRestartRequester.RequestRestartReturns(false)
+		})
+
+		It("responds with service unavailable, without stopping the container", func() {
+			Expect(restart()).To(Equal(http.StatusServiceUnavailable))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the container is not running", func() {
+		BeforeEach(func() {
+			container.State = executor.StateCreated
+			fakeExecutorClient.GetContainerReturns(container, nil)
+		})
+
+		It("responds with conflict, without stopping it", func() {
+			Expect(restart()).To(Equal(http.StatusConflict))
+			Expect(fakeRestartRequester.RequestRestartCallCount()).To(Equal(0))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the container belongs to another process", func() {
+		BeforeEach(func() {
+			container.Tags[rep.ProcessGuidTag] = "other-process-guid"
+			fakeExecutorClient.GetContainerReturns(container, nil)
+		})
+
+		It("responds with not found", func() {
+			Expect(restart()).To(Equal(http.StatusNotFound))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the container does not exist", func() {
+		BeforeEach(func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{}, executor.ErrContainerNotFound)
+		})
+
+		It("responds with not found", func() {
+			Expect(restart()).To(Equal(http.StatusNotFound))
+			Expect(fakeRestartRequester.RequestRestartCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the container cannot be fetched", func() {
+		BeforeEach(func() {
+			fakeExecutorClient.GetContainerReturns(executor.Container{}, errors.New("boom"))
+		})
+
+		It("responds with internal server error", func() {
+			Expect(restart()).To(Equal(http.StatusInternalServerError))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+var errStreamingUnsupported = errors.New("response writer does not support streaming")
+
+type stateEvents struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newStateEventsHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *stateEvents {
+	return &stateEvents{rep: rep, metrics: metrics}
+}
+
+func (h *stateEvents) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "StateEvents"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("auction-stream-state")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		deferErr = errStreamingUnsupported
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-stream-state", deferErr)
+		return
+	}
+
+	var events <-chan rep.StateEvent
+	events, deferErr = h.rep.StreamState(logger, r.Context().Done())
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-stream-state", deferErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for event := range events {
+		err := rep.WriteStateEvent(w, event)
+		if err != nil {
+			logger.Info("client-disconnected", lager.Data{"error": err.Error()})
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateEvents", func() {
+	var events chan rep.StateEvent
+
+	BeforeEach(func() {
+		events = make(chan rep.StateEvent, 2)
+		events <- rep.NewStateSnapshotEvent(rep.CellState{CellID: "cell-id"})
+		events <- rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true}
+		close(events)
+
+		fakeLocalRep.StreamStateReturns(events, nil)
+	})
+
+	It("streams the events as server-sent events", func() {
+		request, err := requestGenerator.CreateRequest(rep.StateEventsRoute, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Type")).To(HavePrefix("text/event-stream"))
+
+		source := rep.NewStateEventSource(response.Body)
+		defer source.Close()
+
+		event, err := source.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event).To(Equal(rep.NewStateSnapshotEvent(rep.CellState{CellID: "cell-id"})))
+
+		event, err = source.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event).To(Equal(rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true}))
+	})
+
+	It("stops streaming once the client disconnects", func() {
+		blocking := make(chan rep.StateEvent)
+		fakeLocalRep.StreamStateReturns(blocking, nil)
+
+		request, err := requestGenerator.CreateRequest(rep.StateEventsRoute, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+
+		Eventually(fakeLocalRep.StreamStateCallCount).Should(Equal(1))
+		_, done := fakeLocalRep.StreamStateArgsForCall(0)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("emits the request metrics", func() {
+		Request(rep.StateEventsRoute, nil, nil)
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("StateEvents"))
+	})
+
+	Context("when the state cannot be streamed", func() {
+		BeforeEach(func() {
+			fakeLocalRep.StreamStateReturns(nil, errors.New("boom"))
+		})
+
+		It("fails, returning nothing", func() {
+			status, body := Request(rep.StateEventsRoute, nil, nil)
+			Expect(status).To(Equal(http.StatusInternalServerError))
+			Expect(body).To(BeEmpty())
+		})
+	})
+
+	It("ends the response when the stream ends", func() {
+		status, body := Request(rep.StateEventsRoute, nil, nil)
+		Expect(status).To(Equal(http.StatusOK))
+
+		source := rep.NewStateEventSource(ioutil.NopCloser(bytes.NewReader(body)))
+		_, err := source.Next()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = source.Next()
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
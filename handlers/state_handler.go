@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -30,6 +30,14 @@ func (h *state) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.L
 
 	logger = logger.Session("auction-fetch-state")
 
+	var query rep.StateQuery
+	query, deferErr = rep.ParseStateQuery(r.URL.Query())
+	if deferErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		logger.Error("failed-to-parse-query", deferErr)
+		return
+	}
+
 	var state rep.CellState
 	var healthy bool
 	state, healthy, deferErr = h.rep.State(logger)
@@ -39,10 +47,39 @@ func (h *state) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.L
 		return
 	}
 
+	if !query.IsZero() {
+		state = state.Filter(query)
+	}
+
+	contentType := responseContentType(r)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Vary", "Accept")
+
 	if !healthy {
 		logger.Info("cell-not-healthy")
 		w.WriteHeader(http.StatusServiceUnavailable)
+	} else if state.Generation != 0 {
+		etag := contentETag(query.ETag(state.Generation), contentType)
+		w.Header().Set("ETag", etag)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
 	}
 
-	json.NewEncoder(w).Encode(state)
+	err := writeResponse(w, contentType, &state)
+	if err != nil {
+		logger.Error("failed-to-encode-state", err)
+	}
+}
+
+// etagMatches returns true if the If-None-Match header lists the etag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
 }
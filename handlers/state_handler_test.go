@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"time"
 
@@ -67,6 +68,217 @@ var _ = Describe("State", func() {
 		Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(0))
 	})
 
+	It("returns JSON by default", func() {
+		request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		Expect(response.Header.Get("Content-Type")).To(Equal(rep.JSONContentType))
+	})
+
+	Context("when the client accepts protobuf", func() {
+		protobufRequest := func(accept string) *http.Response {
+			request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Accept", accept)
+
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			return response
+		}
+
+		It("returns the state in protobuf", func() {
+			response := protobufRequest("application/x-protobuf, application/json;q=0.9")
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal(rep.ProtobufContentType))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			var state rep.CellState
+			Expect(state.UnmarshalProtobuf(body)).To(Succeed())
+			Expect(state).To(Equal(repState))
+		})
+
+		It("returns JSON when the client prefers it", func() {
+			response := protobufRequest("application/x-protobuf;q=0.5, application/json")
+			defer response.Body.Close()
+
+			Expect(response.Header.Get("Content-Type")).To(Equal(rep.JSONContentType))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(JSONFor(repState)))
+		})
+	})
+
+	Context("when the request has a query", func() {
+		BeforeEach(func() {
+			repState.CellID = "cell-id"
+			repState.Zone = "z1"
+			repState.Tasks = []rep.Task{rep.NewTask("task-1", "domain", rep.NewResource(10, 20, 0), rep.PlacementConstraint{})}
+			repState.Generation = 42
+		})
+
+		queryRequest := func(rawQuery string) *http.Response {
+			request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.URL.RawQuery = rawQuery
+
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			return response
+		}
+
+		It("returns the filtered state", func() {
+			response := queryRequest("summary=true")
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(JSONFor(rep.CellState{
+				CellID:          "cell-id",
+				Zone:            "z1",
+				RootFSProviders: rep.RootFSProviders{"docker": rep.ArbitraryRootFSProvider{}},
+				Generation:      42,
+			})))
+		})
+
+		It("tags the response with an entity tag specific to the query", func() {
+			query := rep.StateQuery{Summary: true}
+			response := queryRequest(query.Values().Encode())
+			defer response.Body.Close()
+
+			Expect(response.Header.Get("ETag")).To(Equal(query.ETag(42)))
+			Expect(response.Header.Get("ETag")).NotTo(Equal(rep.StateETag(42)))
+		})
+
+		It("returns not modified when the query's tag matches", func() {
+			query := rep.StateQuery{Summary: true}
+			request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.URL.RawQuery = query.Values().Encode()
+			request.Header.Set("If-None-Match", query.ETag(42))
+
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusNotModified))
+		})
+
+		Context("when the query is invalid", func() {
+			It("fails without fetching the state", func() {
+				response := queryRequest("limit=-1")
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeLocalRep.StateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the state has a generation", func() {
+		BeforeEach(func() {
+			repState.Generation = 42
+		})
+
+		stateRequest := func(ifNoneMatch string) *http.Response {
+			request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			if ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", ifNoneMatch)
+			}
+
+			response, err := client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+			return response
+		}
+
+		It("returns the generation as the entity tag", func() {
+			response := stateRequest("")
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("ETag")).To(Equal(`"42"`))
+		})
+
+		It("returns not modified when the tag matches", func() {
+			response := stateRequest(`"41", "42"`)
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusNotModified))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(BeEmpty())
+		})
+
+		It("returns the state when the tag does not match", func() {
+			response := stateRequest(`"41"`)
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(JSONFor(repState)))
+		})
+
+		Context("and the client accepts protobuf", func() {
+			protobufStateRequest := func(ifNoneMatch string) *http.Response {
+				request, err := requestGenerator.CreateRequest(rep.StateRoute, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Accept", rep.ProtobufContentType)
+				request.Header.Set("If-None-Match", ifNoneMatch)
+
+				response, err := client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+				return response
+			}
+
+			It("returns a tag that differs from the JSON tag", func() {
+				response := protobufStateRequest("")
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("ETag")).To(Equal(`"42-protobuf"`))
+			})
+
+			It("does not match the JSON tag", func() {
+				response := protobufStateRequest(`"42"`)
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal(rep.ProtobufContentType))
+			})
+
+			It("returns not modified when the protobuf tag matches", func() {
+				response := protobufStateRequest(`"42-protobuf"`)
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusNotModified))
+			})
+		})
+
+		Context("and the cell is not healthy", func() {
+			BeforeEach(func() {
+				fakeLocalRep.StateReturns(repState, false, nil)
+			})
+
+			It("returns the state without a tag", func() {
+				response := stateRequest(`"42"`)
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+				Expect(response.Header.Get("ETag")).To(BeEmpty())
+			})
+		})
+	})
+
 	Context("when the state call is not healthy", func() {
 		BeforeEach(func() {
 			fakeLocalRep.StateReturns(repState, false, nil)
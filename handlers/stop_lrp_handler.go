@@ -1,27 +1,37 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"time"
 
+	loggingclient "code.cloudfoundry.org/diego-logging-client"
 	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/executor/depot/log_streamer"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/locket/metrics/helpers"
 	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context"
 )
 
 // This is public for testing purpose
 type StopLRPInstanceHandler struct {
-	client  executor.Client
-	metrics helpers.RequestMetrics
+	client           executor.Client
+	restartRequester restart_context.RestartRequester
+	metronClient     loggingclient.IngressClient
+	metrics          helpers.RequestMetrics
 }
 
 // This is public for testing purpose
-func NewStopLRPInstanceHandler(client executor.Client, metrics helpers.RequestMetrics) *StopLRPInstanceHandler {
+func NewStopLRPInstanceHandler(client executor.Client, restartRequester restart_context.RestartRequester, metronClient loggingclient.IngressClient, metrics helpers.RequestMetrics) *StopLRPInstanceHandler {
 	return &StopLRPInstanceHandler{
-		client:  client,
-		metrics: metrics,
+		client:           client,
+		restartRequester: restartRequester,
+		metronClient:     metronClient,
+		metrics:          metrics,
 	}
 }
 
@@ -55,12 +65,87 @@ func (h *StopLRPInstanceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	deferErr = h.client.StopContainer(logger, rep.LRPContainerGuid(processGuid, instanceGuid))
+	var request rep.StopLRPInstanceRequest
+	if r.Body != nil {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			deferErr = err
+			logger.Error("failed-to-read-request", deferErr)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(data) > 0 {
+			deferErr = json.Unmarshal(data, &request)
+			if deferErr != nil {
+				logger.Error("failed-to-unmarshal-request", deferErr)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	deferErr = request.Validate()
+	if deferErr != nil {
+		logger.Error("invalid-request", deferErr)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.HasStopOptions() {
+		// The executor stops every container with its own signal and graceful
+		// shutdown interval, so the instance is not stopped rather than stopped
+		// differently than asked.
+		logger.Error("stop-options-unsupported", rep.ErrStopOptionsUnsupported, lager.Data{
+			"grace-period-in-seconds": request.GracePeriodInSeconds,
+			"signal":                  request.Signal,
+		})
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(rep.ErrStopOptionsUnsupported.Error()))
+		return
+	}
+
+	containerGuid := rep.LRPContainerGuid(processGuid, instanceGuid)
+
+	// A stop overrides any restart still waiting for the container to stop.
+	h.restartRequester.CancelRestart(containerGuid)
+
+	deferErr = h.client.StopContainer(logger, containerGuid)
 	if deferErr != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		logger.Error("failed-to-stop-container", deferErr)
 		return
 	}
 
+	if request.Reason != "" {
+		h.streamReason(logger, containerGuid, instanceGuid, request.Reason)
+	}
+
 	w.WriteHeader(http.StatusAccepted)
 }
+
+func (h *StopLRPInstanceHandler) streamReason(logger lager.Logger, containerGuid, instanceGuid, reason string) {
+	logger.Info("stop-reason", lager.Data{"reason": reason})
+
+	if h.metronClient == nil {
+		return
+	}
+
+	container, err := h.client.GetContainer(logger, containerGuid)
+	if err != nil {
+		logger.Error("failed-to-get-container-for-reason", err)
+		return
+	}
+
+	streamer := log_streamer.New(
+		container.RunInfo.LogConfig.Guid,
+		container.RunInfo.LogConfig.SourceName,
+		container.RunInfo.LogConfig.Index,
+		container.RunInfo.LogConfig.Tags,
+		h.metronClient,
+		0,
+		0,
+	)
+	fmt.Fprintf(streamer.Stdout(), "Stopping instance %s: %s", instanceGuid, reason)
+	streamer.Flush()
+}
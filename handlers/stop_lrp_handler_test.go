@@ -9,33 +9,42 @@ import (
 	"net/url"
 	"time"
 
+	mfakes "code.cloudfoundry.org/diego-logging-client/testhelpers"
+	"code.cloudfoundry.org/executor"
 	executorfakes "code.cloudfoundry.org/executor/fakes"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context/fake_restart_context"
 	"code.cloudfoundry.org/rep/handlers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("StopLRPInstanceHandler", func() {
 	var (
-		stopInstanceHandler *handlers.StopLRPInstanceHandler
-		fakeClient          *executorfakes.FakeClient
-		resp                *httptest.ResponseRecorder
-		req                 *http.Request
-		logger              *lagertest.TestLogger
+		stopInstanceHandler  *handlers.StopLRPInstanceHandler
+		fakeClient           *executorfakes.FakeClient
+		fakeRestartRequester *fake_restart_context.FakeRestartRequester
+		fakeMetronClient     *mfakes.FakeIngressClient
+		resp                 *httptest.ResponseRecorder
+		req                  *http.Request
+		logger               *lagertest.TestLogger
 	)
 
 	BeforeEach(func() {
 		var err error
 
 		fakeClient = &executorfakes.FakeClient{}
+		fakeRestartRequester = &fake_restart_context.FakeRestartRequester{}
+		fakeMetronClient = &mfakes.FakeIngressClient{}
 
 		logger = lagertest.NewTestLogger("test")
 		logger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
 
-		stopInstanceHandler = handlers.NewStopLRPInstanceHandler(fakeClient, fakeRequestMetrics)
+		stopInstanceHandler = handlers.NewStopLRPInstanceHandler(fakeClient, fakeRestartRequester, fakeMetronClient, fakeRequestMetrics)
 
 		resp = httptest.NewRecorder()
 
@@ -83,6 +92,11 @@ var _ = Describe("StopLRPInstanceHandler", func() {
 				Expect(instanceGuid).To(Equal(instanceGuid))
 			})
 
+			It("drops any pending restart of the instance", func() {
+				Expect(fakeRestartRequester.CancelRestartCallCount()).To(Equal(1))
+				Expect(fakeRestartRequester.CancelRestartArgsForCall(0)).To(Equal(instanceGuid))
+			})
+
 			It("emits the request metrics", func() {
 				Expect(fakeRequestMetrics.IncrementRequestsStartedCounterCallCount()).To(Equal(1))
 				calledRequestType, delta := fakeRequestMetrics.IncrementRequestsStartedCounterArgsForCall(0)
@@ -131,6 +145,116 @@ var _ = Describe("StopLRPInstanceHandler", func() {
 				Expect(calledRequestType).To(Equal("StopLRPInstance"))
 			})
 		})
+
+		Context("with a reason", func() {
+			var sentLogsWhenStopped int
+
+			BeforeEach(func() {
+				sentLogsWhenStopped = -1
+				fakeClient.StopContainerStub = func(lager.Logger, string) error {
+					sentLogsWhenStopped = fakeMetronClient.SendAppLogCallCount()
+					return nil
+				}
+				req.Body = ioutil.NopCloser(bytes.NewBufferString(`{"reason":"blue/green cutover"}`))
+				fakeClient.GetContainerReturns(executor.Container{
+					RunInfo: executor.RunInfo{LogConfig: executor.LogConfig{Guid: "log-guid", SourceName: "source-name", Index: 3}},
+				}, nil)
+			})
+
+			It("writes the reason to the instance's log stream", func() {
+				Expect(fakeClient.GetContainerCallCount()).To(Equal(1))
+				_, guid := fakeClient.GetContainerArgsForCall(0)
+				Expect(guid).To(Equal("instance-guid"))
+
+				Expect(fakeMetronClient.SendAppLogCallCount()).To(Equal(1))
+				msg, sourceName, tags := fakeMetronClient.SendAppLogArgsForCall(0)
+				Expect(msg).To(Equal("Stopping instance instance-guid: blue/green cutover"))
+				Expect(sourceName).To(Equal("source-name"))
+				Expect(tags["source_id"]).To(Equal("log-guid"))
+				Expect(tags["instance_id"]).To(Equal("3"))
+			})
+
+			It("stops the instance before writing the reason", func() {
+				Expect(resp.Code).To(Equal(http.StatusAccepted))
+				Expect(fakeClient.StopContainerCallCount()).To(Equal(1))
+				Expect(sentLogsWhenStopped).To(Equal(0))
+			})
+
+			Context("when stopping the instance fails", func() {
+				BeforeEach(func() {
+					fakeClient.StopContainerReturns(errors.New("fail"))
+				})
+
+				It("does not write the reason", func() {
+					Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+					Expect(fakeClient.GetContainerCallCount()).To(Equal(0))
+					Expect(fakeMetronClient.SendAppLogCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the container cannot be fetched", func() {
+				BeforeEach(func() {
+					fakeClient.GetContainerReturns(executor.Container{}, errors.New("boom"))
+				})
+
+				It("still stops the instance", func() {
+					Expect(fakeMetronClient.SendAppLogCallCount()).To(Equal(0))
+					Expect(resp.Code).To(Equal(http.StatusAccepted))
+					Expect(fakeClient.StopContainerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when there is no metron client", func() {
+				BeforeEach(func() {
+					stopInstanceHandler = handlers.NewStopLRPInstanceHandler(fakeClient, fakeRestartRequester, nil, fakeRequestMetrics)
+				})
+
+				It("only logs the reason", func() {
+					Expect(fakeClient.GetContainerCallCount()).To(Equal(0))
+					Expect(logger.Buffer()).To(gbytes.Say("stop-reason.*blue/green cutover"))
+					Expect(fakeClient.StopContainerCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("with a grace period and a signal", func() {
+			BeforeEach(func() {
+				req.Body = ioutil.NopCloser(bytes.NewBufferString(`{"grace_period_in_seconds":300,"signal":"QUIT","reason":"draining"}`))
+			})
+
+			It("responds with 422 Unprocessable Entity, saying the executor cannot honour them", func() {
+				Expect(resp.Code).To(Equal(http.StatusUnprocessableEntity))
+				Expect(resp.Body.String()).To(Equal(rep.ErrStopOptionsUnsupported.Error()))
+			})
+
+			It("does not stop the instance", func() {
+				Expect(fakeClient.StopContainerCallCount()).To(Equal(0))
+				Expect(fakeRestartRequester.CancelRestartCallCount()).To(Equal(0))
+				Expect(fakeMetronClient.SendAppLogCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("with an invalid signal", func() {
+			BeforeEach(func() {
+				req.Body = ioutil.NopCloser(bytes.NewBufferString(`{"signal":"SIGTERM"}`))
+			})
+
+			It("responds with 400 Bad Request", func() {
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeClient.StopContainerCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("with a malformed body", func() {
+			BeforeEach(func() {
+				req.Body = ioutil.NopCloser(bytes.NewBufferString("foo"))
+			})
+
+			It("responds with 400 Bad Request", func() {
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeClient.StopContainerCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Context("when the request is invalid", func() {
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/generator/restart_context"
+)
+
+type stopLRPInstancesHandler struct {
+	client           executor.Client
+	restartRequester restart_context.RestartRequester
+	metrics          helpers.RequestMetrics
+}
+
+func newStopLRPInstancesHandler(client executor.Client, restartRequester restart_context.RestartRequester, metrics helpers.RequestMetrics) *stopLRPInstancesHandler {
+	return &stopLRPInstancesHandler{client: client, restartRequester: restartRequester, metrics: metrics}
+}
+
+func (h *stopLRPInstancesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "StopLRPInstances"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	logger = logger.Session("handling-stop-lrp-instances")
+
+	var request rep.StopLRPInstancesRequest
+	deferErr = json.NewDecoder(r.Body).Decode(&request)
+	if deferErr != nil {
+		logger.Error("failed-to-unmarshal", deferErr)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	processGuids := make(map[string]string, len(request.Instances))
+	instanceGuids := make([]string, 0, len(request.Instances))
+	for _, instance := range request.Instances {
+		if instance.ProcessGuid == "" || instance.InstanceGuid == "" {
+			deferErr = errors.New("process_guid and instance_guid are required for every instance")
+			logger.Error("invalid-instance", deferErr, lager.Data{"instance": instance})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		processGuids[instance.InstanceGuid] = instance.ProcessGuid
+		instanceGuids = append(instanceGuids, instance.InstanceGuid)
+	}
+
+	logger.Info("stopping", lager.Data{"count": len(instanceGuids)})
+	results := runBulk(instanceGuids, func(instanceGuid string) error {
+		containerGuid := rep.LRPContainerGuid(processGuids[instanceGuid], instanceGuid)
+		h.restartRequester.CancelRestart(containerGuid)
+		err := h.client.StopContainer(logger, containerGuid)
+		switch err {
+		case nil:
+			return nil
+		case executor.ErrContainerNotFound:
+			logger.Info("container-not-found", lager.Data{"container-guid": containerGuid})
+			return nil
+		default:
+			logger.Error("failed-to-stop-container", err, lager.Data{"container-guid": containerGuid})
+			return err
+		}
+	})
+	logger.Info("finished-stopping", lager.Data{"count": len(results)})
+
+	json.NewEncoder(w).Encode(rep.BulkResponse{Results: results})
+}
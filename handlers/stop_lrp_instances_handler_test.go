@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StopLRPInstances", func() {
+	var request rep.StopLRPInstancesRequest
+
+	BeforeEach(func() {
+		request = rep.StopLRPInstancesRequest{
+			Instances: []rep.LRPInstanceKey{
+				{ProcessGuid: "pg-1", InstanceGuid: "ig-1"},
+				{ProcessGuid: "pg-1", InstanceGuid: "ig-2"},
+				{ProcessGuid: "pg-2", InstanceGuid: "ig-3"},
+			},
+		}
+		fakeExecutorClient.StopContainerStub = func(_ lager.Logger, guid string) error {
+			switch guid {
+			case "ig-2":
+				return errors.New("boom")
+			case "ig-3":
+				return executor.ErrContainerNotFound
+			}
+			return nil
+		}
+	})
+
+	It("stops every instance and returns the result of each, treating a missing container as stopped", func() {
+		status, body := Request(rep.StopLRPInstancesRoute, nil, JSONReaderFor(request))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(JSONFor(rep.BulkResponse{Results: map[string]rep.BulkResult{
+			"ig-1": {},
+			"ig-2": {Error: "boom"},
+			"ig-3": {},
+		}})))
+
+		Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(3))
+		guids := []string{}
+		for i := 0; i < 3; i++ {
+			_, guid := fakeExecutorClient.StopContainerArgsForCall(i)
+			guids = append(guids, guid)
+		}
+		Expect(guids).To(ConsistOf("ig-1", "ig-2", "ig-3"))
+	})
+
+	It("stops each instance once", func() {
+		request.Instances = append(request.Instances, request.Instances[0])
+		Request(rep.StopLRPInstancesRoute, nil, JSONReaderFor(request))
+		Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(3))
+	})
+
+	It("emits the request metrics", func() {
+		Request(rep.StopLRPInstancesRoute, nil, JSONReaderFor(request))
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("StopLRPInstances"))
+	})
+
+	Context("when there are many instances", func() {
+		var (
+			inFlight, maxInFlight int
+			lock                  sync.Mutex
+		)
+
+		BeforeEach(func() {
+			inFlight, maxInFlight = 0, 0
+			request.Instances = nil
+			for i := 0; i < 100; i++ {
+				request.Instances = append(request.Instances, rep.LRPInstanceKey{
+					ProcessGuid:  "pg",
+					InstanceGuid: fmt.Sprintf("ig-%d", i),
+				})
+			}
+			fakeExecutorClient.StopContainerStub = func(lager.Logger, string) error {
+				lock.Lock()
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				lock.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				lock.Lock()
+				inFlight--
+				lock.Unlock()
+				return nil
+			}
+		})
+
+		It("stops them concurrently with a bounded number of workers", func() {
+			status, _ := Request(rep.StopLRPInstancesRoute, nil, JSONReaderFor(request))
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(100))
+			Expect(maxInFlight).To(BeNumerically(">", 1))
+			Expect(maxInFlight).To(BeNumerically("<=", 16))
+		})
+	})
+
+	Context("when an instance is missing a guid", func() {
+		BeforeEach(func() {
+			request.Instances = append(request.Instances, rep.LRPInstanceKey{ProcessGuid: "pg-3"})
+		})
+
+		It("responds with bad request and stops nothing", func() {
+			status, _ := Request(rep.StopLRPInstancesRoute, nil, JSONReaderFor(request))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the request is malformed", func() {
+		It("responds with bad request", func() {
+			status, _ := Request(rep.StopLRPInstancesRoute, nil, strings.NewReader("foo"))
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(fakeExecutorClient.StopContainerCallCount()).To(Equal(0))
+		})
+	})
+})
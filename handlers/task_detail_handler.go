@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/auctioncellrep"
+)
+
+type taskDetail struct {
+	rep     auctioncellrep.AuctionCellClient
+	metrics helpers.RequestMetrics
+}
+
+func newTaskDetailHandler(rep auctioncellrep.AuctionCellClient, metrics helpers.RequestMetrics) *taskDetail {
+	return &taskDetail{rep: rep, metrics: metrics}
+}
+
+func (h *taskDetail) ServeHTTP(w http.ResponseWriter, r *http.Request, logger lager.Logger) {
+	var deferErr error
+
+	start := time.Now()
+	requestType := "TaskDetail"
+	startMetrics(h.metrics, requestType)
+	defer stopMetrics(h.metrics, requestType, start, &deferErr)
+
+	taskGuid := r.FormValue(":task_guid")
+	logger = logger.Session("task-detail", lager.Data{"task-guid": taskGuid})
+
+	var detail rep.ContainerDetail
+	detail, deferErr = h.rep.ContainerDetail(logger, taskGuid)
+	if deferErr == executor.ErrContainerNotFound {
+		deferErr = nil
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if deferErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Error("failed-to-fetch-container-detail", deferErr)
+		return
+	}
+
+	if detail.Lifecycle != rep.TaskLifecycle {
+		logger.Info("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", rep.JSONContentType)
+	json.NewEncoder(w).Encode(detail)
+}
@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/executor"
+	"code.cloudfoundry.org/rep"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskDetail", func() {
+	var (
+		params rata.Params
+		detail rep.ContainerDetail
+	)
+
+	BeforeEach(func() {
+		params = rata.Params{"task_guid": "some-task-guid"}
+		detail = rep.ContainerDetail{
+			Guid:      "some-task-guid",
+			Lifecycle: rep.TaskLifecycle,
+			TaskGuid:  "some-task-guid",
+			State:     executor.StateCompleted,
+			RunResult: executor.ContainerRunResult{Failed: true, FailureReason: "exit status 1"},
+		}
+		fakeLocalRep.ContainerDetailReturns(detail, nil)
+	})
+
+	It("returns the container detail", func() {
+		status, body := Request(rep.TaskDetailRoute, params, nil)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(JSONFor(detail)))
+
+		Expect(fakeLocalRep.ContainerDetailCallCount()).To(Equal(1))
+		_, guid := fakeLocalRep.ContainerDetailArgsForCall(0)
+		Expect(guid).To(Equal("some-task-guid"))
+	})
+
+	It("emits the request metrics", func() {
+		Request(rep.TaskDetailRoute, params, nil)
+
+		Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+		calledRequestType, delta := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
+		Expect(delta).To(Equal(1))
+		Expect(calledRequestType).To(Equal("TaskDetail"))
+	})
+
+	Context("when the container does not exist", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{}, executor.ErrContainerNotFound)
+		})
+
+		It("responds with not found", func() {
+			status, body := Request(rep.TaskDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+			Expect(body).To(BeEmpty())
+		})
+	})
+
+	Context("when the container is not a task", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{Lifecycle: rep.LRPLifecycle}, nil)
+		})
+
+		It("responds with not found", func() {
+			status, _ := Request(rep.TaskDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the container cannot be fetched", func() {
+		BeforeEach(func() {
+			fakeLocalRep.ContainerDetailReturns(rep.ContainerDetail{}, errors.New("boom"))
+		})
+
+		It("fails, returning nothing", func() {
+			status, body := Request(rep.TaskDetailRoute, params, nil)
+			Expect(status).To(Equal(http.StatusInternalServerError))
+			Expect(body).To(BeEmpty())
+		})
+	})
+})
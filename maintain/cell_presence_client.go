@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/consuladapter"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/locket"
+	"github.com/hashicorp/consul/api"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -19,6 +20,7 @@ const CellSchemaKey = "cell"
 
 type CellPresenceClient interface {
 	NewCellPresenceRunner(logger lager.Logger, cellPresence *models.CellPresence, retryInterval, lockTTL time.Duration) ifrit.Runner
+	SetCellPresence(logger lager.Logger, cellPresence *models.CellPresence) error
 
 	CellById(logger lager.Logger, cellId string) (*models.CellPresence, error)
 	Cells(logger lager.Logger) (models.CellSet, error)
@@ -54,6 +56,25 @@ func (db *cellPresenceClient) NewCellPresenceRunner(logger lager.Logger, cellPre
 	return locket.NewPresence(logger, db.consulClient, CellSchemaPath(cellPresence.CellId), payload, db.clock, retryInterval, lockTTL)
 }
 
+// SetCellPresence replaces the value of the presence registered by a runner
+// from NewCellPresenceRunner. Consul keeps the key locked by the runner's
+// session, so the cell does not disappear while its presence is updated. If
+// the runner has to recreate its session, it registers the presence it was
+// created with again.
+func (db *cellPresenceClient) SetCellPresence(logger lager.Logger, cellPresence *models.CellPresence) error {
+	payload, err := models.ToJSON(cellPresence)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.consulClient.KV().Put(&api.KVPair{Key: CellSchemaPath(cellPresence.CellId), Value: payload}, nil)
+	if err != nil {
+		logger.Error("failed-setting-cell-presence", err)
+		return convertConsulError(err)
+	}
+	return nil
+}
+
 func (c *cellPresenceClient) Cells(logger lager.Logger) (models.CellSet, error) {
 	kvPairs, _, err := c.consulClient.KV().List(CellSchemaRoot(), nil)
 	if err != nil {
@@ -65,6 +65,34 @@ var _ = Describe("CellPresenceClient", func() {
 		})
 	})
 
+	Describe("SetCellPresence", func() {
+		var (
+			cellID  string
+			process ifrit.Process
+		)
+
+		BeforeEach(func() {
+			cellID = "cell-id"
+			process = ifrit.Invoke(cellPresenceClient.NewCellPresenceRunner(logger, newCellPresence(cellID), locket.RetryInterval, locket.DefaultSessionTTL))
+		})
+
+		AfterEach(func() {
+			ginkgomon.Interrupt(process)
+		})
+
+		It("replaces the registered presence without releasing it", func() {
+			updatedPresence := newCellPresence(cellID)
+			updatedPresence.Zone = "another-zone"
+
+			Expect(cellPresenceClient.SetCellPresence(logger, updatedPresence)).To(Succeed())
+
+			presence, err := cellPresenceClient.CellById(logger, cellID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(presence).To(BeEquivalentTo(updatedPresence))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+	})
+
 	Describe("Cells", func() {
 		const cell1 = "cell-id-1"
 		const cell2 = "cell-id-2"
@@ -0,0 +1,130 @@
+package maintain
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/locket/lock"
+	locketmodels "code.cloudfoundry.org/locket/models"
+	"github.com/tedsuo/ifrit"
+	"google.golang.org/grpc"
+)
+
+type locketPresence struct {
+	resource     *locketmodels.Resource
+	locker       *presenceLocker
+	ttlInSeconds int64
+	runner       ifrit.Runner
+
+	configLock sync.Mutex
+	config     Config
+	capacity   models.CellCapacity
+}
+
+// NewLocketCellPresence registers the cell's presence in locket under owner,
+// re-locking it every RetryInterval. Locket updates the value of a lock
+// re-acquired by its owner, so the preloaded rootfses can change while the
+// cell stays registered.
+func NewLocketCellPresence(
+	logger lager.Logger,
+	config Config,
+	capacity models.CellCapacity,
+	locketClient locketmodels.LocketClient,
+	owner string,
+	ttlInSeconds int64,
+	clock clock.Clock,
+) (CellPresence, error) {
+	value, err := cellPresenceValue(config, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &locketmodels.Resource{
+		Key:      config.CellID,
+		Owner:    owner,
+		Value:    value,
+		TypeCode: locketmodels.PRESENCE,
+		Type:     locketmodels.PresenceType,
+	}
+	locker := &presenceLocker{LocketClient: locketClient, value: value}
+
+	logger.Debug("presence-payload", lager.Data{"payload": resource})
+	return &locketPresence{
+		resource:     resource,
+		locker:       locker,
+		ttlInSeconds: ttlInSeconds,
+		runner:       lock.NewPresenceRunner(logger, locker, resource, ttlInSeconds, clock, config.RetryInterval),
+		config:       config,
+		capacity:     capacity,
+	}, nil
+}
+
+func (p *locketPresence) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	return p.runner.Run(signals, ready)
+}
+
+// SetPreloadedRootFSes re-locks the presence with the new preloaded rootfses
+// right away. If that fails, the next periodic re-lock advertises them.
+func (p *locketPresence) SetPreloadedRootFSes(logger lager.Logger, rootFSes []string) error {
+	p.configLock.Lock()
+	defer p.configLock.Unlock()
+
+	p.config.PreloadedRootFSes = rootFSes
+	value, err := cellPresenceValue(p.config, p.capacity)
+	if err != nil {
+		return err
+	}
+	p.locker.setValue(value)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.ttlInSeconds)*time.Second)
+	defer cancel()
+
+	_, err = p.locker.Lock(ctx, &locketmodels.LockRequest{Resource: p.resource, TtlInSeconds: p.ttlInSeconds})
+	if err != nil {
+		logger.Error("failed-updating-presence", err)
+		return err
+	}
+	return nil
+}
+
+func cellPresenceValue(config Config, capacity models.CellCapacity) (string, error) {
+	cellPresence := models.NewCellPresence(config.CellID, config.RepAddress, config.RepUrl, config.Zone, capacity,
+		config.RootFSProviders, config.PreloadedRootFSes, config.PlacementTags, config.OptionalPlacementTags)
+
+	payload, err := json.Marshal(cellPresence)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// presenceLocker locks the presence with its current value, since the
+// presence runner keeps locking the resource it was created with.
+type presenceLocker struct {
+	locketmodels.LocketClient
+
+	lock  sync.Mutex
+	value string
+}
+
+func (l *presenceLocker) Lock(ctx context.Context, request *locketmodels.LockRequest, opts ...grpc.CallOption) (*locketmodels.LockResponse, error) {
+	l.lock.Lock()
+	resource := *request.Resource
+	resource.Value = l.value
+	l.lock.Unlock()
+
+	return l.LocketClient.Lock(ctx, &locketmodels.LockRequest{Resource: &resource, TtlInSeconds: request.TtlInSeconds}, opts...)
+}
+
+func (l *presenceLocker) setValue(value string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.value = value
+}
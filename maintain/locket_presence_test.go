@@ -0,0 +1,127 @@
+package maintain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	locketmodels "code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/models/modelsfakes"
+	"code.cloudfoundry.org/rep/maintain"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocketCellPresence", func() {
+	var (
+		logger       *lagertest.TestLogger
+		locketClient *modelsfakes.FakeLocketClient
+		clock        *fakeclock.FakeClock
+		config       maintain.Config
+		presence     maintain.CellPresence
+		process      ifrit.Process
+	)
+
+	lockedPresence := func(i int) models.CellPresence {
+		_, request, _ := locketClient.LockArgsForCall(i)
+		Expect(request.Resource.Owner).To(Equal("owner"))
+
+		var cellPresence models.CellPresence
+		Expect(json.Unmarshal([]byte(request.Resource.Value), &cellPresence)).To(Succeed())
+		return cellPresence
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		locketClient = &modelsfakes.FakeLocketClient{}
+		clock = fakeclock.NewFakeClock(time.Now())
+
+		config = maintain.Config{
+			CellID:            "cell-id",
+			RepAddress:        "1.2.3.4",
+			RepUrl:            "https://cell-id.service.cf.internal",
+			Zone:              "az1",
+			RetryInterval:     time.Second,
+			RootFSProviders:   []string{"provider-1"},
+			PreloadedRootFSes: []string{"cflinuxfs3"},
+		}
+
+		var err error
+		presence, err = maintain.NewLocketCellPresence(logger, config, models.NewCellCapacity(128, 1024, 6), locketClient, "owner", 15, clock)
+		Expect(err).NotTo(HaveOccurred())
+
+		process = ginkgomon.Invoke(presence)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("registers the cell's presence", func() {
+		expectedPresence, err := json.Marshal(models.NewCellPresence(
+			"cell-id",
+			"1.2.3.4",
+			"https://cell-id.service.cf.internal",
+			"az1",
+			models.NewCellCapacity(128, 1024, 6),
+			[]string{"provider-1"},
+			[]string{"cflinuxfs3"},
+			nil,
+			nil,
+		))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(locketClient.LockCallCount()).To(Equal(1))
+		_, request, _ := locketClient.LockArgsForCall(0)
+		Expect(request.Resource.Key).To(Equal("cell-id"))
+		Expect(request.Resource.Owner).To(Equal("owner"))
+		Expect(request.Resource.Value).To(MatchJSON(expectedPresence))
+		Expect(request.Resource.Type).To(Equal(locketmodels.PresenceType))
+		Expect(request.TtlInSeconds).To(Equal(int64(15)))
+	})
+
+	Context("when the preloaded rootfses change", func() {
+		BeforeEach(func() {
+			Expect(presence.SetPreloadedRootFSes(logger, []string{"cflinuxfs3", "cflinuxfs4"})).To(Succeed())
+		})
+
+		It("re-locks the presence with them right away", func() {
+			Expect(locketClient.LockCallCount()).To(Equal(2))
+			Expect(lockedPresence(1).RootfsProviders).To(ContainElement(&models.Provider{
+				Name:       models.PreloadedRootFSScheme,
+				Properties: []string{"cflinuxfs3", "cflinuxfs4"},
+			}))
+		})
+
+		It("keeps the presence registered without releasing it", func() {
+			Expect(locketClient.ReleaseCallCount()).To(Equal(0))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+
+		It("keeps advertising them when it periodically re-locks the presence", func() {
+			clock.WaitForWatcherAndIncrement(time.Second)
+
+			Eventually(locketClient.LockCallCount).Should(Equal(3))
+			Expect(lockedPresence(2).RootfsProviders).To(ContainElement(&models.Provider{
+				Name:       models.PreloadedRootFSScheme,
+				Properties: []string{"cflinuxfs3", "cflinuxfs4"},
+			}))
+		})
+	})
+
+	Context("when re-locking the presence fails", func() {
+		BeforeEach(func() {
+			locketClient.LockReturns(nil, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(presence.SetPreloadedRootFSes(logger, []string{"cflinuxfs4"})).To(MatchError("boom"))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package maintain
 import (
 	"errors"
 	"os"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/bbs/models"
@@ -19,6 +20,10 @@ type Maintainer struct {
 	logger         lager.Logger
 	lockTTL        time.Duration
 	clock          clock.Clock
+
+	presenceLock sync.Mutex
+	cellCapacity models.CellCapacity
+	heartbeating bool
 }
 
 type Config struct {
@@ -106,11 +111,42 @@ func (m *Maintainer) createHeartbeater() (ifrit.Runner, error) {
 	if err != nil {
 		return nil, err
 	}
-	cellCapacity := models.NewCellCapacity(int32(resources.MemoryMB), int32(resources.DiskMB), int32(resources.Containers))
-	cellPresence := models.NewCellPresence(m.CellID, m.RepAddress, m.RepUrl, m.Zone, cellCapacity, m.RootFSProviders, m.PreloadedRootFSes, m.PlacementTags, m.OptionalPlacementTags)
+
+	m.presenceLock.Lock()
+	defer m.presenceLock.Unlock()
+
+	m.cellCapacity = models.NewCellCapacity(int32(resources.MemoryMB), int32(resources.DiskMB), int32(resources.Containers))
+	cellPresence := m.cellPresence()
 	return m.serviceClient.NewCellPresenceRunner(m.logger, &cellPresence, m.RetryInterval, m.lockTTL), nil
 }
 
+// SetPreloadedRootFSes replaces the preloaded rootfses the cell advertises.
+// While the maintainer is heartbeating, the registered presence is updated in
+// place; otherwise the next heartbeater advertises them.
+func (m *Maintainer) SetPreloadedRootFSes(logger lager.Logger, rootFSes []string) error {
+	m.presenceLock.Lock()
+	defer m.presenceLock.Unlock()
+
+	m.PreloadedRootFSes = rootFSes
+	if !m.heartbeating {
+		return nil
+	}
+
+	cellPresence := m.cellPresence()
+	return m.serviceClient.SetCellPresence(logger, &cellPresence)
+}
+
+func (m *Maintainer) cellPresence() models.CellPresence {
+	return models.NewCellPresence(m.CellID, m.RepAddress, m.RepUrl, m.Zone, m.cellCapacity, m.RootFSProviders, m.PreloadedRootFSes, m.PlacementTags, m.OptionalPlacementTags)
+}
+
+func (m *Maintainer) setHeartbeating(heartbeating bool) {
+	m.presenceLock.Lock()
+	defer m.presenceLock.Unlock()
+
+	m.heartbeating = heartbeating
+}
+
 func (m *Maintainer) heartbeat(sigChan <-chan os.Signal, ready chan<- struct{}, heartbeater ifrit.Runner) error {
 	m.logger.Info("start-heartbeating")
 	defer m.logger.Info("complete-heartbeating")
@@ -122,6 +158,8 @@ func (m *Maintainer) heartbeat(sigChan <-chan os.Signal, ready chan<- struct{},
 	select {
 	case <-heartbeatProcess.Ready():
 		m.logger.Info("ready")
+		m.setHeartbeating(true)
+		defer m.setHeartbeating(false)
 	case err := <-heartbeatExitChan:
 		if err != nil {
 			m.logger.Error("heartbeat-exited", err)
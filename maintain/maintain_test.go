@@ -29,7 +29,7 @@ var _ = Describe("Maintain Presence", func() {
 		serviceClient   *maintainfakes.FakeCellPresenceClient
 		logger          *lagertest.TestLogger
 
-		maintainer        ifrit.Runner
+		maintainer        *maintain.Maintainer
 		maintainProcess   ifrit.Process
 		heartbeaterErrors chan error
 		observedSignals   chan os.Signal
@@ -120,6 +120,23 @@ var _ = Describe("Maintain Presence", func() {
 		})
 	})
 
+	Context("when the preloaded rootfses change before the cell is heartbeating", func() {
+		It("advertises them once it starts heartbeating", func() {
+			Expect(maintainer.SetPreloadedRootFSes(logger, []string{"cflinuxfs4"})).To(Succeed())
+			Expect(serviceClient.SetCellPresenceCallCount()).To(Equal(0))
+
+			pingErrors <- nil
+			maintainProcess = ginkgomon.Invoke(maintainer)
+
+			Expect(serviceClient.NewCellPresenceRunnerCallCount()).To(Equal(1))
+			_, presence, _, _ := serviceClient.NewCellPresenceRunnerArgsForCall(0)
+			Expect(presence.RootfsProviders).To(ContainElement(&models.Provider{
+				Name:       models.PreloadedRootFSScheme,
+				Properties: []string{"cflinuxfs4"},
+			}))
+		})
+	})
+
 	Context("when pinging the executor succeeds", func() {
 		Context("when the heartbeater is not ready", func() {
 			BeforeEach(func() {
@@ -202,6 +219,39 @@ var _ = Describe("Maintain Presence", func() {
 				Eventually(fakeHeartbeater.RunCallCount).Should(Equal(1))
 			})
 
+			It("updates the registered presence in place when the preloaded rootfses change", func() {
+				Expect(maintainer.SetPreloadedRootFSes(logger, []string{"cflinuxfs3", "cflinuxfs4"})).To(Succeed())
+
+				expectedPresence := models.NewCellPresence(
+					"cell-id",
+					"1.2.3.4",
+					"https://cell-id.service.cf.internal",
+					"az1",
+					models.NewCellCapacity(128, 1024, 6),
+					[]string{"provider-1", "provider-2"},
+					[]string{"cflinuxfs3", "cflinuxfs4"},
+					[]string{"test-tag-1", "test-tag-2"},
+					[]string{"optional-test-tag-1", "optional-test-tag-2"},
+				)
+
+				Expect(serviceClient.SetCellPresenceCallCount()).To(Equal(1))
+				_, presence := serviceClient.SetCellPresenceArgsForCall(0)
+				Expect(*presence).To(Equal(expectedPresence))
+
+				Expect(serviceClient.NewCellPresenceRunnerCallCount()).To(Equal(1))
+				Consistently(observedSignals).ShouldNot(Receive())
+			})
+
+			Context("when updating the registered presence fails", func() {
+				BeforeEach(func() {
+					serviceClient.SetCellPresenceReturns(errors.New("boom"))
+				})
+
+				It("returns the error", func() {
+					Expect(maintainer.SetPreloadedRootFSes(logger, []string{"cflinuxfs4"})).To(MatchError("boom"))
+				})
+			})
+
 			It("continues pings the executor on an interval", func() {
 				for i := 2; i < 6; i++ {
 					pingErrors <- nil
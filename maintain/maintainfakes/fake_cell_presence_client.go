@@ -64,6 +64,18 @@ type FakeCellPresenceClient struct {
 	newCellPresenceRunnerReturnsOnCall map[int]struct {
 		result1 ifrit.Runner
 	}
+	SetCellPresenceStub        func(lager.Logger, *models.CellPresence) error
+	setCellPresenceMutex       sync.RWMutex
+	setCellPresenceArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *models.CellPresence
+	}
+	setCellPresenceReturns struct {
+		result1 error
+	}
+	setCellPresenceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -322,6 +334,68 @@ func (fake *FakeCellPresenceClient) NewCellPresenceRunnerReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeCellPresenceClient) SetCellPresence(arg1 lager.Logger, arg2 *models.CellPresence) error {
+	fake.setCellPresenceMutex.Lock()
+	ret, specificReturn := fake.setCellPresenceReturnsOnCall[len(fake.setCellPresenceArgsForCall)]
+	fake.setCellPresenceArgsForCall = append(fake.setCellPresenceArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *models.CellPresence
+	}{arg1, arg2})
+	fake.recordInvocation("SetCellPresence", []interface{}{arg1, arg2})
+	setCellPresenceStubCopy := fake.SetCellPresenceStub
+	fake.setCellPresenceMutex.Unlock()
+	if setCellPresenceStubCopy != nil {
+		return setCellPresenceStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setCellPresenceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCellPresenceClient) SetCellPresenceCallCount() int {
+	fake.setCellPresenceMutex.RLock()
+	defer fake.setCellPresenceMutex.RUnlock()
+	return len(fake.setCellPresenceArgsForCall)
+}
+
+func (fake *FakeCellPresenceClient) SetCellPresenceCalls(stub func(lager.Logger, *models.CellPresence) error) {
+	fake.setCellPresenceMutex.Lock()
+	defer fake.setCellPresenceMutex.Unlock()
+	fake.SetCellPresenceStub = stub
+}
+
+func (fake *FakeCellPresenceClient) SetCellPresenceArgsForCall(i int) (lager.Logger, *models.CellPresence) {
+	fake.setCellPresenceMutex.RLock()
+	defer fake.setCellPresenceMutex.RUnlock()
+	argsForCall := fake.setCellPresenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCellPresenceClient) SetCellPresenceReturns(result1 error) {
+	fake.setCellPresenceMutex.Lock()
+	defer fake.setCellPresenceMutex.Unlock()
+	fake.SetCellPresenceStub = nil
+	fake.setCellPresenceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCellPresenceClient) SetCellPresenceReturnsOnCall(i int, result1 error) {
+	fake.setCellPresenceMutex.Lock()
+	defer fake.setCellPresenceMutex.Unlock()
+	fake.SetCellPresenceStub = nil
+	if fake.setCellPresenceReturnsOnCall == nil {
+		fake.setCellPresenceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setCellPresenceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCellPresenceClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -333,6 +407,8 @@ func (fake *FakeCellPresenceClient) Invocations() map[string][][]interface{} {
 	defer fake.cellsMutex.RUnlock()
 	fake.newCellPresenceRunnerMutex.RLock()
 	defer fake.newCellPresenceRunnerMutex.RUnlock()
+	fake.setCellPresenceMutex.RLock()
+	defer fake.setCellPresenceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
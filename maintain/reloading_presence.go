@@ -0,0 +1,65 @@
+package maintain
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+//go:generate counterfeiter . CellPresence
+
+// CellPresence is a runner that advertises the cell and can change the
+// preloaded rootfses it advertises while it runs.
+type CellPresence interface {
+	ifrit.Runner
+	SetPreloadedRootFSes(logger lager.Logger, rootFSes []string) error
+}
+
+type reloadingPresence struct {
+	logger            lager.Logger
+	changed           <-chan struct{}
+	presence          CellPresence
+	preloadedRootFSes func() []string
+}
+
+// NewReloadingPresence runs the presence. Whenever changed receives, the
+// presence is updated in place with the current preloaded rootfses, so that
+// the cell stays registered while it re-advertises them.
+func NewReloadingPresence(logger lager.Logger, changed <-chan struct{}, presence CellPresence, preloadedRootFSes func() []string) ifrit.Runner {
+	return &reloadingPresence{
+		logger:            logger.Session("reloading-presence"),
+		changed:           changed,
+		presence:          presence,
+		preloadedRootFSes: preloadedRootFSes,
+	}
+}
+
+func (p *reloadingPresence) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	process := ifrit.Background(p.presence)
+	processReady := process.Ready()
+
+	for {
+		select {
+		case <-processReady:
+			processReady = nil
+			if ready != nil {
+				close(ready)
+			}
+
+		case err := <-process.Wait():
+			return err
+
+		case sig := <-signals:
+			process.Signal(sig)
+			return <-process.Wait()
+
+		case <-p.changed:
+			p.logger.Info("updating-presence")
+			err := p.presence.SetPreloadedRootFSes(p.logger, p.preloadedRootFSes())
+			if err != nil {
+				p.logger.Error("failed-updating-presence", err)
+			}
+		}
+	}
+}
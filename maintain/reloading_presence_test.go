@@ -0,0 +1,103 @@
+package maintain_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep/maintain"
+	"code.cloudfoundry.org/rep/maintain/maintainfakes"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ReloadingPresence", func() {
+	var (
+		logger            *lagertest.TestLogger
+		changed           chan struct{}
+		presence          *maintainfakes.FakeCellPresence
+		presenceErrors    chan error
+		observedSignals   chan os.Signal
+		preloadedRootFSes []string
+		reloading         ifrit.Runner
+		process           ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		changed = make(chan struct{})
+		presenceErrors = make(chan error, 1)
+		observedSignals = make(chan os.Signal, 10)
+		preloadedRootFSes = []string{"cflinuxfs3"}
+
+		presence = &maintainfakes.FakeCellPresence{}
+		presence.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			select {
+			case sig := <-signals:
+				observedSignals <- sig
+				return nil
+			case err := <-presenceErrors:
+				return err
+			}
+		}
+
+		reloading = maintain.NewReloadingPresence(logger, changed, presence, func() []string {
+			return preloadedRootFSes
+		})
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(reloading)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("becomes ready once the presence is ready", func() {
+		Expect(presence.RunCallCount()).To(Equal(1))
+	})
+
+	It("updates the presence in place when the state changes", func() {
+		preloadedRootFSes = []string{"cflinuxfs3", "cflinuxfs4"}
+		changed <- struct{}{}
+
+		Eventually(presence.SetPreloadedRootFSesCallCount).Should(Equal(1))
+		_, rootFSes := presence.SetPreloadedRootFSesArgsForCall(0)
+		Expect(rootFSes).To(ConsistOf("cflinuxfs3", "cflinuxfs4"))
+
+		Consistently(observedSignals).ShouldNot(Receive())
+		Expect(presence.RunCallCount()).To(Equal(1))
+	})
+
+	Context("when updating the presence fails", func() {
+		BeforeEach(func() {
+			presence.SetPreloadedRootFSesReturns(errors.New("boom"))
+		})
+
+		It("keeps running the presence", func() {
+			changed <- struct{}{}
+
+			Eventually(logger.TestSink.Buffer).Should(gbytes.Say("failed-updating-presence"))
+			Consistently(process.Wait()).ShouldNot(Receive())
+		})
+	})
+
+	It("forwards signals to the presence", func() {
+		process.Signal(os.Kill)
+
+		Eventually(observedSignals).Should(Receive(Equal(os.Kill)))
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("exits when the presence exits", func() {
+		presenceErrors <- errors.New("lost the lock")
+
+		Eventually(process.Wait()).Should(Receive(MatchError("lost the lock")))
+	})
+})
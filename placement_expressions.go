@@ -0,0 +1,135 @@
+package rep
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	PlacementOperatorIn           = "in"
+	PlacementOperatorNotIn        = "notin"
+	PlacementOperatorExists       = "exists"
+	PlacementOperatorDoesNotExist = "!exists"
+)
+
+// PlacementRequirement is a single parsed placement expression evaluated
+// against the placement labels of a cell.
+type PlacementRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// ParsePlacementExpression parses "key in (v1, v2)" and "key notin (v1, v2)",
+// which compare the value of the label with a set of values, as well as "key"
+// and "!key", which require the label to exist or to be absent.
+func ParsePlacementExpression(expression string) (PlacementRequirement, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return PlacementRequirement{}, errors.New("empty placement expression")
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) == 1 {
+		if strings.HasPrefix(expression, "!") {
+			key := strings.TrimPrefix(expression, "!")
+			if !validPlacementKey(key) {
+				return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+			}
+			return PlacementRequirement{Key: key, Operator: PlacementOperatorDoesNotExist}, nil
+		}
+		if !validPlacementKey(expression) {
+			return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+		}
+		return PlacementRequirement{Key: expression, Operator: PlacementOperatorExists}, nil
+	}
+
+	if len(fields) < 3 {
+		return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+	}
+
+	key, operator := fields[0], fields[1]
+	if !validPlacementKey(key) || (operator != PlacementOperatorIn && operator != PlacementOperatorNotIn) {
+		return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+	}
+
+	set := strings.TrimSpace(strings.Join(fields[2:], " "))
+	if !strings.HasPrefix(set, "(") || !strings.HasSuffix(set, ")") {
+		return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+	}
+
+	values := []string{}
+	for _, value := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(set, "("), ")"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return PlacementRequirement{}, fmt.Errorf("invalid placement expression: %s", expression)
+		}
+		values = append(values, value)
+	}
+
+	return PlacementRequirement{Key: key, Operator: operator, Values: values}, nil
+}
+
+func validPlacementKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, "!(), =")
+}
+
+func (r PlacementRequirement) Matches(labels map[string]string) bool {
+	value, found := labels[r.Key]
+
+	switch r.Operator {
+	case PlacementOperatorExists:
+		return found
+	case PlacementOperatorDoesNotExist:
+		return !found
+	case PlacementOperatorIn:
+		return found && r.hasValue(value)
+	case PlacementOperatorNotIn:
+		return !found || !r.hasValue(value)
+	}
+	return false
+}
+
+func (r PlacementRequirement) hasValue(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// PlacementMatcher matches cells whose labels satisfy all of its requirements.
+type PlacementMatcher []PlacementRequirement
+
+func NewPlacementMatcher(expressions []string) (PlacementMatcher, error) {
+	matcher := make(PlacementMatcher, 0, len(expressions))
+	for _, expression := range expressions {
+		requirement, err := ParsePlacementExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+		matcher = append(matcher, requirement)
+	}
+	return matcher, nil
+}
+
+func (m PlacementMatcher) Matches(labels map[string]string) bool {
+	for _, requirement := range m {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// placementTagMatcher expresses flat placement tags as requirements: every
+// desired tag must exist among the cell's tags.
+func placementTagMatcher(desiredTags []string) PlacementMatcher {
+	matcher := make(PlacementMatcher, 0, len(desiredTags))
+	for _, tag := range desiredTags {
+		matcher = append(matcher, PlacementRequirement{Key: tag, Operator: PlacementOperatorExists})
+	}
+	return matcher
+}
@@ -0,0 +1,91 @@
+package rep_test
+
+import (
+	"code.cloudfoundry.org/rep"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PlacementExpressions", func() {
+	Describe("ParsePlacementExpression", func() {
+		It("parses set membership expressions", func() {
+			requirement, err := rep.ParsePlacementExpression("tier in (edge, core)")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(rep.PlacementRequirement{
+				Key:      "tier",
+				Operator: rep.PlacementOperatorIn,
+				Values:   []string{"edge", "core"},
+			}))
+
+			requirement, err = rep.ParsePlacementExpression("gpu notin (true)")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(rep.PlacementRequirement{
+				Key:      "gpu",
+				Operator: rep.PlacementOperatorNotIn,
+				Values:   []string{"true"},
+			}))
+		})
+
+		It("parses existence expressions", func() {
+			requirement, err := rep.ParsePlacementExpression("gpu")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(rep.PlacementRequirement{Key: "gpu", Operator: rep.PlacementOperatorExists}))
+
+			requirement, err = rep.ParsePlacementExpression(" !gpu ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirement).To(Equal(rep.PlacementRequirement{Key: "gpu", Operator: rep.PlacementOperatorDoesNotExist}))
+		})
+
+		It("rejects invalid expressions", func() {
+			for _, expression := range []string{
+				"",
+				"!",
+				"tier in",
+				"tier is (edge)",
+				"tier in edge",
+				"tier in (edge,)",
+				"tier=edge",
+			} {
+				_, err := rep.ParsePlacementExpression(expression)
+				Expect(err).To(HaveOccurred(), expression)
+			}
+		})
+	})
+
+	Describe("PlacementMatcher", func() {
+		var labels map[string]string
+
+		BeforeEach(func() {
+			labels = map[string]string{"gpu": "false", "tier": "edge"}
+		})
+
+		It("matches when every requirement is satisfied", func() {
+			matcher, err := rep.NewPlacementMatcher([]string{"tier in (edge, core)", "gpu notin (true)", "gpu", "!zone"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.Matches(labels)).To(BeTrue())
+		})
+
+		It("does not match when any requirement is unsatisfied", func() {
+			for _, expression := range []string{"tier in (core)", "gpu notin (false)", "zone", "!tier"} {
+				matcher, err := rep.NewPlacementMatcher([]string{expression})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matcher.Matches(labels)).To(BeFalse(), expression)
+			}
+		})
+
+		It("treats a missing label as not in any set", func() {
+			matcher, err := rep.NewPlacementMatcher([]string{"zone notin (z1)"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.Matches(labels)).To(BeTrue())
+
+			matcher, err = rep.NewPlacementMatcher([]string{"zone in (z1)"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.Matches(labels)).To(BeFalse())
+		})
+
+		It("returns an error when an expression is invalid", func() {
+			_, err := rep.NewPlacementMatcher([]string{"gpu", "tier in"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
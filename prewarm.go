@@ -0,0 +1,19 @@
+package rep
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/bbs/models"
+)
+
+// ErrPrewarmRejected is returned by Client.Prewarm when the cell refuses to
+// warm the requested images, for instance because it is evacuating.
+var ErrPrewarmRejected = errors.New("cell rejected the prewarm request")
+
+// PrewarmRequest asks a cell to pull rootfs images and download cached
+// dependencies ahead of the work that will use them. The cached dependencies
+// are downloaded alongside the first rootfs.
+type PrewarmRequest struct {
+	RootFSes           []string                   `json:"rootfses"`
+	CachedDependencies []*models.CachedDependency `json:"cached_dependencies,omitempty"`
+}
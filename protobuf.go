@@ -0,0 +1,417 @@
+package rep
+
+import (
+	"encoding/json"
+	"mime"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/rep/protobuf"
+)
+
+const (
+	JSONContentType     = "application/json"
+	ProtobufContentType = "application/x-protobuf"
+
+	// acceptProtobuf is the Accept header sent by the Client. Cells that do
+	// not support protobuf ignore it and reply with JSON.
+	acceptProtobuf = ProtobufContentType + ", " + JSONContentType + ";q=0.9"
+)
+
+// IsProtobufContentType returns true if the Content-Type header names the
+// protobuf encoding. Any other content type, including none, is JSON.
+func IsProtobufContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ProtobufContentType
+}
+
+type protobufMessage interface {
+	MarshalProtobuf() ([]byte, error)
+	UnmarshalProtobuf(data []byte) error
+}
+
+// unmarshalBody decodes a request or response body in the given content type.
+func unmarshalBody(contentType string, data []byte, v protobufMessage) error {
+	if IsProtobufContentType(contentType) {
+		return v.UnmarshalProtobuf(data)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// The protobuf encodings of CellState and Work are the messages generated
+// from protobuf/rep.proto. The rootfs providers, whose types are registered at
+// runtime, are embedded in their JSON encoding.
+
+// MarshalProtobuf returns the protobuf encoding of the state.
+func (c *CellState) MarshalProtobuf() ([]byte, error) {
+	message, err := c.toProtobuf()
+	if err != nil {
+		return nil, err
+	}
+	return message.Marshal()
+}
+
+// UnmarshalProtobuf replaces the state with the one decoded from data.
+func (c *CellState) UnmarshalProtobuf(data []byte) error {
+	var message protobuf.CellState
+	err := message.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	state := CellState{
+		RepURL:                  message.RepUrl,
+		CellID:                  message.CellId,
+		CellIndex:               int(message.CellIndex),
+		AvailableResources:      resourcesFromProtobuf(message.AvailableResources),
+		TotalResources:          resourcesFromProtobuf(message.TotalResources),
+		LRPs:                    lrpsFromProtobuf(message.Lrps),
+		Tasks:                   tasksFromProtobuf(message.Tasks),
+		StartingContainerCount:  int(message.StartingContainerCount),
+		Zone:                    message.Zone,
+		Evacuating:              message.Evacuating,
+		VolumeDrivers:           message.VolumeDrivers,
+		PlacementTags:           message.PlacementTags,
+		OptionalPlacementTags:   message.OptionalPlacementTags,
+		ProxyMemoryAllocationMB: int(message.ProxyMemoryAllocationMb),
+		Scoring:                 scoringFromProtobuf(message.Scoring),
+		PlacementLabels:         message.PlacementLabels,
+		WarmRootFSes:            message.WarmRootfses,
+		WarmCachedDependencies:  message.WarmCachedDependencies,
+		CachedRootFSes:          message.CachedRootfses,
+		Generation:              message.Generation,
+	}
+	if len(message.RootFsProviders) > 0 {
+		err = json.Unmarshal(message.RootFsProviders, &state.RootFSProviders)
+		if err != nil {
+			return err
+		}
+	}
+	if len(message.DomainQuotas) > 0 {
+		state.DomainQuotas = make(DomainQuotas, len(message.DomainQuotas))
+		for domain, quota := range message.DomainQuotas {
+			state.DomainQuotas[domain] = DomainQuota{MemoryMB: quota.MemoryMb, DiskMB: quota.DiskMb, Containers: int(quota.Containers)}
+		}
+	}
+	if len(message.DomainUsage) > 0 {
+		state.DomainUsage = make(map[string]DomainUsage, len(message.DomainUsage))
+		for domain, usage := range message.DomainUsage {
+			state.DomainUsage[domain] = DomainUsage{MemoryMB: usage.MemoryMb, DiskMB: usage.DiskMb, Containers: int(usage.Containers)}
+		}
+	}
+	if len(message.StackHealth) > 0 {
+		state.StackHealth = make(map[string]StackHealth, len(message.StackHealth))
+		for stack, health := range message.StackHealth {
+			state.StackHealth[stack] = StackHealth{Path: health.Path, Healthy: health.Healthy, Error: health.Error}
+		}
+	}
+
+	*c = state
+	return nil
+}
+
+func (c *CellState) toProtobuf() (*protobuf.CellState, error) {
+	message := &protobuf.CellState{
+		RepUrl:                  c.RepURL,
+		CellId:                  c.CellID,
+		CellIndex:               int64(c.CellIndex),
+		AvailableResources:      c.AvailableResources.toProtobuf(),
+		TotalResources:          c.TotalResources.toProtobuf(),
+		Lrps:                    lrpsToProtobuf(c.LRPs),
+		Tasks:                   tasksToProtobuf(c.Tasks),
+		StartingContainerCount:  int64(c.StartingContainerCount),
+		Zone:                    c.Zone,
+		Evacuating:              c.Evacuating,
+		VolumeDrivers:           c.VolumeDrivers,
+		PlacementTags:           c.PlacementTags,
+		OptionalPlacementTags:   c.OptionalPlacementTags,
+		ProxyMemoryAllocationMb: int64(c.ProxyMemoryAllocationMB),
+		Scoring:                 c.Scoring.toProtobuf(),
+		PlacementLabels:         c.PlacementLabels,
+		WarmRootfses:            c.WarmRootFSes,
+		WarmCachedDependencies:  c.WarmCachedDependencies,
+		CachedRootfses:          c.CachedRootFSes,
+		Generation:              c.Generation,
+	}
+	if c.RootFSProviders != nil {
+		providers, err := json.Marshal(c.RootFSProviders)
+		if err != nil {
+			return nil, err
+		}
+		message.RootFsProviders = providers
+	}
+	if len(c.DomainQuotas) > 0 {
+		message.DomainQuotas = make(map[string]protobuf.DomainResources, len(c.DomainQuotas))
+		for domain, quota := range c.DomainQuotas {
+			message.DomainQuotas[domain] = protobuf.DomainResources{MemoryMb: quota.MemoryMB, DiskMb: quota.DiskMB, Containers: int64(quota.Containers)}
+		}
+	}
+	if len(c.DomainUsage) > 0 {
+		message.DomainUsage = make(map[string]protobuf.DomainResources, len(c.DomainUsage))
+		for domain, usage := range c.DomainUsage {
+			message.DomainUsage[domain] = protobuf.DomainResources{MemoryMb: usage.MemoryMB, DiskMb: usage.DiskMB, Containers: int64(usage.Containers)}
+		}
+	}
+	if len(c.StackHealth) > 0 {
+		message.StackHealth = make(map[string]protobuf.StackHealth, len(c.StackHealth))
+		for stack, health := range c.StackHealth {
+			message.StackHealth[stack] = protobuf.StackHealth{Path: health.Path, Healthy: health.Healthy, Error: health.Error}
+		}
+	}
+	return message, nil
+}
+
+// MarshalProtobuf returns the protobuf encoding of the work.
+func (w *Work) MarshalProtobuf() ([]byte, error) {
+	message := &protobuf.Work{
+		Lrps:          lrpsToProtobuf(w.LRPs),
+		Tasks:         tasksToProtobuf(w.Tasks),
+		CellId:        w.CellID,
+		ReservationId: w.ReservationID,
+	}
+	if len(w.Evictions) > 0 {
+		message.Evictions = make([]protobuf.Eviction, len(w.Evictions))
+		for i := range w.Evictions {
+			message.Evictions[i] = w.Evictions[i].toProtobuf()
+		}
+	}
+	return message.Marshal()
+}
+
+// UnmarshalProtobuf replaces the work with the one decoded from data.
+func (w *Work) UnmarshalProtobuf(data []byte) error {
+	var message protobuf.Work
+	err := message.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	work := Work{
+		LRPs:          lrpsFromProtobuf(message.Lrps),
+		Tasks:         tasksFromProtobuf(message.Tasks),
+		CellID:        message.CellId,
+		ReservationID: message.ReservationId,
+	}
+	if len(message.Evictions) > 0 {
+		work.Evictions = make([]Eviction, len(message.Evictions))
+		for i := range message.Evictions {
+			work.Evictions[i] = evictionFromProtobuf(message.Evictions[i])
+		}
+	}
+
+	*w = work
+	return nil
+}
+
+func (res *Resources) toProtobuf() protobuf.Resources {
+	return protobuf.Resources{
+		MemoryMb:   res.MemoryMB,
+		DiskMb:     res.DiskMB,
+		Containers: int64(res.Containers),
+		CpuWeight:  res.CPUWeight,
+		MaxPids:    res.MaxPids,
+	}
+}
+
+func resourcesFromProtobuf(message protobuf.Resources) Resources {
+	return Resources{
+		MemoryMB:   message.MemoryMb,
+		DiskMB:     message.DiskMb,
+		Containers: int(message.Containers),
+		CPUWeight:  message.CpuWeight,
+		MaxPids:    message.MaxPids,
+	}
+}
+
+func (res *Resource) toProtobuf() protobuf.Resource {
+	return protobuf.Resource{
+		MemoryMb:  res.MemoryMB,
+		DiskMb:    res.DiskMB,
+		MaxPids:   res.MaxPids,
+		CpuWeight: res.CPUWeight,
+	}
+}
+
+func resourceFromProtobuf(message protobuf.Resource) Resource {
+	return Resource{
+		MemoryMB:  message.MemoryMb,
+		DiskMB:    message.DiskMb,
+		MaxPids:   message.MaxPids,
+		CPUWeight: message.CpuWeight,
+	}
+}
+
+func (pc *PlacementConstraint) toProtobuf() protobuf.PlacementConstraint {
+	return protobuf.PlacementConstraint{
+		PlacementTags:        pc.PlacementTags,
+		VolumeDrivers:        pc.VolumeDrivers,
+		RootFs:               pc.RootFs,
+		PlacementExpressions: pc.PlacementExpressions,
+		MaxInstancesPerCell:  pc.MaxInstancesPerCell,
+	}
+}
+
+func placementConstraintFromProtobuf(message protobuf.PlacementConstraint) PlacementConstraint {
+	return PlacementConstraint{
+		PlacementTags:        message.PlacementTags,
+		VolumeDrivers:        message.VolumeDrivers,
+		RootFs:               message.RootFs,
+		PlacementExpressions: message.PlacementExpressions,
+		MaxInstancesPerCell:  message.MaxInstancesPerCell,
+	}
+}
+
+func (pf *PlacementFailure) toProtobuf() *protobuf.PlacementFailure {
+	if pf == nil {
+		return nil
+	}
+	return &protobuf.PlacementFailure{Reason: string(pf.Reason), Message: pf.Message}
+}
+
+func placementFailureFromProtobuf(message *protobuf.PlacementFailure) *PlacementFailure {
+	if message == nil {
+		return nil
+	}
+	return &PlacementFailure{Reason: PlacementFailureReason(message.Reason), Message: message.Message}
+}
+
+func lrpsToProtobuf(lrps []LRP) []protobuf.LRP {
+	if len(lrps) == 0 {
+		return nil
+	}
+
+	messages := make([]protobuf.LRP, len(lrps))
+	for i := range lrps {
+		lrp := &lrps[i]
+		messages[i] = protobuf.LRP{
+			InstanceGuid:        lrp.InstanceGUID,
+			ActualLrpKey:        lrp.ActualLRPKey,
+			PlacementConstraint: lrp.PlacementConstraint.toProtobuf(),
+			Resource:            lrp.Resource.toProtobuf(),
+			State:               lrp.State,
+			Priority:            lrp.Priority,
+			PlacementFailure:    lrp.PlacementFailure.toProtobuf(),
+		}
+	}
+	return messages
+}
+
+func lrpsFromProtobuf(messages []protobuf.LRP) []LRP {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	lrps := make([]LRP, len(messages))
+	for i := range messages {
+		message := &messages[i]
+		lrps[i] = LRP{
+			InstanceGUID:        message.InstanceGuid,
+			ActualLRPKey:        message.ActualLrpKey,
+			PlacementConstraint: placementConstraintFromProtobuf(message.PlacementConstraint),
+			Resource:            resourceFromProtobuf(message.Resource),
+			State:               message.State,
+			Priority:            message.Priority,
+			PlacementFailure:    placementFailureFromProtobuf(message.PlacementFailure),
+		}
+	}
+	return lrps
+}
+
+func tasksToProtobuf(tasks []Task) []protobuf.Task {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	messages := make([]protobuf.Task, len(tasks))
+	for i := range tasks {
+		task := &tasks[i]
+		messages[i] = protobuf.Task{
+			TaskGuid:            task.TaskGuid,
+			Domain:              task.Domain,
+			PlacementConstraint: task.PlacementConstraint.toProtobuf(),
+			Resource:            task.Resource.toProtobuf(),
+			State:               int32(task.State),
+			Failed:              task.Failed,
+			Priority:            task.Priority,
+			PlacementFailure:    task.PlacementFailure.toProtobuf(),
+		}
+	}
+	return messages
+}
+
+func tasksFromProtobuf(messages []protobuf.Task) []Task {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	tasks := make([]Task, len(messages))
+	for i := range messages {
+		message := &messages[i]
+		tasks[i] = Task{
+			TaskGuid:            message.TaskGuid,
+			Domain:              message.Domain,
+			PlacementConstraint: placementConstraintFromProtobuf(message.PlacementConstraint),
+			Resource:            resourceFromProtobuf(message.Resource),
+			State:               models.Task_State(message.State),
+			Failed:              message.Failed,
+			Priority:            message.Priority,
+			PlacementFailure:    placementFailureFromProtobuf(message.PlacementFailure),
+		}
+	}
+	return tasks
+}
+
+func (e *Eviction) toProtobuf() protobuf.Eviction {
+	return protobuf.Eviction{
+		ContainerGuid: e.ContainerGuid,
+		ProcessGuid:   e.ProcessGuid,
+		InstanceGuid:  e.InstanceGuid,
+		Index:         e.Index,
+		TaskGuid:      e.TaskGuid,
+		Domain:        e.Domain,
+		Priority:      e.Priority,
+		MemoryMb:      e.MemoryMB,
+		PreemptedBy:   e.PreemptedBy,
+	}
+}
+
+func evictionFromProtobuf(message protobuf.Eviction) Eviction {
+	return Eviction{
+		ContainerGuid: message.ContainerGuid,
+		ProcessGuid:   message.ProcessGuid,
+		InstanceGuid:  message.InstanceGuid,
+		Index:         message.Index,
+		TaskGuid:      message.TaskGuid,
+		Domain:        message.Domain,
+		Priority:      message.Priority,
+		MemoryMB:      message.MemoryMb,
+		PreemptedBy:   message.PreemptedBy,
+	}
+}
+
+func (s *ScoringConfig) toProtobuf() protobuf.ScoringConfig {
+	message := protobuf.ScoringConfig{Strategy: s.Strategy}
+	if s.Weights != nil {
+		message.Weights = &protobuf.ScoringWeights{
+			MemoryMb:   s.Weights.MemoryMB,
+			DiskMb:     s.Weights.DiskMB,
+			Containers: s.Weights.Containers,
+			CpuWeight:  s.Weights.CPUWeight,
+			MaxPids:    s.Weights.MaxPids,
+		}
+	}
+	return message
+}
+
+func scoringFromProtobuf(message protobuf.ScoringConfig) ScoringConfig {
+	scoring := ScoringConfig{Strategy: message.Strategy}
+	if message.Weights != nil {
+		scoring.Weights = &ScoringWeights{
+			MemoryMB:   message.Weights.MemoryMb,
+			DiskMB:     message.Weights.DiskMb,
+			Containers: message.Weights.Containers,
+			CPUWeight:  message.Weights.CpuWeight,
+			MaxPids:    message.Weights.MaxPids,
+		}
+	}
+	return scoring
+}
@@ -0,0 +1,3 @@
+package protobuf // import "code.cloudfoundry.org/rep/protobuf"
+
+//go:generate protoc --proto_path=$GOPATH/src:$GOPATH/src/github.com/gogo/protobuf/protobuf/:$GOPATH/src/code.cloudfoundry.org/bbs/models/:. --gogoslick_out=Mactual_lrp.proto=code.cloudfoundry.org/bbs/models:. rep.proto
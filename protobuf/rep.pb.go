@@ -0,0 +1,6613 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: rep.proto
+
+package protobuf
+
+import (
+	models "code.cloudfoundry.org/bbs/models"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type CellState struct {
+	RepUrl    string `protobuf:"bytes,1,opt,name=rep_url,json=repUrl,proto3" json:"rep_url,omitempty"`
+	CellId    string `protobuf:"bytes,2,opt,name=cell_id,json=cellId,proto3" json:"cell_id,omitempty"`
+	CellIndex int64  `protobuf:"varint,3,opt,name=cell_index,json=cellIndex,proto3" json:"cell_index,omitempty"`
+	// The JSON encoding of the RootFSProviders, whose types are not known to
+	// protobuf.
+	RootFsProviders         []byte                     `protobuf:"bytes,4,opt,name=root_fs_providers,json=rootFsProviders,proto3" json:"root_fs_providers,omitempty"`
+	AvailableResources      Resources                  `protobuf:"bytes,5,opt,name=available_resources,json=availableResources,proto3" json:"available_resources"`
+	TotalResources          Resources                  `protobuf:"bytes,6,opt,name=total_resources,json=totalResources,proto3" json:"total_resources"`
+	Lrps                    []LRP                      `protobuf:"bytes,7,rep,name=lrps,proto3" json:"lrps"`
+	Tasks                   []Task                     `protobuf:"bytes,8,rep,name=tasks,proto3" json:"tasks"`
+	StartingContainerCount  int64                      `protobuf:"varint,9,opt,name=starting_container_count,json=startingContainerCount,proto3" json:"starting_container_count,omitempty"`
+	Zone                    string                     `protobuf:"bytes,10,opt,name=zone,proto3" json:"zone,omitempty"`
+	Evacuating              bool                       `protobuf:"varint,11,opt,name=evacuating,proto3" json:"evacuating,omitempty"`
+	VolumeDrivers           []string                   `protobuf:"bytes,12,rep,name=volume_drivers,json=volumeDrivers,proto3" json:"volume_drivers,omitempty"`
+	PlacementTags           []string                   `protobuf:"bytes,13,rep,name=placement_tags,json=placementTags,proto3" json:"placement_tags,omitempty"`
+	OptionalPlacementTags   []string                   `protobuf:"bytes,14,rep,name=optional_placement_tags,json=optionalPlacementTags,proto3" json:"optional_placement_tags,omitempty"`
+	ProxyMemoryAllocationMb int64                      `protobuf:"varint,15,opt,name=proxy_memory_allocation_mb,json=proxyMemoryAllocationMb,proto3" json:"proxy_memory_allocation_mb,omitempty"`
+	Scoring                 ScoringConfig              `protobuf:"bytes,16,opt,name=scoring,proto3" json:"scoring"`
+	PlacementLabels         map[string]string          `protobuf:"bytes,17,rep,name=placement_labels,json=placementLabels,proto3" json:"placement_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DomainQuotas            map[string]DomainResources `protobuf:"bytes,18,rep,name=domain_quotas,json=domainQuotas,proto3" json:"domain_quotas" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DomainUsage             map[string]DomainResources `protobuf:"bytes,19,rep,name=domain_usage,json=domainUsage,proto3" json:"domain_usage" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StackHealth             map[string]StackHealth     `protobuf:"bytes,20,rep,name=stack_health,json=stackHealth,proto3" json:"stack_health" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	WarmRootfses            []string                   `protobuf:"bytes,21,rep,name=warm_rootfses,json=warmRootfses,proto3" json:"warm_rootfses,omitempty"`
+	WarmCachedDependencies  []string                   `protobuf:"bytes,22,rep,name=warm_cached_dependencies,json=warmCachedDependencies,proto3" json:"warm_cached_dependencies,omitempty"`
+	CachedRootfses          []string                   `protobuf:"bytes,23,rep,name=cached_rootfses,json=cachedRootfses,proto3" json:"cached_rootfses,omitempty"`
+	Generation              uint64                     `protobuf:"varint,24,opt,name=generation,proto3" json:"generation,omitempty"`
+}
+
+func (m *CellState) Reset()      { *m = CellState{} }
+func (*CellState) ProtoMessage() {}
+func (*CellState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{0}
+}
+func (m *CellState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CellState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CellState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CellState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CellState.Merge(m, src)
+}
+func (m *CellState) XXX_Size() int {
+	return m.Size()
+}
+func (m *CellState) XXX_DiscardUnknown() {
+	xxx_messageInfo_CellState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CellState proto.InternalMessageInfo
+
+func (m *CellState) GetRepUrl() string {
+	if m != nil {
+		return m.RepUrl
+	}
+	return ""
+}
+
+func (m *CellState) GetCellId() string {
+	if m != nil {
+		return m.CellId
+	}
+	return ""
+}
+
+func (m *CellState) GetCellIndex() int64 {
+	if m != nil {
+		return m.CellIndex
+	}
+	return 0
+}
+
+func (m *CellState) GetRootFsProviders() []byte {
+	if m != nil {
+		return m.RootFsProviders
+	}
+	return nil
+}
+
+func (m *CellState) GetAvailableResources() Resources {
+	if m != nil {
+		return m.AvailableResources
+	}
+	return Resources{}
+}
+
+func (m *CellState) GetTotalResources() Resources {
+	if m != nil {
+		return m.TotalResources
+	}
+	return Resources{}
+}
+
+func (m *CellState) GetLrps() []LRP {
+	if m != nil {
+		return m.Lrps
+	}
+	return nil
+}
+
+func (m *CellState) GetTasks() []Task {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *CellState) GetStartingContainerCount() int64 {
+	if m != nil {
+		return m.StartingContainerCount
+	}
+	return 0
+}
+
+func (m *CellState) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *CellState) GetEvacuating() bool {
+	if m != nil {
+		return m.Evacuating
+	}
+	return false
+}
+
+func (m *CellState) GetVolumeDrivers() []string {
+	if m != nil {
+		return m.VolumeDrivers
+	}
+	return nil
+}
+
+func (m *CellState) GetPlacementTags() []string {
+	if m != nil {
+		return m.PlacementTags
+	}
+	return nil
+}
+
+func (m *CellState) GetOptionalPlacementTags() []string {
+	if m != nil {
+		return m.OptionalPlacementTags
+	}
+	return nil
+}
+
+func (m *CellState) GetProxyMemoryAllocationMb() int64 {
+	if m != nil {
+		return m.ProxyMemoryAllocationMb
+	}
+	return 0
+}
+
+func (m *CellState) GetScoring() ScoringConfig {
+	if m != nil {
+		return m.Scoring
+	}
+	return ScoringConfig{}
+}
+
+func (m *CellState) GetPlacementLabels() map[string]string {
+	if m != nil {
+		return m.PlacementLabels
+	}
+	return nil
+}
+
+func (m *CellState) GetDomainQuotas() map[string]DomainResources {
+	if m != nil {
+		return m.DomainQuotas
+	}
+	return nil
+}
+
+func (m *CellState) GetDomainUsage() map[string]DomainResources {
+	if m != nil {
+		return m.DomainUsage
+	}
+	return nil
+}
+
+func (m *CellState) GetStackHealth() map[string]StackHealth {
+	if m != nil {
+		return m.StackHealth
+	}
+	return nil
+}
+
+func (m *CellState) GetWarmRootfses() []string {
+	if m != nil {
+		return m.WarmRootfses
+	}
+	return nil
+}
+
+func (m *CellState) GetWarmCachedDependencies() []string {
+	if m != nil {
+		return m.WarmCachedDependencies
+	}
+	return nil
+}
+
+func (m *CellState) GetCachedRootfses() []string {
+	if m != nil {
+		return m.CachedRootfses
+	}
+	return nil
+}
+
+func (m *CellState) GetGeneration() uint64 {
+	if m != nil {
+		return m.Generation
+	}
+	return 0
+}
+
+type Work struct {
+	Lrps          []LRP      `protobuf:"bytes,1,rep,name=lrps,proto3" json:"lrps"`
+	Tasks         []Task     `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks"`
+	CellId        string     `protobuf:"bytes,3,opt,name=cell_id,json=cellId,proto3" json:"cell_id,omitempty"`
+	ReservationId string     `protobuf:"bytes,4,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Evictions     []Eviction `protobuf:"bytes,5,rep,name=evictions,proto3" json:"evictions"`
+}
+
+func (m *Work) Reset()      { *m = Work{} }
+func (*Work) ProtoMessage() {}
+func (*Work) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{1}
+}
+func (m *Work) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Work) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Work.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Work) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Work.Merge(m, src)
+}
+func (m *Work) XXX_Size() int {
+	return m.Size()
+}
+func (m *Work) XXX_DiscardUnknown() {
+	xxx_messageInfo_Work.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Work proto.InternalMessageInfo
+
+func (m *Work) GetLrps() []LRP {
+	if m != nil {
+		return m.Lrps
+	}
+	return nil
+}
+
+func (m *Work) GetTasks() []Task {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *Work) GetCellId() string {
+	if m != nil {
+		return m.CellId
+	}
+	return ""
+}
+
+func (m *Work) GetReservationId() string {
+	if m != nil {
+		return m.ReservationId
+	}
+	return ""
+}
+
+func (m *Work) GetEvictions() []Eviction {
+	if m != nil {
+		return m.Evictions
+	}
+	return nil
+}
+
+type Resources struct {
+	MemoryMb   int32 `protobuf:"varint,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	DiskMb     int32 `protobuf:"varint,2,opt,name=disk_mb,json=diskMb,proto3" json:"disk_mb,omitempty"`
+	Containers int64 `protobuf:"varint,3,opt,name=containers,proto3" json:"containers,omitempty"`
+	CpuWeight  int32 `protobuf:"varint,4,opt,name=cpu_weight,json=cpuWeight,proto3" json:"cpu_weight,omitempty"`
+	MaxPids    int32 `protobuf:"varint,5,opt,name=max_pids,json=maxPids,proto3" json:"max_pids,omitempty"`
+}
+
+func (m *Resources) Reset()      { *m = Resources{} }
+func (*Resources) ProtoMessage() {}
+func (*Resources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{2}
+}
+func (m *Resources) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Resources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resources.Merge(m, src)
+}
+func (m *Resources) XXX_Size() int {
+	return m.Size()
+}
+func (m *Resources) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resources proto.InternalMessageInfo
+
+func (m *Resources) GetMemoryMb() int32 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *Resources) GetDiskMb() int32 {
+	if m != nil {
+		return m.DiskMb
+	}
+	return 0
+}
+
+func (m *Resources) GetContainers() int64 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+func (m *Resources) GetCpuWeight() int32 {
+	if m != nil {
+		return m.CpuWeight
+	}
+	return 0
+}
+
+func (m *Resources) GetMaxPids() int32 {
+	if m != nil {
+		return m.MaxPids
+	}
+	return 0
+}
+
+type Resource struct {
+	MemoryMb  int32 `protobuf:"varint,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	DiskMb    int32 `protobuf:"varint,2,opt,name=disk_mb,json=diskMb,proto3" json:"disk_mb,omitempty"`
+	MaxPids   int32 `protobuf:"varint,3,opt,name=max_pids,json=maxPids,proto3" json:"max_pids,omitempty"`
+	CpuWeight int32 `protobuf:"varint,4,opt,name=cpu_weight,json=cpuWeight,proto3" json:"cpu_weight,omitempty"`
+}
+
+func (m *Resource) Reset()      { *m = Resource{} }
+func (*Resource) ProtoMessage() {}
+func (*Resource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{3}
+}
+func (m *Resource) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Resource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Resource.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Resource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resource.Merge(m, src)
+}
+func (m *Resource) XXX_Size() int {
+	return m.Size()
+}
+func (m *Resource) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resource proto.InternalMessageInfo
+
+func (m *Resource) GetMemoryMb() int32 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *Resource) GetDiskMb() int32 {
+	if m != nil {
+		return m.DiskMb
+	}
+	return 0
+}
+
+func (m *Resource) GetMaxPids() int32 {
+	if m != nil {
+		return m.MaxPids
+	}
+	return 0
+}
+
+func (m *Resource) GetCpuWeight() int32 {
+	if m != nil {
+		return m.CpuWeight
+	}
+	return 0
+}
+
+type PlacementConstraint struct {
+	PlacementTags        []string `protobuf:"bytes,1,rep,name=placement_tags,json=placementTags,proto3" json:"placement_tags,omitempty"`
+	VolumeDrivers        []string `protobuf:"bytes,2,rep,name=volume_drivers,json=volumeDrivers,proto3" json:"volume_drivers,omitempty"`
+	RootFs               string   `protobuf:"bytes,3,opt,name=root_fs,json=rootFs,proto3" json:"root_fs,omitempty"`
+	PlacementExpressions []string `protobuf:"bytes,4,rep,name=placement_expressions,json=placementExpressions,proto3" json:"placement_expressions,omitempty"`
+	MaxInstancesPerCell  int32    `protobuf:"varint,5,opt,name=max_instances_per_cell,json=maxInstancesPerCell,proto3" json:"max_instances_per_cell,omitempty"`
+}
+
+func (m *PlacementConstraint) Reset()      { *m = PlacementConstraint{} }
+func (*PlacementConstraint) ProtoMessage() {}
+func (*PlacementConstraint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{4}
+}
+func (m *PlacementConstraint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlacementConstraint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlacementConstraint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlacementConstraint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlacementConstraint.Merge(m, src)
+}
+func (m *PlacementConstraint) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlacementConstraint) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlacementConstraint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlacementConstraint proto.InternalMessageInfo
+
+func (m *PlacementConstraint) GetPlacementTags() []string {
+	if m != nil {
+		return m.PlacementTags
+	}
+	return nil
+}
+
+func (m *PlacementConstraint) GetVolumeDrivers() []string {
+	if m != nil {
+		return m.VolumeDrivers
+	}
+	return nil
+}
+
+func (m *PlacementConstraint) GetRootFs() string {
+	if m != nil {
+		return m.RootFs
+	}
+	return ""
+}
+
+func (m *PlacementConstraint) GetPlacementExpressions() []string {
+	if m != nil {
+		return m.PlacementExpressions
+	}
+	return nil
+}
+
+func (m *PlacementConstraint) GetMaxInstancesPerCell() int32 {
+	if m != nil {
+		return m.MaxInstancesPerCell
+	}
+	return 0
+}
+
+type PlacementFailure struct {
+	Reason  string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *PlacementFailure) Reset()      { *m = PlacementFailure{} }
+func (*PlacementFailure) ProtoMessage() {}
+func (*PlacementFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{5}
+}
+func (m *PlacementFailure) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlacementFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlacementFailure.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlacementFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlacementFailure.Merge(m, src)
+}
+func (m *PlacementFailure) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlacementFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlacementFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlacementFailure proto.InternalMessageInfo
+
+func (m *PlacementFailure) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PlacementFailure) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type LRP struct {
+	InstanceGuid        string              `protobuf:"bytes,1,opt,name=instance_guid,json=instanceGuid,proto3" json:"instance_guid,omitempty"`
+	ActualLrpKey        models.ActualLRPKey `protobuf:"bytes,2,opt,name=actual_lrp_key,json=actualLrpKey,proto3" json:"actual_lrp_key"`
+	PlacementConstraint PlacementConstraint `protobuf:"bytes,3,opt,name=placement_constraint,json=placementConstraint,proto3" json:"placement_constraint"`
+	Resource            Resource            `protobuf:"bytes,4,opt,name=resource,proto3" json:"resource"`
+	State               string              `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Priority            int32               `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	PlacementFailure    *PlacementFailure   `protobuf:"bytes,7,opt,name=placement_failure,json=placementFailure,proto3" json:"placement_failure,omitempty"`
+}
+
+func (m *LRP) Reset()      { *m = LRP{} }
+func (*LRP) ProtoMessage() {}
+func (*LRP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{6}
+}
+func (m *LRP) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LRP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LRP.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LRP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LRP.Merge(m, src)
+}
+func (m *LRP) XXX_Size() int {
+	return m.Size()
+}
+func (m *LRP) XXX_DiscardUnknown() {
+	xxx_messageInfo_LRP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LRP proto.InternalMessageInfo
+
+func (m *LRP) GetInstanceGuid() string {
+	if m != nil {
+		return m.InstanceGuid
+	}
+	return ""
+}
+
+func (m *LRP) GetActualLrpKey() models.ActualLRPKey {
+	if m != nil {
+		return m.ActualLrpKey
+	}
+	return models.ActualLRPKey{}
+}
+
+func (m *LRP) GetPlacementConstraint() PlacementConstraint {
+	if m != nil {
+		return m.PlacementConstraint
+	}
+	return PlacementConstraint{}
+}
+
+func (m *LRP) GetResource() Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return Resource{}
+}
+
+func (m *LRP) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *LRP) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *LRP) GetPlacementFailure() *PlacementFailure {
+	if m != nil {
+		return m.PlacementFailure
+	}
+	return nil
+}
+
+type Task struct {
+	TaskGuid            string              `protobuf:"bytes,1,opt,name=task_guid,json=taskGuid,proto3" json:"task_guid,omitempty"`
+	Domain              string              `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	PlacementConstraint PlacementConstraint `protobuf:"bytes,3,opt,name=placement_constraint,json=placementConstraint,proto3" json:"placement_constraint"`
+	Resource            Resource            `protobuf:"bytes,4,opt,name=resource,proto3" json:"resource"`
+	State               int32               `protobuf:"varint,5,opt,name=state,proto3" json:"state,omitempty"`
+	Failed              bool                `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Priority            int32               `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	PlacementFailure    *PlacementFailure   `protobuf:"bytes,8,opt,name=placement_failure,json=placementFailure,proto3" json:"placement_failure,omitempty"`
+}
+
+func (m *Task) Reset()      { *m = Task{} }
+func (*Task) ProtoMessage() {}
+func (*Task) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{7}
+}
+func (m *Task) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Task) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Task.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Task) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Task.Merge(m, src)
+}
+func (m *Task) XXX_Size() int {
+	return m.Size()
+}
+func (m *Task) XXX_DiscardUnknown() {
+	xxx_messageInfo_Task.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Task proto.InternalMessageInfo
+
+func (m *Task) GetTaskGuid() string {
+	if m != nil {
+		return m.TaskGuid
+	}
+	return ""
+}
+
+func (m *Task) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *Task) GetPlacementConstraint() PlacementConstraint {
+	if m != nil {
+		return m.PlacementConstraint
+	}
+	return PlacementConstraint{}
+}
+
+func (m *Task) GetResource() Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return Resource{}
+}
+
+func (m *Task) GetState() int32 {
+	if m != nil {
+		return m.State
+	}
+	return 0
+}
+
+func (m *Task) GetFailed() bool {
+	if m != nil {
+		return m.Failed
+	}
+	return false
+}
+
+func (m *Task) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Task) GetPlacementFailure() *PlacementFailure {
+	if m != nil {
+		return m.PlacementFailure
+	}
+	return nil
+}
+
+type Eviction struct {
+	ContainerGuid string `protobuf:"bytes,1,opt,name=container_guid,json=containerGuid,proto3" json:"container_guid,omitempty"`
+	ProcessGuid   string `protobuf:"bytes,2,opt,name=process_guid,json=processGuid,proto3" json:"process_guid,omitempty"`
+	InstanceGuid  string `protobuf:"bytes,3,opt,name=instance_guid,json=instanceGuid,proto3" json:"instance_guid,omitempty"`
+	Index         int32  `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	TaskGuid      string `protobuf:"bytes,5,opt,name=task_guid,json=taskGuid,proto3" json:"task_guid,omitempty"`
+	Domain        string `protobuf:"bytes,6,opt,name=domain,proto3" json:"domain,omitempty"`
+	Priority      int32  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	MemoryMb      int32  `protobuf:"varint,8,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	PreemptedBy   string `protobuf:"bytes,9,opt,name=preempted_by,json=preemptedBy,proto3" json:"preempted_by,omitempty"`
+}
+
+func (m *Eviction) Reset()      { *m = Eviction{} }
+func (*Eviction) ProtoMessage() {}
+func (*Eviction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{8}
+}
+func (m *Eviction) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Eviction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Eviction.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Eviction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Eviction.Merge(m, src)
+}
+func (m *Eviction) XXX_Size() int {
+	return m.Size()
+}
+func (m *Eviction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Eviction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Eviction proto.InternalMessageInfo
+
+func (m *Eviction) GetContainerGuid() string {
+	if m != nil {
+		return m.ContainerGuid
+	}
+	return ""
+}
+
+func (m *Eviction) GetProcessGuid() string {
+	if m != nil {
+		return m.ProcessGuid
+	}
+	return ""
+}
+
+func (m *Eviction) GetInstanceGuid() string {
+	if m != nil {
+		return m.InstanceGuid
+	}
+	return ""
+}
+
+func (m *Eviction) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Eviction) GetTaskGuid() string {
+	if m != nil {
+		return m.TaskGuid
+	}
+	return ""
+}
+
+func (m *Eviction) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *Eviction) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Eviction) GetMemoryMb() int32 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *Eviction) GetPreemptedBy() string {
+	if m != nil {
+		return m.PreemptedBy
+	}
+	return ""
+}
+
+type ScoringConfig struct {
+	Strategy string          `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Weights  *ScoringWeights `protobuf:"bytes,2,opt,name=weights,proto3" json:"weights,omitempty"`
+}
+
+func (m *ScoringConfig) Reset()      { *m = ScoringConfig{} }
+func (*ScoringConfig) ProtoMessage() {}
+func (*ScoringConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{9}
+}
+func (m *ScoringConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScoringConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScoringConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScoringConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoringConfig.Merge(m, src)
+}
+func (m *ScoringConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScoringConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoringConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoringConfig proto.InternalMessageInfo
+
+func (m *ScoringConfig) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *ScoringConfig) GetWeights() *ScoringWeights {
+	if m != nil {
+		return m.Weights
+	}
+	return nil
+}
+
+type ScoringWeights struct {
+	MemoryMb   float64 `protobuf:"fixed64,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	DiskMb     float64 `protobuf:"fixed64,2,opt,name=disk_mb,json=diskMb,proto3" json:"disk_mb,omitempty"`
+	Containers float64 `protobuf:"fixed64,3,opt,name=containers,proto3" json:"containers,omitempty"`
+	CpuWeight  float64 `protobuf:"fixed64,4,opt,name=cpu_weight,json=cpuWeight,proto3" json:"cpu_weight,omitempty"`
+	MaxPids    float64 `protobuf:"fixed64,5,opt,name=max_pids,json=maxPids,proto3" json:"max_pids,omitempty"`
+}
+
+func (m *ScoringWeights) Reset()      { *m = ScoringWeights{} }
+func (*ScoringWeights) ProtoMessage() {}
+func (*ScoringWeights) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{10}
+}
+func (m *ScoringWeights) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScoringWeights) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScoringWeights.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScoringWeights) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScoringWeights.Merge(m, src)
+}
+func (m *ScoringWeights) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScoringWeights) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScoringWeights.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScoringWeights proto.InternalMessageInfo
+
+func (m *ScoringWeights) GetMemoryMb() float64 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *ScoringWeights) GetDiskMb() float64 {
+	if m != nil {
+		return m.DiskMb
+	}
+	return 0
+}
+
+func (m *ScoringWeights) GetContainers() float64 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+func (m *ScoringWeights) GetCpuWeight() float64 {
+	if m != nil {
+		return m.CpuWeight
+	}
+	return 0
+}
+
+func (m *ScoringWeights) GetMaxPids() float64 {
+	if m != nil {
+		return m.MaxPids
+	}
+	return 0
+}
+
+type StackHealth struct {
+	Path    string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Healthy bool   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *StackHealth) Reset()      { *m = StackHealth{} }
+func (*StackHealth) ProtoMessage() {}
+func (*StackHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{11}
+}
+func (m *StackHealth) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StackHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StackHealth.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StackHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StackHealth.Merge(m, src)
+}
+func (m *StackHealth) XXX_Size() int {
+	return m.Size()
+}
+func (m *StackHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_StackHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StackHealth proto.InternalMessageInfo
+
+func (m *StackHealth) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *StackHealth) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *StackHealth) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// DomainResources is both a DomainQuota and a DomainUsage.
+type DomainResources struct {
+	MemoryMb   int32 `protobuf:"varint,1,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
+	DiskMb     int32 `protobuf:"varint,2,opt,name=disk_mb,json=diskMb,proto3" json:"disk_mb,omitempty"`
+	Containers int64 `protobuf:"varint,3,opt,name=containers,proto3" json:"containers,omitempty"`
+}
+
+func (m *DomainResources) Reset()      { *m = DomainResources{} }
+func (*DomainResources) ProtoMessage() {}
+func (*DomainResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5660e50dc1c3f612, []int{12}
+}
+func (m *DomainResources) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DomainResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DomainResources.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DomainResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DomainResources.Merge(m, src)
+}
+func (m *DomainResources) XXX_Size() int {
+	return m.Size()
+}
+func (m *DomainResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_DomainResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DomainResources proto.InternalMessageInfo
+
+func (m *DomainResources) GetMemoryMb() int32 {
+	if m != nil {
+		return m.MemoryMb
+	}
+	return 0
+}
+
+func (m *DomainResources) GetDiskMb() int32 {
+	if m != nil {
+		return m.DiskMb
+	}
+	return 0
+}
+
+func (m *DomainResources) GetContainers() int64 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CellState)(nil), "rep.CellState")
+	proto.RegisterMapType((map[string]DomainResources)(nil), "rep.CellState.DomainQuotasEntry")
+	proto.RegisterMapType((map[string]DomainResources)(nil), "rep.CellState.DomainUsageEntry")
+	proto.RegisterMapType((map[string]string)(nil), "rep.CellState.PlacementLabelsEntry")
+	proto.RegisterMapType((map[string]StackHealth)(nil), "rep.CellState.StackHealthEntry")
+	proto.RegisterType((*Work)(nil), "rep.Work")
+	proto.RegisterType((*Resources)(nil), "rep.Resources")
+	proto.RegisterType((*Resource)(nil), "rep.Resource")
+	proto.RegisterType((*PlacementConstraint)(nil), "rep.PlacementConstraint")
+	proto.RegisterType((*PlacementFailure)(nil), "rep.PlacementFailure")
+	proto.RegisterType((*LRP)(nil), "rep.LRP")
+	proto.RegisterType((*Task)(nil), "rep.Task")
+	proto.RegisterType((*Eviction)(nil), "rep.Eviction")
+	proto.RegisterType((*ScoringConfig)(nil), "rep.ScoringConfig")
+	proto.RegisterType((*ScoringWeights)(nil), "rep.ScoringWeights")
+	proto.RegisterType((*StackHealth)(nil), "rep.StackHealth")
+	proto.RegisterType((*DomainResources)(nil), "rep.DomainResources")
+}
+
+func init() { proto.RegisterFile("rep.proto", fileDescriptor_5660e50dc1c3f612) }
+
+var fileDescriptor_5660e50dc1c3f612 = []byte{
+	// 1508 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x4b, 0x6f, 0x1b, 0x47,
+	0x12, 0xe6, 0xf0, 0xcd, 0xa2, 0x48, 0x51, 0xad, 0xd7, 0xac, 0x16, 0x4b, 0x73, 0x69, 0x78, 0x97,
+	0x30, 0x60, 0x19, 0x2b, 0x03, 0x0b, 0x23, 0x41, 0x82, 0x58, 0x0f, 0xc7, 0x86, 0xa5, 0x80, 0x1e,
+	0xdb, 0x30, 0xe0, 0xcb, 0xa0, 0x39, 0xd3, 0xa2, 0x06, 0x9a, 0x99, 0x9e, 0x74, 0xcf, 0xd0, 0xa2,
+	0x4f, 0xf9, 0x09, 0xb9, 0xe7, 0xe2, 0x63, 0x7e, 0x48, 0x0e, 0xbe, 0x04, 0xf0, 0xd1, 0xc8, 0x21,
+	0x88, 0x65, 0xc0, 0xc8, 0xd1, 0x3f, 0x21, 0xe8, 0xee, 0x79, 0x51, 0xa2, 0x65, 0x27, 0x48, 0x80,
+	0xdc, 0x58, 0xf5, 0x7d, 0x5d, 0xdd, 0x5d, 0x35, 0xfd, 0x55, 0x11, 0x1a, 0x8c, 0x04, 0x9b, 0x01,
+	0xa3, 0x21, 0x45, 0x25, 0x46, 0x82, 0x8d, 0x6b, 0x63, 0x27, 0x3c, 0x8a, 0x46, 0x9b, 0x16, 0xf5,
+	0xae, 0x8f, 0xe9, 0x98, 0x5e, 0x97, 0xd8, 0x28, 0x3a, 0x94, 0x96, 0x34, 0xe4, 0x2f, 0xb5, 0x66,
+	0xa3, 0x83, 0xad, 0x30, 0xc2, 0xae, 0xe9, 0xb2, 0x38, 0x4a, 0xff, 0xa7, 0x26, 0x34, 0x76, 0x88,
+	0xeb, 0x3e, 0x08, 0x71, 0x48, 0xd0, 0x3a, 0xd4, 0x18, 0x09, 0xcc, 0x88, 0xb9, 0xba, 0xd6, 0xd3,
+	0x06, 0x0d, 0xa3, 0xca, 0x48, 0xf0, 0x88, 0xb9, 0x02, 0xb0, 0x88, 0xeb, 0x9a, 0x8e, 0xad, 0x17,
+	0x15, 0x20, 0xcc, 0xbb, 0x36, 0xfa, 0x17, 0x80, 0x02, 0x7c, 0x9b, 0x9c, 0xe8, 0xa5, 0x9e, 0x36,
+	0x28, 0x19, 0x0d, 0x89, 0x09, 0x07, 0xba, 0x0a, 0x4b, 0x8c, 0xd2, 0xd0, 0x3c, 0xe4, 0x66, 0xc0,
+	0xe8, 0xc4, 0xb1, 0x09, 0xe3, 0x7a, 0xb9, 0xa7, 0x0d, 0x16, 0x8c, 0x45, 0x01, 0xdc, 0xe6, 0xc3,
+	0xc4, 0x8d, 0xf6, 0x60, 0x19, 0x4f, 0xb0, 0xe3, 0xe2, 0x91, 0x4b, 0x4c, 0x46, 0x38, 0x8d, 0x98,
+	0x45, 0xb8, 0x5e, 0xe9, 0x69, 0x83, 0xe6, 0x56, 0x7b, 0x53, 0xdc, 0xdc, 0x48, 0xbc, 0xdb, 0xe5,
+	0x17, 0x3f, 0x5f, 0x2a, 0x18, 0x28, 0x5d, 0x90, 0x22, 0xe8, 0x33, 0x58, 0x0c, 0x69, 0x88, 0xdd,
+	0x5c, 0x88, 0xea, 0x05, 0x21, 0xda, 0x92, 0x9c, 0x2d, 0xef, 0x43, 0xd9, 0x65, 0x01, 0xd7, 0x6b,
+	0xbd, 0xd2, 0xa0, 0xb9, 0x55, 0x97, 0x6b, 0xf6, 0x8d, 0x61, 0xcc, 0x96, 0x18, 0xba, 0x02, 0x95,
+	0x10, 0xf3, 0x63, 0xae, 0xd7, 0x25, 0xa9, 0x21, 0x49, 0x0f, 0x31, 0x3f, 0x8e, 0x59, 0x0a, 0x45,
+	0x37, 0x41, 0xe7, 0x21, 0x66, 0xa1, 0xe3, 0x8f, 0x4d, 0x8b, 0xfa, 0x21, 0x76, 0x7c, 0xc2, 0x4c,
+	0x8b, 0x46, 0x7e, 0xa8, 0x37, 0x64, 0xa6, 0xd6, 0x12, 0x7c, 0x27, 0x81, 0x77, 0x04, 0x8a, 0x10,
+	0x94, 0x9f, 0x51, 0x9f, 0xe8, 0x20, 0x73, 0x2d, 0x7f, 0xa3, 0x2e, 0x00, 0x99, 0x60, 0x2b, 0xc2,
+	0x82, 0xaf, 0x37, 0x7b, 0xda, 0xa0, 0x6e, 0xe4, 0x3c, 0xe8, 0x0a, 0xb4, 0x27, 0xd4, 0x8d, 0x3c,
+	0x62, 0xda, 0xcc, 0x99, 0x88, 0x3c, 0x2f, 0xf4, 0x4a, 0x83, 0x86, 0xd1, 0x52, 0xde, 0x5d, 0xe5,
+	0x14, 0xb4, 0xc0, 0xc5, 0x16, 0xf1, 0x88, 0x1f, 0x9a, 0x21, 0x1e, 0x73, 0xbd, 0xa5, 0x68, 0xa9,
+	0xf7, 0x21, 0x1e, 0x73, 0xf4, 0x7f, 0x58, 0xa7, 0x41, 0xe8, 0x50, 0x1f, 0xbb, 0xe6, 0x19, 0x7e,
+	0x5b, 0xf2, 0x57, 0x13, 0x78, 0x38, 0xb3, 0xee, 0x53, 0xd8, 0x08, 0x18, 0x3d, 0x99, 0x9a, 0x1e,
+	0xf1, 0x28, 0x9b, 0x9a, 0xd8, 0x75, 0xa9, 0x85, 0x05, 0xd3, 0xf4, 0x46, 0xfa, 0xa2, 0xbc, 0xf5,
+	0xba, 0x64, 0x1c, 0x48, 0xc2, 0xad, 0x14, 0x3f, 0x18, 0xa1, 0x2d, 0xa8, 0x71, 0x8b, 0x32, 0x71,
+	0xbf, 0x8e, 0x2c, 0x19, 0x92, 0x99, 0x7d, 0xa0, 0x7c, 0x3b, 0xd4, 0x3f, 0x74, 0xc6, 0x71, 0x8a,
+	0x13, 0x22, 0xfa, 0x0a, 0x3a, 0xd9, 0xf9, 0x5c, 0x3c, 0x22, 0x2e, 0xd7, 0x97, 0x64, 0x59, 0x2e,
+	0xcb, 0xc5, 0xe9, 0xc7, 0xbd, 0x99, 0x1e, 0x74, 0x5f, 0xb2, 0xf6, 0xfc, 0x90, 0x4d, 0x8d, 0xc5,
+	0x60, 0xd6, 0x8b, 0xee, 0x41, 0xcb, 0xa6, 0x1e, 0x76, 0x7c, 0xf3, 0xeb, 0x88, 0x86, 0x98, 0xeb,
+	0x48, 0x06, 0xeb, 0x9d, 0x09, 0xb6, 0x2b, 0x39, 0xf7, 0x25, 0x45, 0x46, 0x8a, 0xcf, 0xb5, 0x60,
+	0xe7, 0x00, 0x74, 0x07, 0x62, 0xdb, 0x8c, 0x38, 0x1e, 0x13, 0x7d, 0x59, 0xc6, 0xba, 0x34, 0x37,
+	0xd6, 0x23, 0xc1, 0xc8, 0x87, 0x6a, 0xda, 0x99, 0x5f, 0x44, 0xe2, 0x21, 0xb6, 0x8e, 0xcd, 0x23,
+	0x82, 0xdd, 0xf0, 0x48, 0x5f, 0x99, 0x1b, 0xe9, 0x81, 0xa0, 0xdc, 0x91, 0x8c, 0x99, 0x48, 0x3c,
+	0xf3, 0xa3, 0xcb, 0xd0, 0x7a, 0x8a, 0x99, 0x67, 0x8a, 0xe7, 0x77, 0xc8, 0x09, 0xd7, 0x57, 0x65,
+	0x3d, 0x17, 0x84, 0xd3, 0x88, 0x7d, 0xe2, 0xd3, 0x95, 0x24, 0x0b, 0x5b, 0x47, 0xc4, 0x36, 0x6d,
+	0x12, 0x10, 0xdf, 0x26, 0xbe, 0xe5, 0x10, 0xae, 0xaf, 0x49, 0xfe, 0x9a, 0xc0, 0x77, 0x24, 0xbc,
+	0x9b, 0x43, 0xd1, 0x7f, 0x61, 0x31, 0x5e, 0x94, 0x6e, 0xb0, 0x2e, 0x17, 0xb4, 0x95, 0x3b, 0xdd,
+	0xa2, 0x0b, 0x30, 0x26, 0x3e, 0x61, 0xb2, 0xf8, 0xba, 0xde, 0xd3, 0x06, 0x65, 0x23, 0xe7, 0xd9,
+	0xd8, 0x86, 0x95, 0x79, 0x15, 0x43, 0x1d, 0x28, 0x1d, 0x93, 0x69, 0xac, 0x4f, 0xe2, 0x27, 0x5a,
+	0x81, 0xca, 0x04, 0xbb, 0x11, 0x89, 0xa5, 0x49, 0x19, 0x9f, 0x14, 0x6f, 0x6a, 0x1b, 0x8f, 0x60,
+	0xe9, 0x5c, 0xa1, 0xe6, 0x04, 0xb8, 0x9a, 0x0f, 0xd0, 0xdc, 0x5a, 0x91, 0x59, 0x55, 0x0b, 0x53,
+	0x61, 0xc8, 0x87, 0x7d, 0x08, 0x9d, 0xb3, 0x35, 0xfb, 0x13, 0xa2, 0x0e, 0xa1, 0x73, 0xb6, 0x7e,
+	0x73, 0xa2, 0xfe, 0x67, 0x36, 0x6a, 0x47, 0xbd, 0x90, 0x6c, 0x5d, 0x2e, 0x62, 0xff, 0x07, 0x0d,
+	0xca, 0x8f, 0x29, 0x3b, 0x4e, 0x45, 0x4d, 0xfb, 0x18, 0x51, 0x2b, 0x5e, 0x28, 0x6a, 0xb9, 0x4e,
+	0x50, 0x9a, 0xe9, 0x04, 0x57, 0xa0, 0xcd, 0x08, 0x27, 0x6c, 0xa2, 0x5e, 0xbb, 0x63, 0x4b, 0x9d,
+	0x6f, 0x18, 0xad, 0x9c, 0xf7, 0xae, 0x8d, 0xfe, 0x07, 0x0d, 0x32, 0x71, 0x2c, 0x61, 0x09, 0x6d,
+	0x17, 0x5b, 0xb5, 0xe4, 0x56, 0x7b, 0xb1, 0x37, 0xde, 0x2e, 0x63, 0xf5, 0xbf, 0xd3, 0xa0, 0x91,
+	0x09, 0xf4, 0x3f, 0xa1, 0x11, 0x6b, 0x8b, 0x37, 0x92, 0x89, 0xa9, 0x18, 0x75, 0xe5, 0x38, 0x18,
+	0x89, 0xd3, 0xd9, 0x0e, 0x3f, 0x16, 0x50, 0x51, 0x42, 0x55, 0x61, 0x1e, 0x8c, 0xc4, 0xd7, 0x96,
+	0x4a, 0x30, 0x8f, 0xfb, 0x54, 0xce, 0x23, 0xfb, 0x58, 0x10, 0x99, 0x4f, 0x89, 0x33, 0x3e, 0x0a,
+	0xe5, 0xc9, 0x2b, 0x46, 0xc3, 0x0a, 0xa2, 0xc7, 0xd2, 0x81, 0xfe, 0x01, 0x75, 0x0f, 0x9f, 0x98,
+	0x81, 0x63, 0xab, 0x86, 0x54, 0x31, 0x6a, 0x1e, 0x3e, 0x19, 0x3a, 0x36, 0xef, 0x3f, 0x83, 0x7a,
+	0x72, 0xb8, 0x3f, 0x78, 0xb6, 0x7c, 0xf0, 0xd2, 0x4c, 0xf0, 0x0f, 0x1c, 0xab, 0xff, 0x56, 0x83,
+	0xe5, 0xf4, 0x91, 0xec, 0x50, 0x9f, 0x87, 0x0c, 0x3b, 0x7e, 0x38, 0x47, 0xe4, 0xb5, 0x79, 0x22,
+	0x7f, 0xbe, 0x65, 0x14, 0xe7, 0xb5, 0x0c, 0x31, 0x15, 0xa8, 0x26, 0x9e, 0x94, 0x5c, 0xb5, 0x6e,
+	0x74, 0x03, 0x56, 0xb3, 0x6d, 0xc8, 0x49, 0xc0, 0x08, 0xe7, 0xb2, 0xae, 0x65, 0x19, 0x66, 0x25,
+	0x05, 0xf7, 0x32, 0x0c, 0xdd, 0x80, 0x35, 0x71, 0x5b, 0xc7, 0xe7, 0x21, 0xf6, 0x2d, 0xc2, 0xcd,
+	0x40, 0x34, 0x45, 0xe2, 0xba, 0x71, 0x62, 0x97, 0x3d, 0x7c, 0x72, 0x37, 0x01, 0x87, 0x84, 0x09,
+	0x75, 0xeb, 0xef, 0x42, 0x27, 0xbd, 0xe7, 0x6d, 0xec, 0xb8, 0x11, 0x23, 0x68, 0x0d, 0xaa, 0x8c,
+	0x60, 0x4e, 0xfd, 0x6c, 0x56, 0x11, 0x16, 0xd2, 0xa1, 0xe6, 0x11, 0x2e, 0xf5, 0x56, 0x09, 0x42,
+	0x62, 0xf6, 0xdf, 0x16, 0xa1, 0xb4, 0x6f, 0x0c, 0x85, 0x04, 0x26, 0xdb, 0x9b, 0xe3, 0xc8, 0xb1,
+	0xe3, 0x00, 0x0b, 0x89, 0xf3, 0xcb, 0xc8, 0xb1, 0xd1, 0x17, 0xd0, 0xce, 0xa6, 0x25, 0x53, 0xbc,
+	0xc2, 0xe4, 0x1d, 0x7b, 0xd4, 0x26, 0x2e, 0xdf, 0xbc, 0x25, 0xd1, 0x7d, 0x63, 0x78, 0x8f, 0xa4,
+	0xea, 0xaf, 0x56, 0xec, 0xb3, 0xe0, 0x1e, 0x99, 0xa2, 0xfb, 0x90, 0x65, 0xc0, 0xb4, 0xd2, 0xea,
+	0xc8, 0x24, 0x36, 0xb7, 0x74, 0xf9, 0xd5, 0xcf, 0xa9, 0x5e, 0x1c, 0x6b, 0x39, 0x38, 0x0f, 0xa1,
+	0xeb, 0x50, 0x4f, 0xc6, 0x1a, 0xf9, 0x35, 0x24, 0x8f, 0x27, 0xf9, 0x02, 0xe3, 0xb5, 0x29, 0x49,
+	0x68, 0x23, 0x17, 0xad, 0x41, 0x26, 0xb7, 0x61, 0x28, 0x03, 0x6d, 0x40, 0x3d, 0x60, 0x0e, 0x65,
+	0x4e, 0x38, 0x95, 0xc3, 0x51, 0xc5, 0x48, 0x6d, 0xb4, 0x0d, 0x4b, 0xd9, 0xa9, 0x0f, 0x55, 0xae,
+	0xf5, 0x9a, 0xdc, 0x6b, 0x75, 0xf6, 0xc8, 0x71, 0x21, 0x8c, 0x4e, 0x70, 0xc6, 0xd3, 0xff, 0xb1,
+	0x08, 0x65, 0x21, 0x1d, 0xe2, 0x41, 0x08, 0xd9, 0xc8, 0x67, 0xb9, 0x2e, 0x1c, 0x32, 0xc3, 0x6b,
+	0x50, 0x55, 0x2d, 0x2e, 0x99, 0x29, 0x95, 0xf5, 0xf7, 0xcb, 0x5b, 0x25, 0xc9, 0xdb, 0x1a, 0x54,
+	0x45, 0x46, 0x88, 0x2d, 0xb3, 0x56, 0x37, 0x62, 0x6b, 0x26, 0x9f, 0xb5, 0x8f, 0xc9, 0x67, 0xfd,
+	0xf7, 0xe5, 0xf3, 0x79, 0x11, 0xea, 0x89, 0x3e, 0x8a, 0x57, 0x9b, 0x4d, 0x93, 0xb9, 0xc4, 0xb6,
+	0x52, 0xaf, 0xcc, 0xee, 0xbf, 0x61, 0x21, 0x60, 0xd4, 0x22, 0x9c, 0x2b, 0x92, 0xca, 0x71, 0x33,
+	0xf6, 0x49, 0xca, 0xb9, 0x77, 0x50, 0x9a, 0xf3, 0x0e, 0x56, 0xa0, 0xa2, 0x86, 0x7b, 0xa5, 0x3e,
+	0xca, 0x98, 0x2d, 0x6c, 0xe5, 0xbd, 0x85, 0xad, 0xce, 0x14, 0xf6, 0xa2, 0x34, 0xcd, 0x48, 0x67,
+	0xfd, 0x8c, 0x74, 0xca, 0xbb, 0x10, 0xe2, 0x05, 0x21, 0xb1, 0xcd, 0xd1, 0x54, 0x6f, 0x24, 0x77,
+	0x89, 0x7d, 0xdb, 0xd3, 0xfe, 0x13, 0x68, 0xcd, 0xcc, 0x89, 0x62, 0x33, 0x51, 0xfd, 0x90, 0x8c,
+	0x93, 0xfe, 0x99, 0xda, 0xe8, 0x1a, 0xd4, 0x94, 0xa4, 0xf2, 0xf8, 0x51, 0x2f, 0xe7, 0x07, 0x4d,
+	0x25, 0xae, 0xdc, 0x48, 0x38, 0xfd, 0xe7, 0x1a, 0xb4, 0x67, 0xb1, 0xf3, 0x4a, 0xaf, 0xbd, 0x5f,
+	0xe9, 0xb5, 0x0b, 0xba, 0x90, 0xf6, 0x81, 0x2e, 0xa4, 0x5d, 0xd4, 0x85, 0xb4, 0xac, 0x0b, 0xdd,
+	0x87, 0x66, 0x6e, 0x08, 0x10, 0x7f, 0x20, 0x02, 0x1c, 0x1e, 0xc5, 0x17, 0x97, 0xbf, 0x85, 0x2e,
+	0xaa, 0xe1, 0x51, 0x29, 0x59, 0xdd, 0x48, 0x4c, 0x51, 0x62, 0xc2, 0x18, 0x65, 0x71, 0xfd, 0x95,
+	0xd1, 0x1f, 0xc3, 0xe2, 0x99, 0x69, 0xe5, 0xaf, 0xe9, 0xbd, 0xdb, 0x9f, 0xbf, 0x7c, 0xdd, 0x2d,
+	0xbc, 0x7a, 0xdd, 0x2d, 0xbc, 0x7b, 0xdd, 0xd5, 0xbe, 0x39, 0xed, 0x6a, 0xdf, 0x9f, 0x76, 0x0b,
+	0x2f, 0x4e, 0xbb, 0xda, 0xcb, 0xd3, 0xae, 0xf6, 0xcb, 0x69, 0x57, 0xfb, 0xf5, 0xb4, 0x5b, 0x78,
+	0x77, 0xda, 0xd5, 0xbe, 0x7d, 0xd3, 0x2d, 0xbc, 0x7c, 0xd3, 0x2d, 0xbc, 0x7a, 0xd3, 0x2d, 0x3c,
+	0xa9, 0x27, 0x7f, 0x75, 0x47, 0x55, 0xf9, 0xeb, 0xc6, 0x6f, 0x03, 0x00, 0x2c, 0x9b, 0x14, 0x64,
+	0x1d, 0x0f, 0x00, 0x00,
+}
+
+func (this *CellState) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 28)
+	s = append(s, "&protobuf.CellState{")
+	s = append(s, "RepUrl: "+fmt.Sprintf("%#v", this.RepUrl)+",\n")
+	s = append(s, "CellId: "+fmt.Sprintf("%#v", this.CellId)+",\n")
+	s = append(s, "CellIndex: "+fmt.Sprintf("%#v", this.CellIndex)+",\n")
+	s = append(s, "RootFsProviders: "+fmt.Sprintf("%#v", this.RootFsProviders)+",\n")
+	s = append(s, "AvailableResources: "+strings.Replace(this.AvailableResources.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "TotalResources: "+strings.Replace(this.TotalResources.GoString(), `&`, ``, 1)+",\n")
+	if this.Lrps != nil {
+		vs := make([]*LRP, len(this.Lrps))
+		for i := range vs {
+			vs[i] = &this.Lrps[i]
+		}
+		s = append(s, "Lrps: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Tasks != nil {
+		vs := make([]*Task, len(this.Tasks))
+		for i := range vs {
+			vs[i] = &this.Tasks[i]
+		}
+		s = append(s, "Tasks: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "StartingContainerCount: "+fmt.Sprintf("%#v", this.StartingContainerCount)+",\n")
+	s = append(s, "Zone: "+fmt.Sprintf("%#v", this.Zone)+",\n")
+	s = append(s, "Evacuating: "+fmt.Sprintf("%#v", this.Evacuating)+",\n")
+	s = append(s, "VolumeDrivers: "+fmt.Sprintf("%#v", this.VolumeDrivers)+",\n")
+	s = append(s, "PlacementTags: "+fmt.Sprintf("%#v", this.PlacementTags)+",\n")
+	s = append(s, "OptionalPlacementTags: "+fmt.Sprintf("%#v", this.OptionalPlacementTags)+",\n")
+	s = append(s, "ProxyMemoryAllocationMb: "+fmt.Sprintf("%#v", this.ProxyMemoryAllocationMb)+",\n")
+	s = append(s, "Scoring: "+strings.Replace(this.Scoring.GoString(), `&`, ``, 1)+",\n")
+	keysForPlacementLabels := make([]string, 0, len(this.PlacementLabels))
+	for k, _ := range this.PlacementLabels {
+		keysForPlacementLabels = append(keysForPlacementLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForPlacementLabels)
+	mapStringForPlacementLabels := "map[string]string{"
+	for _, k := range keysForPlacementLabels {
+		mapStringForPlacementLabels += fmt.Sprintf("%#v: %#v,", k, this.PlacementLabels[k])
+	}
+	mapStringForPlacementLabels += "}"
+	if this.PlacementLabels != nil {
+		s = append(s, "PlacementLabels: "+mapStringForPlacementLabels+",\n")
+	}
+	keysForDomainQuotas := make([]string, 0, len(this.DomainQuotas))
+	for k, _ := range this.DomainQuotas {
+		keysForDomainQuotas = append(keysForDomainQuotas, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForDomainQuotas)
+	mapStringForDomainQuotas := "map[string]DomainResources{"
+	for _, k := range keysForDomainQuotas {
+		mapStringForDomainQuotas += fmt.Sprintf("%#v: %#v,", k, this.DomainQuotas[k])
+	}
+	mapStringForDomainQuotas += "}"
+	if this.DomainQuotas != nil {
+		s = append(s, "DomainQuotas: "+mapStringForDomainQuotas+",\n")
+	}
+	keysForDomainUsage := make([]string, 0, len(this.DomainUsage))
+	for k, _ := range this.DomainUsage {
+		keysForDomainUsage = append(keysForDomainUsage, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForDomainUsage)
+	mapStringForDomainUsage := "map[string]DomainResources{"
+	for _, k := range keysForDomainUsage {
+		mapStringForDomainUsage += fmt.Sprintf("%#v: %#v,", k, this.DomainUsage[k])
+	}
+	mapStringForDomainUsage += "}"
+	if this.DomainUsage != nil {
+		s = append(s, "DomainUsage: "+mapStringForDomainUsage+",\n")
+	}
+	keysForStackHealth := make([]string, 0, len(this.StackHealth))
+	for k, _ := range this.StackHealth {
+		keysForStackHealth = append(keysForStackHealth, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForStackHealth)
+	mapStringForStackHealth := "map[string]StackHealth{"
+	for _, k := range keysForStackHealth {
+		mapStringForStackHealth += fmt.Sprintf("%#v: %#v,", k, this.StackHealth[k])
+	}
+	mapStringForStackHealth += "}"
+	if this.StackHealth != nil {
+		s = append(s, "StackHealth: "+mapStringForStackHealth+",\n")
+	}
+	s = append(s, "WarmRootfses: "+fmt.Sprintf("%#v", this.WarmRootfses)+",\n")
+	s = append(s, "WarmCachedDependencies: "+fmt.Sprintf("%#v", this.WarmCachedDependencies)+",\n")
+	s = append(s, "CachedRootfses: "+fmt.Sprintf("%#v", this.CachedRootfses)+",\n")
+	s = append(s, "Generation: "+fmt.Sprintf("%#v", this.Generation)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Work) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&protobuf.Work{")
+	if this.Lrps != nil {
+		vs := make([]*LRP, len(this.Lrps))
+		for i := range vs {
+			vs[i] = &this.Lrps[i]
+		}
+		s = append(s, "Lrps: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Tasks != nil {
+		vs := make([]*Task, len(this.Tasks))
+		for i := range vs {
+			vs[i] = &this.Tasks[i]
+		}
+		s = append(s, "Tasks: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "CellId: "+fmt.Sprintf("%#v", this.CellId)+",\n")
+	s = append(s, "ReservationId: "+fmt.Sprintf("%#v", this.ReservationId)+",\n")
+	if this.Evictions != nil {
+		vs := make([]*Eviction, len(this.Evictions))
+		for i := range vs {
+			vs[i] = &this.Evictions[i]
+		}
+		s = append(s, "Evictions: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Resources) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&protobuf.Resources{")
+	s = append(s, "MemoryMb: "+fmt.Sprintf("%#v", this.MemoryMb)+",\n")
+	s = append(s, "DiskMb: "+fmt.Sprintf("%#v", this.DiskMb)+",\n")
+	s = append(s, "Containers: "+fmt.Sprintf("%#v", this.Containers)+",\n")
+	s = append(s, "CpuWeight: "+fmt.Sprintf("%#v", this.CpuWeight)+",\n")
+	s = append(s, "MaxPids: "+fmt.Sprintf("%#v", this.MaxPids)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Resource) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&protobuf.Resource{")
+	s = append(s, "MemoryMb: "+fmt.Sprintf("%#v", this.MemoryMb)+",\n")
+	s = append(s, "DiskMb: "+fmt.Sprintf("%#v", this.DiskMb)+",\n")
+	s = append(s, "MaxPids: "+fmt.Sprintf("%#v", this.MaxPids)+",\n")
+	s = append(s, "CpuWeight: "+fmt.Sprintf("%#v", this.CpuWeight)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PlacementConstraint) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&protobuf.PlacementConstraint{")
+	s = append(s, "PlacementTags: "+fmt.Sprintf("%#v", this.PlacementTags)+",\n")
+	s = append(s, "VolumeDrivers: "+fmt.Sprintf("%#v", this.VolumeDrivers)+",\n")
+	s = append(s, "RootFs: "+fmt.Sprintf("%#v", this.RootFs)+",\n")
+	s = append(s, "PlacementExpressions: "+fmt.Sprintf("%#v", this.PlacementExpressions)+",\n")
+	s = append(s, "MaxInstancesPerCell: "+fmt.Sprintf("%#v", this.MaxInstancesPerCell)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PlacementFailure) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&protobuf.PlacementFailure{")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LRP) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&protobuf.LRP{")
+	s = append(s, "InstanceGuid: "+fmt.Sprintf("%#v", this.InstanceGuid)+",\n")
+	s = append(s, "ActualLrpKey: "+strings.Replace(this.ActualLrpKey.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "PlacementConstraint: "+strings.Replace(this.PlacementConstraint.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "Resource: "+strings.Replace(this.Resource.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "Priority: "+fmt.Sprintf("%#v", this.Priority)+",\n")
+	if this.PlacementFailure != nil {
+		s = append(s, "PlacementFailure: "+fmt.Sprintf("%#v", this.PlacementFailure)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Task) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&protobuf.Task{")
+	s = append(s, "TaskGuid: "+fmt.Sprintf("%#v", this.TaskGuid)+",\n")
+	s = append(s, "Domain: "+fmt.Sprintf("%#v", this.Domain)+",\n")
+	s = append(s, "PlacementConstraint: "+strings.Replace(this.PlacementConstraint.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "Resource: "+strings.Replace(this.Resource.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "Failed: "+fmt.Sprintf("%#v", this.Failed)+",\n")
+	s = append(s, "Priority: "+fmt.Sprintf("%#v", this.Priority)+",\n")
+	if this.PlacementFailure != nil {
+		s = append(s, "PlacementFailure: "+fmt.Sprintf("%#v", this.PlacementFailure)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Eviction) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&protobuf.Eviction{")
+	s = append(s, "ContainerGuid: "+fmt.Sprintf("%#v", this.ContainerGuid)+",\n")
+	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
+	s = append(s, "InstanceGuid: "+fmt.Sprintf("%#v", this.InstanceGuid)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "TaskGuid: "+fmt.Sprintf("%#v", this.TaskGuid)+",\n")
+	s = append(s, "Domain: "+fmt.Sprintf("%#v", this.Domain)+",\n")
+	s = append(s, "Priority: "+fmt.Sprintf("%#v", this.Priority)+",\n")
+	s = append(s, "MemoryMb: "+fmt.Sprintf("%#v", this.MemoryMb)+",\n")
+	s = append(s, "PreemptedBy: "+fmt.Sprintf("%#v", this.PreemptedBy)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ScoringConfig) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&protobuf.ScoringConfig{")
+	s = append(s, "Strategy: "+fmt.Sprintf("%#v", this.Strategy)+",\n")
+	if this.Weights != nil {
+		s = append(s, "Weights: "+fmt.Sprintf("%#v", this.Weights)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ScoringWeights) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&protobuf.ScoringWeights{")
+	s = append(s, "MemoryMb: "+fmt.Sprintf("%#v", this.MemoryMb)+",\n")
+	s = append(s, "DiskMb: "+fmt.Sprintf("%#v", this.DiskMb)+",\n")
+	s = append(s, "Containers: "+fmt.Sprintf("%#v", this.Containers)+",\n")
+	s = append(s, "CpuWeight: "+fmt.Sprintf("%#v", this.CpuWeight)+",\n")
+	s = append(s, "MaxPids: "+fmt.Sprintf("%#v", this.MaxPids)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *StackHealth) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&protobuf.StackHealth{")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Healthy: "+fmt.Sprintf("%#v", this.Healthy)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DomainResources) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&protobuf.DomainResources{")
+	s = append(s, "MemoryMb: "+fmt.Sprintf("%#v", this.MemoryMb)+",\n")
+	s = append(s, "DiskMb: "+fmt.Sprintf("%#v", this.DiskMb)+",\n")
+	s = append(s, "Containers: "+fmt.Sprintf("%#v", this.Containers)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringRep(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *CellState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CellState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.RepUrl) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.RepUrl)))
+		i += copy(dAtA[i:], m.RepUrl)
+	}
+	if len(m.CellId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.CellId)))
+		i += copy(dAtA[i:], m.CellId)
+	}
+	if m.CellIndex != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.CellIndex))
+	}
+	if len(m.RootFsProviders) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.RootFsProviders)))
+		i += copy(dAtA[i:], m.RootFsProviders)
+	}
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.AvailableResources.Size()))
+	n1, err := m.AvailableResources.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.TotalResources.Size()))
+	n2, err := m.TotalResources.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if len(m.Lrps) > 0 {
+		for _, msg := range m.Lrps {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Tasks) > 0 {
+		for _, msg := range m.Tasks {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.StartingContainerCount != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.StartingContainerCount))
+	}
+	if len(m.Zone) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Zone)))
+		i += copy(dAtA[i:], m.Zone)
+	}
+	if m.Evacuating {
+		dAtA[i] = 0x58
+		i++
+		if m.Evacuating {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.VolumeDrivers) > 0 {
+		for _, s := range m.VolumeDrivers {
+			dAtA[i] = 0x62
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.PlacementTags) > 0 {
+		for _, s := range m.PlacementTags {
+			dAtA[i] = 0x6a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.OptionalPlacementTags) > 0 {
+		for _, s := range m.OptionalPlacementTags {
+			dAtA[i] = 0x72
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.ProxyMemoryAllocationMb != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.ProxyMemoryAllocationMb))
+	}
+	dAtA[i] = 0x82
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.Scoring.Size()))
+	n3, err := m.Scoring.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	if len(m.PlacementLabels) > 0 {
+		for k, _ := range m.PlacementLabels {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.PlacementLabels[k]
+			mapSize := 1 + len(k) + sovRep(uint64(len(k))) + 1 + len(v) + sovRep(uint64(len(v)))
+			i = encodeVarintRep(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.DomainQuotas) > 0 {
+		for k, _ := range m.DomainQuotas {
+			dAtA[i] = 0x92
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.DomainQuotas[k]
+			msgSize := 0
+			if (&v) != nil {
+				msgSize = (&v).Size()
+				msgSize += 1 + sovRep(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovRep(uint64(len(k))) + msgSize
+			i = encodeVarintRep(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRep(dAtA, i, uint64((&v).Size()))
+			n4, err := (&v).MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n4
+		}
+	}
+	if len(m.DomainUsage) > 0 {
+		for k, _ := range m.DomainUsage {
+			dAtA[i] = 0x9a
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.DomainUsage[k]
+			msgSize := 0
+			if (&v) != nil {
+				msgSize = (&v).Size()
+				msgSize += 1 + sovRep(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovRep(uint64(len(k))) + msgSize
+			i = encodeVarintRep(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRep(dAtA, i, uint64((&v).Size()))
+			n5, err := (&v).MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n5
+		}
+	}
+	if len(m.StackHealth) > 0 {
+		for k, _ := range m.StackHealth {
+			dAtA[i] = 0xa2
+			i++
+			dAtA[i] = 0x1
+			i++
+			v := m.StackHealth[k]
+			msgSize := 0
+			if (&v) != nil {
+				msgSize = (&v).Size()
+				msgSize += 1 + sovRep(uint64(msgSize))
+			}
+			mapSize := 1 + len(k) + sovRep(uint64(len(k))) + msgSize
+			i = encodeVarintRep(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRep(dAtA, i, uint64((&v).Size()))
+			n6, err := (&v).MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n6
+		}
+	}
+	if len(m.WarmRootfses) > 0 {
+		for _, s := range m.WarmRootfses {
+			dAtA[i] = 0xaa
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.WarmCachedDependencies) > 0 {
+		for _, s := range m.WarmCachedDependencies {
+			dAtA[i] = 0xb2
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.CachedRootfses) > 0 {
+		for _, s := range m.CachedRootfses {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Generation != 0 {
+		dAtA[i] = 0xc0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Generation))
+	}
+	return i, nil
+}
+
+func (m *Work) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Work) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Lrps) > 0 {
+		for _, msg := range m.Lrps {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Tasks) > 0 {
+		for _, msg := range m.Tasks {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.CellId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.CellId)))
+		i += copy(dAtA[i:], m.CellId)
+	}
+	if len(m.ReservationId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.ReservationId)))
+		i += copy(dAtA[i:], m.ReservationId)
+	}
+	if len(m.Evictions) > 0 {
+		for _, msg := range m.Evictions {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRep(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Resources) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Resources) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.DiskMb))
+	}
+	if m.Containers != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Containers))
+	}
+	if m.CpuWeight != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.CpuWeight))
+	}
+	if m.MaxPids != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MaxPids))
+	}
+	return i, nil
+}
+
+func (m *Resource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Resource) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.DiskMb))
+	}
+	if m.MaxPids != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MaxPids))
+	}
+	if m.CpuWeight != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.CpuWeight))
+	}
+	return i, nil
+}
+
+func (m *PlacementConstraint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementConstraint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PlacementTags) > 0 {
+		for _, s := range m.PlacementTags {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.VolumeDrivers) > 0 {
+		for _, s := range m.VolumeDrivers {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.RootFs) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.RootFs)))
+		i += copy(dAtA[i:], m.RootFs)
+	}
+	if len(m.PlacementExpressions) > 0 {
+		for _, s := range m.PlacementExpressions {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MaxInstancesPerCell != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MaxInstancesPerCell))
+	}
+	return i, nil
+}
+
+func (m *PlacementFailure) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlacementFailure) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *LRP) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LRP) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.InstanceGuid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.InstanceGuid)))
+		i += copy(dAtA[i:], m.InstanceGuid)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.ActualLrpKey.Size()))
+	n7, err := m.ActualLrpKey.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.PlacementConstraint.Size()))
+	n8, err := m.PlacementConstraint.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n8
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.Resource.Size()))
+	n9, err := m.Resource.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if len(m.State) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Priority))
+	}
+	if m.PlacementFailure != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.PlacementFailure.Size()))
+		n10, err := m.PlacementFailure.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	return i, nil
+}
+
+func (m *Task) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Task) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.TaskGuid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.TaskGuid)))
+		i += copy(dAtA[i:], m.TaskGuid)
+	}
+	if len(m.Domain) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Domain)))
+		i += copy(dAtA[i:], m.Domain)
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.PlacementConstraint.Size()))
+	n11, err := m.PlacementConstraint.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n11
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRep(dAtA, i, uint64(m.Resource.Size()))
+	n12, err := m.Resource.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
+	if m.State != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.State))
+	}
+	if m.Failed {
+		dAtA[i] = 0x30
+		i++
+		if m.Failed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Priority))
+	}
+	if m.PlacementFailure != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.PlacementFailure.Size()))
+		n13, err := m.PlacementFailure.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+
+func (m *Eviction) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Eviction) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ContainerGuid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.ContainerGuid)))
+		i += copy(dAtA[i:], m.ContainerGuid)
+	}
+	if len(m.ProcessGuid) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.ProcessGuid)))
+		i += copy(dAtA[i:], m.ProcessGuid)
+	}
+	if len(m.InstanceGuid) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.InstanceGuid)))
+		i += copy(dAtA[i:], m.InstanceGuid)
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Index))
+	}
+	if len(m.TaskGuid) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.TaskGuid)))
+		i += copy(dAtA[i:], m.TaskGuid)
+	}
+	if len(m.Domain) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Domain)))
+		i += copy(dAtA[i:], m.Domain)
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Priority))
+	}
+	if m.MemoryMb != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MemoryMb))
+	}
+	if len(m.PreemptedBy) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.PreemptedBy)))
+		i += copy(dAtA[i:], m.PreemptedBy)
+	}
+	return i, nil
+}
+
+func (m *ScoringConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScoringConfig) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Strategy) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Strategy)))
+		i += copy(dAtA[i:], m.Strategy)
+	}
+	if m.Weights != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Weights.Size()))
+		n14, err := m.Weights.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+
+func (m *ScoringWeights) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScoringWeights) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		dAtA[i] = 0x9
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MemoryMb))))
+		i += 8
+	}
+	if m.DiskMb != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.DiskMb))))
+		i += 8
+	}
+	if m.Containers != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Containers))))
+		i += 8
+	}
+	if m.CpuWeight != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CpuWeight))))
+		i += 8
+	}
+	if m.MaxPids != 0 {
+		dAtA[i] = 0x29
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MaxPids))))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *StackHealth) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StackHealth) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Healthy {
+		dAtA[i] = 0x10
+		i++
+		if m.Healthy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *DomainResources) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DomainResources) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.DiskMb))
+	}
+	if m.Containers != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRep(dAtA, i, uint64(m.Containers))
+	}
+	return i, nil
+}
+
+func encodeVarintRep(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *CellState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RepUrl)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.CellId)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.CellIndex != 0 {
+		n += 1 + sovRep(uint64(m.CellIndex))
+	}
+	l = len(m.RootFsProviders)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = m.AvailableResources.Size()
+	n += 1 + l + sovRep(uint64(l))
+	l = m.TotalResources.Size()
+	n += 1 + l + sovRep(uint64(l))
+	if len(m.Lrps) > 0 {
+		for _, e := range m.Lrps {
+			l = e.Size()
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.Tasks) > 0 {
+		for _, e := range m.Tasks {
+			l = e.Size()
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if m.StartingContainerCount != 0 {
+		n += 1 + sovRep(uint64(m.StartingContainerCount))
+	}
+	l = len(m.Zone)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Evacuating {
+		n += 2
+	}
+	if len(m.VolumeDrivers) > 0 {
+		for _, s := range m.VolumeDrivers {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.PlacementTags) > 0 {
+		for _, s := range m.PlacementTags {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.OptionalPlacementTags) > 0 {
+		for _, s := range m.OptionalPlacementTags {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if m.ProxyMemoryAllocationMb != 0 {
+		n += 1 + sovRep(uint64(m.ProxyMemoryAllocationMb))
+	}
+	l = m.Scoring.Size()
+	n += 2 + l + sovRep(uint64(l))
+	if len(m.PlacementLabels) > 0 {
+		for k, v := range m.PlacementLabels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovRep(uint64(len(k))) + 1 + len(v) + sovRep(uint64(len(v)))
+			n += mapEntrySize + 2 + sovRep(uint64(mapEntrySize))
+		}
+	}
+	if len(m.DomainQuotas) > 0 {
+		for k, v := range m.DomainQuotas {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovRep(uint64(len(k))) + 1 + l + sovRep(uint64(l))
+			n += mapEntrySize + 2 + sovRep(uint64(mapEntrySize))
+		}
+	}
+	if len(m.DomainUsage) > 0 {
+		for k, v := range m.DomainUsage {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovRep(uint64(len(k))) + 1 + l + sovRep(uint64(l))
+			n += mapEntrySize + 2 + sovRep(uint64(mapEntrySize))
+		}
+	}
+	if len(m.StackHealth) > 0 {
+		for k, v := range m.StackHealth {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovRep(uint64(len(k))) + 1 + l + sovRep(uint64(l))
+			n += mapEntrySize + 2 + sovRep(uint64(mapEntrySize))
+		}
+	}
+	if len(m.WarmRootfses) > 0 {
+		for _, s := range m.WarmRootfses {
+			l = len(s)
+			n += 2 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.WarmCachedDependencies) > 0 {
+		for _, s := range m.WarmCachedDependencies {
+			l = len(s)
+			n += 2 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.CachedRootfses) > 0 {
+		for _, s := range m.CachedRootfses {
+			l = len(s)
+			n += 2 + l + sovRep(uint64(l))
+		}
+	}
+	if m.Generation != 0 {
+		n += 2 + sovRep(uint64(m.Generation))
+	}
+	return n
+}
+
+func (m *Work) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Lrps) > 0 {
+		for _, e := range m.Lrps {
+			l = e.Size()
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.Tasks) > 0 {
+		for _, e := range m.Tasks {
+			l = e.Size()
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	l = len(m.CellId)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.ReservationId)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if len(m.Evictions) > 0 {
+		for _, e := range m.Evictions {
+			l = e.Size()
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Resources) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		n += 1 + sovRep(uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		n += 1 + sovRep(uint64(m.DiskMb))
+	}
+	if m.Containers != 0 {
+		n += 1 + sovRep(uint64(m.Containers))
+	}
+	if m.CpuWeight != 0 {
+		n += 1 + sovRep(uint64(m.CpuWeight))
+	}
+	if m.MaxPids != 0 {
+		n += 1 + sovRep(uint64(m.MaxPids))
+	}
+	return n
+}
+
+func (m *Resource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		n += 1 + sovRep(uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		n += 1 + sovRep(uint64(m.DiskMb))
+	}
+	if m.MaxPids != 0 {
+		n += 1 + sovRep(uint64(m.MaxPids))
+	}
+	if m.CpuWeight != 0 {
+		n += 1 + sovRep(uint64(m.CpuWeight))
+	}
+	return n
+}
+
+func (m *PlacementConstraint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PlacementTags) > 0 {
+		for _, s := range m.PlacementTags {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if len(m.VolumeDrivers) > 0 {
+		for _, s := range m.VolumeDrivers {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	l = len(m.RootFs)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if len(m.PlacementExpressions) > 0 {
+		for _, s := range m.PlacementExpressions {
+			l = len(s)
+			n += 1 + l + sovRep(uint64(l))
+		}
+	}
+	if m.MaxInstancesPerCell != 0 {
+		n += 1 + sovRep(uint64(m.MaxInstancesPerCell))
+	}
+	return n
+}
+
+func (m *PlacementFailure) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *LRP) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InstanceGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = m.ActualLrpKey.Size()
+	n += 1 + l + sovRep(uint64(l))
+	l = m.PlacementConstraint.Size()
+	n += 1 + l + sovRep(uint64(l))
+	l = m.Resource.Size()
+	n += 1 + l + sovRep(uint64(l))
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovRep(uint64(m.Priority))
+	}
+	if m.PlacementFailure != nil {
+		l = m.PlacementFailure.Size()
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *Task) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TaskGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = m.PlacementConstraint.Size()
+	n += 1 + l + sovRep(uint64(l))
+	l = m.Resource.Size()
+	n += 1 + l + sovRep(uint64(l))
+	if m.State != 0 {
+		n += 1 + sovRep(uint64(m.State))
+	}
+	if m.Failed {
+		n += 2
+	}
+	if m.Priority != 0 {
+		n += 1 + sovRep(uint64(m.Priority))
+	}
+	if m.PlacementFailure != nil {
+		l = m.PlacementFailure.Size()
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *Eviction) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.ProcessGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.InstanceGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovRep(uint64(m.Index))
+	}
+	l = len(m.TaskGuid)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	l = len(m.Domain)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovRep(uint64(m.Priority))
+	}
+	if m.MemoryMb != 0 {
+		n += 1 + sovRep(uint64(m.MemoryMb))
+	}
+	l = len(m.PreemptedBy)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *ScoringConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Strategy)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Weights != nil {
+		l = m.Weights.Size()
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *ScoringWeights) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		n += 9
+	}
+	if m.DiskMb != 0 {
+		n += 9
+	}
+	if m.Containers != 0 {
+		n += 9
+	}
+	if m.CpuWeight != 0 {
+		n += 9
+	}
+	if m.MaxPids != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *StackHealth) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	if m.Healthy {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRep(uint64(l))
+	}
+	return n
+}
+
+func (m *DomainResources) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemoryMb != 0 {
+		n += 1 + sovRep(uint64(m.MemoryMb))
+	}
+	if m.DiskMb != 0 {
+		n += 1 + sovRep(uint64(m.DiskMb))
+	}
+	if m.Containers != 0 {
+		n += 1 + sovRep(uint64(m.Containers))
+	}
+	return n
+}
+
+func sovRep(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRep(x uint64) (n int) {
+	return sovRep(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *CellState) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForPlacementLabels := make([]string, 0, len(this.PlacementLabels))
+	for k, _ := range this.PlacementLabels {
+		keysForPlacementLabels = append(keysForPlacementLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForPlacementLabels)
+	mapStringForPlacementLabels := "map[string]string{"
+	for _, k := range keysForPlacementLabels {
+		mapStringForPlacementLabels += fmt.Sprintf("%v: %v,", k, this.PlacementLabels[k])
+	}
+	mapStringForPlacementLabels += "}"
+	keysForDomainQuotas := make([]string, 0, len(this.DomainQuotas))
+	for k, _ := range this.DomainQuotas {
+		keysForDomainQuotas = append(keysForDomainQuotas, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForDomainQuotas)
+	mapStringForDomainQuotas := "map[string]DomainResources{"
+	for _, k := range keysForDomainQuotas {
+		mapStringForDomainQuotas += fmt.Sprintf("%v: %v,", k, this.DomainQuotas[k])
+	}
+	mapStringForDomainQuotas += "}"
+	keysForDomainUsage := make([]string, 0, len(this.DomainUsage))
+	for k, _ := range this.DomainUsage {
+		keysForDomainUsage = append(keysForDomainUsage, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForDomainUsage)
+	mapStringForDomainUsage := "map[string]DomainResources{"
+	for _, k := range keysForDomainUsage {
+		mapStringForDomainUsage += fmt.Sprintf("%v: %v,", k, this.DomainUsage[k])
+	}
+	mapStringForDomainUsage += "}"
+	keysForStackHealth := make([]string, 0, len(this.StackHealth))
+	for k, _ := range this.StackHealth {
+		keysForStackHealth = append(keysForStackHealth, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForStackHealth)
+	mapStringForStackHealth := "map[string]StackHealth{"
+	for _, k := range keysForStackHealth {
+		mapStringForStackHealth += fmt.Sprintf("%v: %v,", k, this.StackHealth[k])
+	}
+	mapStringForStackHealth += "}"
+	s := strings.Join([]string{`&CellState{`,
+		`RepUrl:` + fmt.Sprintf("%v", this.RepUrl) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`CellIndex:` + fmt.Sprintf("%v", this.CellIndex) + `,`,
+		`RootFsProviders:` + fmt.Sprintf("%v", this.RootFsProviders) + `,`,
+		`AvailableResources:` + strings.Replace(strings.Replace(this.AvailableResources.String(), "Resources", "Resources", 1), `&`, ``, 1) + `,`,
+		`TotalResources:` + strings.Replace(strings.Replace(this.TotalResources.String(), "Resources", "Resources", 1), `&`, ``, 1) + `,`,
+		`Lrps:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Lrps), "LRP", "LRP", 1), `&`, ``, 1) + `,`,
+		`Tasks:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1), `&`, ``, 1) + `,`,
+		`StartingContainerCount:` + fmt.Sprintf("%v", this.StartingContainerCount) + `,`,
+		`Zone:` + fmt.Sprintf("%v", this.Zone) + `,`,
+		`Evacuating:` + fmt.Sprintf("%v", this.Evacuating) + `,`,
+		`VolumeDrivers:` + fmt.Sprintf("%v", this.VolumeDrivers) + `,`,
+		`PlacementTags:` + fmt.Sprintf("%v", this.PlacementTags) + `,`,
+		`OptionalPlacementTags:` + fmt.Sprintf("%v", this.OptionalPlacementTags) + `,`,
+		`ProxyMemoryAllocationMb:` + fmt.Sprintf("%v", this.ProxyMemoryAllocationMb) + `,`,
+		`Scoring:` + strings.Replace(strings.Replace(this.Scoring.String(), "ScoringConfig", "ScoringConfig", 1), `&`, ``, 1) + `,`,
+		`PlacementLabels:` + mapStringForPlacementLabels + `,`,
+		`DomainQuotas:` + mapStringForDomainQuotas + `,`,
+		`DomainUsage:` + mapStringForDomainUsage + `,`,
+		`StackHealth:` + mapStringForStackHealth + `,`,
+		`WarmRootfses:` + fmt.Sprintf("%v", this.WarmRootfses) + `,`,
+		`WarmCachedDependencies:` + fmt.Sprintf("%v", this.WarmCachedDependencies) + `,`,
+		`CachedRootfses:` + fmt.Sprintf("%v", this.CachedRootfses) + `,`,
+		`Generation:` + fmt.Sprintf("%v", this.Generation) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Work) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Work{`,
+		`Lrps:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Lrps), "LRP", "LRP", 1), `&`, ``, 1) + `,`,
+		`Tasks:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Tasks), "Task", "Task", 1), `&`, ``, 1) + `,`,
+		`CellId:` + fmt.Sprintf("%v", this.CellId) + `,`,
+		`ReservationId:` + fmt.Sprintf("%v", this.ReservationId) + `,`,
+		`Evictions:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Evictions), "Eviction", "Eviction", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Resources) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Resources{`,
+		`MemoryMb:` + fmt.Sprintf("%v", this.MemoryMb) + `,`,
+		`DiskMb:` + fmt.Sprintf("%v", this.DiskMb) + `,`,
+		`Containers:` + fmt.Sprintf("%v", this.Containers) + `,`,
+		`CpuWeight:` + fmt.Sprintf("%v", this.CpuWeight) + `,`,
+		`MaxPids:` + fmt.Sprintf("%v", this.MaxPids) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Resource) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Resource{`,
+		`MemoryMb:` + fmt.Sprintf("%v", this.MemoryMb) + `,`,
+		`DiskMb:` + fmt.Sprintf("%v", this.DiskMb) + `,`,
+		`MaxPids:` + fmt.Sprintf("%v", this.MaxPids) + `,`,
+		`CpuWeight:` + fmt.Sprintf("%v", this.CpuWeight) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PlacementConstraint) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PlacementConstraint{`,
+		`PlacementTags:` + fmt.Sprintf("%v", this.PlacementTags) + `,`,
+		`VolumeDrivers:` + fmt.Sprintf("%v", this.VolumeDrivers) + `,`,
+		`RootFs:` + fmt.Sprintf("%v", this.RootFs) + `,`,
+		`PlacementExpressions:` + fmt.Sprintf("%v", this.PlacementExpressions) + `,`,
+		`MaxInstancesPerCell:` + fmt.Sprintf("%v", this.MaxInstancesPerCell) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PlacementFailure) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PlacementFailure{`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LRP) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LRP{`,
+		`InstanceGuid:` + fmt.Sprintf("%v", this.InstanceGuid) + `,`,
+		`ActualLrpKey:` + strings.Replace(strings.Replace(this.ActualLrpKey.String(), "ActualLRPKey", "models.ActualLRPKey", 1), `&`, ``, 1) + `,`,
+		`PlacementConstraint:` + strings.Replace(strings.Replace(this.PlacementConstraint.String(), "PlacementConstraint", "PlacementConstraint", 1), `&`, ``, 1) + `,`,
+		`Resource:` + strings.Replace(strings.Replace(this.Resource.String(), "Resource", "Resource", 1), `&`, ``, 1) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`PlacementFailure:` + strings.Replace(fmt.Sprintf("%v", this.PlacementFailure), "PlacementFailure", "PlacementFailure", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Task) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Task{`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`PlacementConstraint:` + strings.Replace(strings.Replace(this.PlacementConstraint.String(), "PlacementConstraint", "PlacementConstraint", 1), `&`, ``, 1) + `,`,
+		`Resource:` + strings.Replace(strings.Replace(this.Resource.String(), "Resource", "Resource", 1), `&`, ``, 1) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`Failed:` + fmt.Sprintf("%v", this.Failed) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`PlacementFailure:` + strings.Replace(fmt.Sprintf("%v", this.PlacementFailure), "PlacementFailure", "PlacementFailure", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Eviction) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Eviction{`,
+		`ContainerGuid:` + fmt.Sprintf("%v", this.ContainerGuid) + `,`,
+		`ProcessGuid:` + fmt.Sprintf("%v", this.ProcessGuid) + `,`,
+		`InstanceGuid:` + fmt.Sprintf("%v", this.InstanceGuid) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`TaskGuid:` + fmt.Sprintf("%v", this.TaskGuid) + `,`,
+		`Domain:` + fmt.Sprintf("%v", this.Domain) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
+		`MemoryMb:` + fmt.Sprintf("%v", this.MemoryMb) + `,`,
+		`PreemptedBy:` + fmt.Sprintf("%v", this.PreemptedBy) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ScoringConfig) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ScoringConfig{`,
+		`Strategy:` + fmt.Sprintf("%v", this.Strategy) + `,`,
+		`Weights:` + strings.Replace(fmt.Sprintf("%v", this.Weights), "ScoringWeights", "ScoringWeights", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ScoringWeights) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ScoringWeights{`,
+		`MemoryMb:` + fmt.Sprintf("%v", this.MemoryMb) + `,`,
+		`DiskMb:` + fmt.Sprintf("%v", this.DiskMb) + `,`,
+		`Containers:` + fmt.Sprintf("%v", this.Containers) + `,`,
+		`CpuWeight:` + fmt.Sprintf("%v", this.CpuWeight) + `,`,
+		`MaxPids:` + fmt.Sprintf("%v", this.MaxPids) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StackHealth) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StackHealth{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Healthy:` + fmt.Sprintf("%v", this.Healthy) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DomainResources) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DomainResources{`,
+		`MemoryMb:` + fmt.Sprintf("%v", this.MemoryMb) + `,`,
+		`DiskMb:` + fmt.Sprintf("%v", this.DiskMb) + `,`,
+		`Containers:` + fmt.Sprintf("%v", this.Containers) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringRep(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *CellState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CellState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CellState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RepUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RepUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CellId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellIndex", wireType)
+			}
+			m.CellIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CellIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootFsProviders", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootFsProviders = append(m.RootFsProviders[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootFsProviders == nil {
+				m.RootFsProviders = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AvailableResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AvailableResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lrps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lrps = append(m.Lrps, LRP{})
+			if err := m.Lrps[len(m.Lrps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tasks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tasks = append(m.Tasks, Task{})
+			if err := m.Tasks[len(m.Tasks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartingContainerCount", wireType)
+			}
+			m.StartingContainerCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartingContainerCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Zone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evacuating", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Evacuating = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeDrivers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeDrivers = append(m.VolumeDrivers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlacementTags = append(m.PlacementTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OptionalPlacementTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OptionalPlacementTags = append(m.OptionalPlacementTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyMemoryAllocationMb", wireType)
+			}
+			m.ProxyMemoryAllocationMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProxyMemoryAllocationMb |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scoring", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Scoring.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementLabels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PlacementLabels == nil {
+				m.PlacementLabels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthRep
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipRep(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthRep
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.PlacementLabels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DomainQuotas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DomainQuotas == nil {
+				m.DomainQuotas = make(map[string]DomainResources)
+			}
+			var mapkey string
+			mapvalue := &DomainResources{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthRep
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &DomainResources{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipRep(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthRep
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DomainQuotas[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DomainUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DomainUsage == nil {
+				m.DomainUsage = make(map[string]DomainResources)
+			}
+			var mapkey string
+			mapvalue := &DomainResources{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthRep
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &DomainResources{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipRep(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthRep
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.DomainUsage[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StackHealth", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StackHealth == nil {
+				m.StackHealth = make(map[string]StackHealth)
+			}
+			var mapkey string
+			mapvalue := &StackHealth{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthRep
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthRep
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &StackHealth{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipRep(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthRep
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.StackHealth[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WarmRootfses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WarmRootfses = append(m.WarmRootfses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WarmCachedDependencies", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WarmCachedDependencies = append(m.WarmCachedDependencies, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachedRootfses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CachedRootfses = append(m.CachedRootfses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Generation", wireType)
+			}
+			m.Generation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Generation |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Work) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Work: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Work: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lrps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lrps = append(m.Lrps, LRP{})
+			if err := m.Lrps[len(m.Lrps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tasks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tasks = append(m.Tasks, Task{})
+			if err := m.Tasks[len(m.Tasks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CellId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReservationId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReservationId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evictions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Evictions = append(m.Evictions, Eviction{})
+			if err := m.Evictions[len(m.Evictions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Resources) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Resources: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Resources: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryMb", wireType)
+			}
+			m.MemoryMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskMb", wireType)
+			}
+			m.DiskMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Containers", wireType)
+			}
+			m.Containers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Containers |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuWeight", wireType)
+			}
+			m.CpuWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuWeight |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPids", wireType)
+			}
+			m.MaxPids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPids |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Resource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Resource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Resource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryMb", wireType)
+			}
+			m.MemoryMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskMb", wireType)
+			}
+			m.DiskMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPids", wireType)
+			}
+			m.MaxPids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPids |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuWeight", wireType)
+			}
+			m.CpuWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CpuWeight |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementConstraint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementConstraint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlacementTags = append(m.PlacementTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeDrivers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeDrivers = append(m.VolumeDrivers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootFs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootFs = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementExpressions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PlacementExpressions = append(m.PlacementExpressions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInstancesPerCell", wireType)
+			}
+			m.MaxInstancesPerCell = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxInstancesPerCell |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlacementFailure) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlacementFailure: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlacementFailure: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LRP) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LRP: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LRP: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ActualLrpKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementConstraint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PlacementConstraint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementFailure", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PlacementFailure == nil {
+				m.PlacementFailure = &PlacementFailure{}
+			}
+			if err := m.PlacementFailure.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Task) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Task: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Task: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementConstraint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PlacementConstraint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Failed = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlacementFailure", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PlacementFailure == nil {
+				m.PlacementFailure = &PlacementFailure{}
+			}
+			if err := m.PlacementFailure.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Eviction) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Eviction: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Eviction: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TaskGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Domain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryMb", wireType)
+			}
+			m.MemoryMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreemptedBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreemptedBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScoringConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScoringConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScoringConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strategy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weights", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Weights == nil {
+				m.Weights = &ScoringWeights{}
+			}
+			if err := m.Weights.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScoringWeights) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScoringWeights: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScoringWeights: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryMb", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MemoryMb = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskMb", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.DiskMb = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Containers", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Containers = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuWeight", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CpuWeight = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPids", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MaxPids = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StackHealth) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StackHealth: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StackHealth: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Healthy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Healthy = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DomainResources) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DomainResources: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DomainResources: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryMb", wireType)
+			}
+			m.MemoryMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskMb", wireType)
+			}
+			m.DiskMb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskMb |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Containers", wireType)
+			}
+			m.Containers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Containers |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRep(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRep
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRep
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRep
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthRep
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowRep
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipRep(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthRep
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthRep = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRep   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,186 @@
+package rep_test
+
+import (
+	"encoding/json"
+	"io"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Protobuf", func() {
+	var (
+		lrp  rep.LRP
+		task rep.Task
+	)
+
+	BeforeEach(func() {
+		lrp = rep.NewLRP(
+			"ig-1",
+			models.NewActualLRPKey("pg-1", 2, "domain"),
+			rep.NewResource(128, 256, 1024),
+			rep.NewPlacementConstraint("preloaded:cflinuxfs3", []string{"tag"}, []string{"driver"}),
+		)
+		lrp.State = models.ActualLRPStateRunning
+		lrp.Priority = 3
+		lrp.CPUWeight = 50
+		lrp.PlacementExpressions = []string{"zone == z1"}
+		lrp.MaxInstancesPerCell = 2
+
+		task = rep.NewTask("task-1", "domain", rep.NewResource(64, 128, 512), rep.NewPlacementConstraint("docker:///busybox", nil, nil))
+		task.State = models.Task_Running
+		task.Failed = true
+		task.Priority = -1
+		task.PlacementFailure = rep.NewPlacementFailure(rep.PlacementFailureReasonInsufficientResources, "insufficient resources: memory")
+	})
+
+	Describe("CellState", func() {
+		var state rep.CellState
+
+		BeforeEach(func() {
+			state = rep.CellState{
+				RepURL:    "https://cell-1.service.cf.internal:1801",
+				CellID:    "cell-1",
+				CellIndex: 1,
+				RootFSProviders: rep.RootFSProviders{
+					"docker":    rep.ArbitraryRootFSProvider{},
+					"preloaded": rep.NewFixedSetRootFSProvider("cflinuxfs3"),
+				},
+				AvailableResources:      rep.Resources{MemoryMB: 1024, DiskMB: 2048, Containers: 10, CPUWeight: 100, MaxPids: -1},
+				TotalResources:          rep.NewResources(2048, 4096, 20),
+				LRPs:                    []rep.LRP{lrp},
+				Tasks:                   []rep.Task{task},
+				StartingContainerCount:  3,
+				Zone:                    "z1",
+				Evacuating:              true,
+				VolumeDrivers:           []string{"driver"},
+				PlacementTags:           []string{"tag"},
+				OptionalPlacementTags:   []string{"optional-tag"},
+				ProxyMemoryAllocationMB: 32,
+				Scoring: rep.ScoringConfig{
+					Strategy: "packed",
+					Weights:  &rep.ScoringWeights{MemoryMB: 0.5, DiskMB: 0.25, Containers: 1},
+				},
+				PlacementLabels:        map[string]string{"rack": "r1", "empty": ""},
+				DomainQuotas:           rep.DomainQuotas{"domain": {MemoryMB: 4096, Containers: 8}},
+				DomainUsage:            map[string]rep.DomainUsage{"domain": {MemoryMB: 192, DiskMB: 384, Containers: 2}},
+				StackHealth:            map[string]rep.StackHealth{"cflinuxfs3": {Path: "/var/cflinuxfs3", Healthy: false, Error: "missing"}},
+				WarmRootFSes:           []string{"docker:///busybox"},
+				WarmCachedDependencies: []string{"buildpack"},
+				CachedRootFSes:         []string{"docker:///busybox", "docker:///alpine"},
+				Generation:             42,
+			}
+		})
+
+		It("round-trips every field", func() {
+			payload, err := state.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded rep.CellState
+			Expect(decoded.UnmarshalProtobuf(payload)).To(Succeed())
+			Expect(decoded).To(Equal(state))
+		})
+
+		It("is smaller than the JSON encoding", func() {
+			payload, err := state.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+			jsonPayload, err := json.Marshal(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(payload)).To(BeNumerically("<", len(jsonPayload)))
+		})
+
+		It("decodes an empty message as the zero state", func() {
+			decoded := state
+			Expect(decoded.UnmarshalProtobuf(nil)).To(Succeed())
+			Expect(decoded).To(Equal(rep.CellState{}))
+		})
+
+		It("skips unknown fields", func() {
+			payload, err := state.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+
+			unknown := []byte{
+				0xc0, 0x3e, 0x07, // field 1000, varint
+				0xca, 0x3e, 0x02, 'h', 'i', // field 1001, bytes
+				0xd9, 0x3e, 0, 0, 0, 0, 0, 0, 0, 0, // field 1003, fixed64
+				0xe5, 0x3e, 0, 0, 0, 0, // field 1004, fixed32
+			}
+
+			var decoded rep.CellState
+			Expect(decoded.UnmarshalProtobuf(append(unknown, payload...))).To(Succeed())
+			Expect(decoded).To(Equal(state))
+		})
+
+		It("fails on truncated input", func() {
+			payload, err := state.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded rep.CellState
+			err = decoded.UnmarshalProtobuf(payload[:len(payload)-1])
+			Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+		})
+
+		It("fails when a field has the wrong wire type", func() {
+			var decoded rep.CellState
+			Expect(decoded.UnmarshalProtobuf([]byte{0x0a, 0x01})).NotTo(Succeed())
+			err := decoded.UnmarshalProtobuf([]byte{0x18, 0x01, 0x12, 0x00, 0x08, 0x01})
+			Expect(err).To(MatchError(ContainSubstring("wrong wireType = 0 for field RepUrl")))
+		})
+	})
+
+	Describe("Work", func() {
+		It("round-trips every field", func() {
+			work := rep.Work{
+				LRPs:          []rep.LRP{lrp},
+				Tasks:         []rep.Task{task},
+				CellID:        "cell-1",
+				ReservationID: "reservation-1",
+				Evictions: []rep.Eviction{{
+					ContainerGuid: "container-guid",
+					ProcessGuid:   "pg-2",
+					InstanceGuid:  "ig-2",
+					Index:         1,
+					Domain:        "domain",
+					Priority:      -5,
+					MemoryMB:      256,
+					PreemptedBy:   "ig-1",
+				}},
+			}
+
+			payload, err := work.MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded rep.Work
+			Expect(decoded.UnmarshalProtobuf(payload)).To(Succeed())
+			Expect(decoded).To(Equal(work))
+		})
+
+		It("decodes empty work", func() {
+			payload, err := (&rep.Work{}).MarshalProtobuf()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(BeEmpty())
+
+			var decoded rep.Work
+			Expect(decoded.UnmarshalProtobuf(payload)).To(Succeed())
+			Expect(decoded).To(Equal(rep.Work{}))
+		})
+
+		It("fails on malformed tasks and evictions", func() {
+			var decoded rep.Work
+			Expect(decoded.UnmarshalProtobuf([]byte{0x12, 0x02, 0x0a, 0x05})).To(MatchError(io.ErrUnexpectedEOF))
+			Expect(decoded.UnmarshalProtobuf([]byte{0x2a, 0x02, 0x0a, 0x05})).To(MatchError(io.ErrUnexpectedEOF))
+		})
+	})
+
+	Describe("IsProtobufContentType", func() {
+		It("matches the protobuf media type with or without parameters", func() {
+			Expect(rep.IsProtobufContentType("application/x-protobuf")).To(BeTrue())
+			Expect(rep.IsProtobufContentType("application/x-protobuf; charset=binary")).To(BeTrue())
+			Expect(rep.IsProtobufContentType("application/json")).To(BeFalse())
+			Expect(rep.IsProtobufContentType("")).To(BeFalse())
+		})
+	})
+})
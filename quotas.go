@@ -0,0 +1,74 @@
+package rep
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DomainQuota caps the resources that the containers of a single domain may
+// use on a cell. A zero limit means the resource is not capped.
+type DomainQuota struct {
+	MemoryMB   int32 `json:"memory_mb,omitempty"`
+	DiskMB     int32 `json:"disk_mb,omitempty"`
+	Containers int   `json:"containers,omitempty"`
+}
+
+// DomainQuotas maps a domain to its quota. Domains without an entry are not
+// capped.
+type DomainQuotas map[string]DomainQuota
+
+// DomainUsage is the memory, disk and containers used by a domain on a cell.
+type DomainUsage struct {
+	MemoryMB   int32 `json:"memory_mb"`
+	DiskMB     int32 `json:"disk_mb"`
+	Containers int   `json:"containers"`
+}
+
+func (u *DomainUsage) Add(res *Resource) {
+	u.MemoryMB += res.MemoryMB
+	u.DiskMB += res.DiskMB
+	u.Containers += 1
+}
+
+func (u *DomainUsage) Subtract(res *Resource) {
+	u.MemoryMB -= res.MemoryMB
+	u.DiskMB -= res.DiskMB
+	u.Containers -= 1
+}
+
+// Admit returns a DomainQuotaExceededError if adding a container with the
+// given resource to the usage would exceed the quota.
+func (q DomainQuota) Admit(domain string, usage DomainUsage, res *Resource) error {
+	problems := map[string]struct{}{}
+
+	if q.MemoryMB > 0 && usage.MemoryMB+res.MemoryMB > q.MemoryMB {
+		problems["memory"] = struct{}{}
+	}
+	if q.DiskMB > 0 && usage.DiskMB+res.DiskMB > q.DiskMB {
+		problems["disk"] = struct{}{}
+	}
+	if q.Containers > 0 && usage.Containers+1 > q.Containers {
+		problems["containers"] = struct{}{}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return DomainQuotaExceededError{Domain: domain, Problems: problems}
+}
+
+type DomainQuotaExceededError struct {
+	Domain   string
+	Problems map[string]struct{}
+}
+
+func (e DomainQuotaExceededError) Error() string {
+	keys := []string{}
+	for key, _ := range e.Problems {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("domain quota exceeded for %s: %s", e.Domain, strings.Join(keys, ", "))
+}
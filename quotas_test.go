@@ -0,0 +1,56 @@
+package rep_test
+
+import (
+	"code.cloudfoundry.org/rep"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DomainQuota", func() {
+	var (
+		quota    rep.DomainQuota
+		usage    rep.DomainUsage
+		resource rep.Resource
+	)
+
+	BeforeEach(func() {
+		quota = rep.DomainQuota{MemoryMB: 1024, DiskMB: 2048, Containers: 3}
+		usage = rep.DomainUsage{MemoryMB: 512, DiskMB: 1024, Containers: 2}
+		resource = rep.NewResource(256, 512, 100)
+	})
+
+	Describe("Admit", func() {
+		It("admits resources within the quota", func() {
+			Expect(quota.Admit("domain", usage, &resource)).To(Succeed())
+		})
+
+		It("reports every exceeded limit", func() {
+			usage.Containers = 3
+			resource.MemoryMB = 1024
+
+			err := quota.Admit("domain", usage, &resource)
+			Expect(err).To(MatchError("domain quota exceeded for domain: containers, memory"))
+		})
+
+		It("ignores limits that are zero", func() {
+			quota = rep.DomainQuota{DiskMB: 2048}
+			resource.MemoryMB = 100000
+
+			Expect(quota.Admit("domain", usage, &resource)).To(Succeed())
+		})
+	})
+
+	Describe("DomainUsage.Add", func() {
+		It("adds the resource and a container", func() {
+			usage.Add(&resource)
+			Expect(usage).To(Equal(rep.DomainUsage{MemoryMB: 768, DiskMB: 1536, Containers: 3}))
+		})
+	})
+
+	Describe("DomainUsage.Subtract", func() {
+		It("subtracts the resource and a container", func() {
+			usage.Subtract(&resource)
+			Expect(usage).To(Equal(rep.DomainUsage{MemoryMB: 256, DiskMB: 512, Containers: 1}))
+		})
+	})
+})
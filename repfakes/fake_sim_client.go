@@ -2,6 +2,7 @@
 package repfakes
 
 import (
+	"io"
 	"net/http"
 	"sync"
 	"time"
@@ -24,6 +25,65 @@ type FakeSimClient struct {
 	cancelTaskReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CancelTaskWithStatusStub        func(lager.Logger, string, time.Duration) (rep.CancelTaskStatus, error)
+	cancelTaskWithStatusMutex       sync.RWMutex
+	cancelTaskWithStatusArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
+	}
+	cancelTaskWithStatusReturns struct {
+		result1 rep.CancelTaskStatus
+		result2 error
+	}
+	cancelTaskWithStatusReturnsOnCall map[int]struct {
+		result1 rep.CancelTaskStatus
+		result2 error
+	}
+	CancelTasksStub        func(lager.Logger, []string) (map[string]rep.BulkResult, error)
+	cancelTasksMutex       sync.RWMutex
+	cancelTasksArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []string
+	}
+	cancelTasksReturns struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}
+	cancelTasksReturnsOnCall map[int]struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}
+	ContainerDiagnosticsStub        func(lager.Logger, string, int) (io.ReadCloser, error)
+	containerDiagnosticsMutex       sync.RWMutex
+	containerDiagnosticsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}
+	containerDiagnosticsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	containerDiagnosticsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	LRPInstanceDetailStub        func(lager.Logger, string, string) (rep.ContainerDetail, error)
+	lRPInstanceDetailMutex       sync.RWMutex
+	lRPInstanceDetailArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}
+	lRPInstanceDetailReturns struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
+	lRPInstanceDetailReturnsOnCall map[int]struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
 	PerformStub        func(lager.Logger, rep.Work) (rep.Work, error)
 	performMutex       sync.RWMutex
 	performArgsForCall []struct {
@@ -38,6 +98,60 @@ type FakeSimClient struct {
 		result1 rep.Work
 		result2 error
 	}
+	PerformDryRunStub        func(lager.Logger, rep.Work) (rep.Work, error)
+	performDryRunMutex       sync.RWMutex
+	performDryRunArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.Work
+	}
+	performDryRunReturns struct {
+		result1 rep.Work
+		result2 error
+	}
+	performDryRunReturnsOnCall map[int]struct {
+		result1 rep.Work
+		result2 error
+	}
+	PrewarmStub        func(lager.Logger, rep.PrewarmRequest) error
+	prewarmMutex       sync.RWMutex
+	prewarmArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.PrewarmRequest
+	}
+	prewarmReturns struct {
+		result1 error
+	}
+	prewarmReturnsOnCall map[int]struct {
+		result1 error
+	}
+	QueryStateStub        func(lager.Logger, rep.StateQuery) (rep.CellState, error)
+	queryStateMutex       sync.RWMutex
+	queryStateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.StateQuery
+	}
+	queryStateReturns struct {
+		result1 rep.CellState
+		result2 error
+	}
+	queryStateReturnsOnCall map[int]struct {
+		result1 rep.CellState
+		result2 error
+	}
+	ReserveStub        func(lager.Logger, rep.ReservationRequest) (rep.Reservation, error)
+	reserveMutex       sync.RWMutex
+	reserveArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 rep.ReservationRequest
+	}
+	reserveReturns struct {
+		result1 rep.Reservation
+		result2 error
+	}
+	reserveReturnsOnCall map[int]struct {
+		result1 rep.Reservation
+		result2 error
+	}
 	ResetStub        func() error
 	resetMutex       sync.RWMutex
 	resetArgsForCall []struct {
@@ -48,6 +162,19 @@ type FakeSimClient struct {
 	resetReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RestartLRPInstanceStub        func(lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey) error
+	restartLRPInstanceMutex       sync.RWMutex
+	restartLRPInstanceArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 models.ActualLRPKey
+		arg3 models.ActualLRPInstanceKey
+	}
+	restartLRPInstanceReturns struct {
+		result1 error
+	}
+	restartLRPInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetStateClientStub        func(*http.Client)
 	setStateClientMutex       sync.RWMutex
 	setStateClientArgsForCall []struct {
@@ -89,6 +216,61 @@ type FakeSimClient struct {
 	stopLRPInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StopLRPInstanceWithOptionsStub        func(lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey, rep.StopLRPInstanceRequest) error
+	stopLRPInstanceWithOptionsMutex       sync.RWMutex
+	stopLRPInstanceWithOptionsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 models.ActualLRPKey
+		arg3 models.ActualLRPInstanceKey
+		arg4 rep.StopLRPInstanceRequest
+	}
+	stopLRPInstanceWithOptionsReturns struct {
+		result1 error
+	}
+	stopLRPInstanceWithOptionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StopLRPInstancesStub        func(lager.Logger, []rep.LRPInstanceKey) (map[string]rep.BulkResult, error)
+	stopLRPInstancesMutex       sync.RWMutex
+	stopLRPInstancesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []rep.LRPInstanceKey
+	}
+	stopLRPInstancesReturns struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}
+	stopLRPInstancesReturnsOnCall map[int]struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}
+	StreamStateStub        func(lager.Logger) (rep.StateEventSource, error)
+	streamStateMutex       sync.RWMutex
+	streamStateArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	streamStateReturns struct {
+		result1 rep.StateEventSource
+		result2 error
+	}
+	streamStateReturnsOnCall map[int]struct {
+		result1 rep.StateEventSource
+		result2 error
+	}
+	TaskDetailStub        func(lager.Logger, string) (rep.ContainerDetail, error)
+	taskDetailMutex       sync.RWMutex
+	taskDetailArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	taskDetailReturns struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
+	taskDetailReturnsOnCall map[int]struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -155,6 +337,274 @@ func (fake *FakeSimClient) CancelTaskReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSimClient) CancelTaskWithStatus(arg1 lager.Logger, arg2 string, arg3 time.Duration) (rep.CancelTaskStatus, error) {
+	fake.cancelTaskWithStatusMutex.Lock()
+	ret, specificReturn := fake.cancelTaskWithStatusReturnsOnCall[len(fake.cancelTaskWithStatusArgsForCall)]
+	fake.cancelTaskWithStatusArgsForCall = append(fake.cancelTaskWithStatusArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CancelTaskWithStatus", []interface{}{arg1, arg2, arg3})
+	cancelTaskWithStatusStubCopy := fake.CancelTaskWithStatusStub
+	fake.cancelTaskWithStatusMutex.Unlock()
+	if cancelTaskWithStatusStubCopy != nil {
+		return cancelTaskWithStatusStubCopy(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.cancelTaskWithStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) CancelTaskWithStatusCallCount() int {
+	fake.cancelTaskWithStatusMutex.RLock()
+	defer fake.cancelTaskWithStatusMutex.RUnlock()
+	return len(fake.cancelTaskWithStatusArgsForCall)
+}
+
+func (fake *FakeSimClient) CancelTaskWithStatusCalls(stub func(lager.Logger, string, time.Duration) (rep.CancelTaskStatus, error)) {
+	fake.cancelTaskWithStatusMutex.Lock()
+	defer fake.cancelTaskWithStatusMutex.Unlock()
+	fake.CancelTaskWithStatusStub = stub
+}
+
+func (fake *FakeSimClient) CancelTaskWithStatusArgsForCall(i int) (lager.Logger, string, time.Duration) {
+	fake.cancelTaskWithStatusMutex.RLock()
+	defer fake.cancelTaskWithStatusMutex.RUnlock()
+	argsForCall := fake.cancelTaskWithStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSimClient) CancelTaskWithStatusReturns(result1 rep.CancelTaskStatus, result2 error) {
+	fake.cancelTaskWithStatusMutex.Lock()
+	defer fake.cancelTaskWithStatusMutex.Unlock()
+	fake.CancelTaskWithStatusStub = nil
+	fake.cancelTaskWithStatusReturns = struct {
+		result1 rep.CancelTaskStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) CancelTaskWithStatusReturnsOnCall(i int, result1 rep.CancelTaskStatus, result2 error) {
+	fake.cancelTaskWithStatusMutex.Lock()
+	defer fake.cancelTaskWithStatusMutex.Unlock()
+	fake.CancelTaskWithStatusStub = nil
+	if fake.cancelTaskWithStatusReturnsOnCall == nil {
+		fake.cancelTaskWithStatusReturnsOnCall = make(map[int]struct {
+			result1 rep.CancelTaskStatus
+			result2 error
+		})
+	}
+	fake.cancelTaskWithStatusReturnsOnCall[i] = struct {
+		result1 rep.CancelTaskStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) CancelTasks(arg1 lager.Logger, arg2 []string) (map[string]rep.BulkResult, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.cancelTasksMutex.Lock()
+	ret, specificReturn := fake.cancelTasksReturnsOnCall[len(fake.cancelTasksArgsForCall)]
+	fake.cancelTasksArgsForCall = append(fake.cancelTasksArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("CancelTasks", []interface{}{arg1, arg2Copy})
+	cancelTasksStubCopy := fake.CancelTasksStub
+	fake.cancelTasksMutex.Unlock()
+	if cancelTasksStubCopy != nil {
+		return cancelTasksStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.cancelTasksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) CancelTasksCallCount() int {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	return len(fake.cancelTasksArgsForCall)
+}
+
+func (fake *FakeSimClient) CancelTasksCalls(stub func(lager.Logger, []string) (map[string]rep.BulkResult, error)) {
+	fake.cancelTasksMutex.Lock()
+	defer fake.cancelTasksMutex.Unlock()
+	fake.CancelTasksStub = stub
+}
+
+func (fake *FakeSimClient) CancelTasksArgsForCall(i int) (lager.Logger, []string) {
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	argsForCall := fake.cancelTasksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) CancelTasksReturns(result1 map[string]rep.BulkResult, result2 error) {
+	fake.cancelTasksMutex.Lock()
+	defer fake.cancelTasksMutex.Unlock()
+	fake.CancelTasksStub = nil
+	fake.cancelTasksReturns = struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) CancelTasksReturnsOnCall(i int, result1 map[string]rep.BulkResult, result2 error) {
+	fake.cancelTasksMutex.Lock()
+	defer fake.cancelTasksMutex.Unlock()
+	fake.CancelTasksStub = nil
+	if fake.cancelTasksReturnsOnCall == nil {
+		fake.cancelTasksReturnsOnCall = make(map[int]struct {
+			result1 map[string]rep.BulkResult
+			result2 error
+		})
+	}
+	fake.cancelTasksReturnsOnCall[i] = struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) ContainerDiagnostics(arg1 lager.Logger, arg2 string, arg3 int) (io.ReadCloser, error) {
+	fake.containerDiagnosticsMutex.Lock()
+	ret, specificReturn := fake.containerDiagnosticsReturnsOnCall[len(fake.containerDiagnosticsArgsForCall)]
+	fake.containerDiagnosticsArgsForCall = append(fake.containerDiagnosticsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ContainerDiagnostics", []interface{}{arg1, arg2, arg3})
+	containerDiagnosticsStubCopy := fake.ContainerDiagnosticsStub
+	fake.containerDiagnosticsMutex.Unlock()
+	if containerDiagnosticsStubCopy != nil {
+		return containerDiagnosticsStubCopy(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.containerDiagnosticsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) ContainerDiagnosticsCallCount() int {
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
+	return len(fake.containerDiagnosticsArgsForCall)
+}
+
+func (fake *FakeSimClient) ContainerDiagnosticsCalls(stub func(lager.Logger, string, int) (io.ReadCloser, error)) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = stub
+}
+
+func (fake *FakeSimClient) ContainerDiagnosticsArgsForCall(i int) (lager.Logger, string, int) {
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
+	argsForCall := fake.containerDiagnosticsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSimClient) ContainerDiagnosticsReturns(result1 io.ReadCloser, result2 error) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = nil
+	fake.containerDiagnosticsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) ContainerDiagnosticsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.containerDiagnosticsMutex.Lock()
+	defer fake.containerDiagnosticsMutex.Unlock()
+	fake.ContainerDiagnosticsStub = nil
+	if fake.containerDiagnosticsReturnsOnCall == nil {
+		fake.containerDiagnosticsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.containerDiagnosticsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) LRPInstanceDetail(arg1 lager.Logger, arg2 string, arg3 string) (rep.ContainerDetail, error) {
+	fake.lRPInstanceDetailMutex.Lock()
+	ret, specificReturn := fake.lRPInstanceDetailReturnsOnCall[len(fake.lRPInstanceDetailArgsForCall)]
+	fake.lRPInstanceDetailArgsForCall = append(fake.lRPInstanceDetailArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("LRPInstanceDetail", []interface{}{arg1, arg2, arg3})
+	lRPInstanceDetailStubCopy := fake.LRPInstanceDetailStub
+	fake.lRPInstanceDetailMutex.Unlock()
+	if lRPInstanceDetailStubCopy != nil {
+		return lRPInstanceDetailStubCopy(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.lRPInstanceDetailReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) LRPInstanceDetailCallCount() int {
+	fake.lRPInstanceDetailMutex.RLock()
+	defer fake.lRPInstanceDetailMutex.RUnlock()
+	return len(fake.lRPInstanceDetailArgsForCall)
+}
+
+func (fake *FakeSimClient) LRPInstanceDetailCalls(stub func(lager.Logger, string, string) (rep.ContainerDetail, error)) {
+	fake.lRPInstanceDetailMutex.Lock()
+	defer fake.lRPInstanceDetailMutex.Unlock()
+	fake.LRPInstanceDetailStub = stub
+}
+
+func (fake *FakeSimClient) LRPInstanceDetailArgsForCall(i int) (lager.Logger, string, string) {
+	fake.lRPInstanceDetailMutex.RLock()
+	defer fake.lRPInstanceDetailMutex.RUnlock()
+	argsForCall := fake.lRPInstanceDetailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSimClient) LRPInstanceDetailReturns(result1 rep.ContainerDetail, result2 error) {
+	fake.lRPInstanceDetailMutex.Lock()
+	defer fake.lRPInstanceDetailMutex.Unlock()
+	fake.LRPInstanceDetailStub = nil
+	fake.lRPInstanceDetailReturns = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) LRPInstanceDetailReturnsOnCall(i int, result1 rep.ContainerDetail, result2 error) {
+	fake.lRPInstanceDetailMutex.Lock()
+	defer fake.lRPInstanceDetailMutex.Unlock()
+	fake.LRPInstanceDetailStub = nil
+	if fake.lRPInstanceDetailReturnsOnCall == nil {
+		fake.lRPInstanceDetailReturnsOnCall = make(map[int]struct {
+			result1 rep.ContainerDetail
+			result2 error
+		})
+	}
+	fake.lRPInstanceDetailReturnsOnCall[i] = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSimClient) Perform(arg1 lager.Logger, arg2 rep.Work) (rep.Work, error) {
 	fake.performMutex.Lock()
 	ret, specificReturn := fake.performReturnsOnCall[len(fake.performArgsForCall)]
@@ -220,6 +670,263 @@ func (fake *FakeSimClient) PerformReturnsOnCall(i int, result1 rep.Work, result2
 	}{result1, result2}
 }
 
+func (fake *FakeSimClient) PerformDryRun(arg1 lager.Logger, arg2 rep.Work) (rep.Work, error) {
+	fake.performDryRunMutex.Lock()
+	ret, specificReturn := fake.performDryRunReturnsOnCall[len(fake.performDryRunArgsForCall)]
+	fake.performDryRunArgsForCall = append(fake.performDryRunArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.Work
+	}{arg1, arg2})
+	fake.recordInvocation("PerformDryRun", []interface{}{arg1, arg2})
+	performDryRunStubCopy := fake.PerformDryRunStub
+	fake.performDryRunMutex.Unlock()
+	if performDryRunStubCopy != nil {
+		return performDryRunStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.performDryRunReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) PerformDryRunCallCount() int {
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	return len(fake.performDryRunArgsForCall)
+}
+
+func (fake *FakeSimClient) PerformDryRunCalls(stub func(lager.Logger, rep.Work) (rep.Work, error)) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = stub
+}
+
+func (fake *FakeSimClient) PerformDryRunArgsForCall(i int) (lager.Logger, rep.Work) {
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	argsForCall := fake.performDryRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) PerformDryRunReturns(result1 rep.Work, result2 error) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = nil
+	fake.performDryRunReturns = struct {
+		result1 rep.Work
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) PerformDryRunReturnsOnCall(i int, result1 rep.Work, result2 error) {
+	fake.performDryRunMutex.Lock()
+	defer fake.performDryRunMutex.Unlock()
+	fake.PerformDryRunStub = nil
+	if fake.performDryRunReturnsOnCall == nil {
+		fake.performDryRunReturnsOnCall = make(map[int]struct {
+			result1 rep.Work
+			result2 error
+		})
+	}
+	fake.performDryRunReturnsOnCall[i] = struct {
+		result1 rep.Work
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) Prewarm(arg1 lager.Logger, arg2 rep.PrewarmRequest) error {
+	fake.prewarmMutex.Lock()
+	ret, specificReturn := fake.prewarmReturnsOnCall[len(fake.prewarmArgsForCall)]
+	fake.prewarmArgsForCall = append(fake.prewarmArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.PrewarmRequest
+	}{arg1, arg2})
+	fake.recordInvocation("Prewarm", []interface{}{arg1, arg2})
+	prewarmStubCopy := fake.PrewarmStub
+	fake.prewarmMutex.Unlock()
+	if prewarmStubCopy != nil {
+		return prewarmStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.prewarmReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSimClient) PrewarmCallCount() int {
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	return len(fake.prewarmArgsForCall)
+}
+
+func (fake *FakeSimClient) PrewarmCalls(stub func(lager.Logger, rep.PrewarmRequest) error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = stub
+}
+
+func (fake *FakeSimClient) PrewarmArgsForCall(i int) (lager.Logger, rep.PrewarmRequest) {
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	argsForCall := fake.prewarmArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) PrewarmReturns(result1 error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = nil
+	fake.prewarmReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimClient) PrewarmReturnsOnCall(i int, result1 error) {
+	fake.prewarmMutex.Lock()
+	defer fake.prewarmMutex.Unlock()
+	fake.PrewarmStub = nil
+	if fake.prewarmReturnsOnCall == nil {
+		fake.prewarmReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.prewarmReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimClient) QueryState(arg1 lager.Logger, arg2 rep.StateQuery) (rep.CellState, error) {
+	fake.queryStateMutex.Lock()
+	ret, specificReturn := fake.queryStateReturnsOnCall[len(fake.queryStateArgsForCall)]
+	fake.queryStateArgsForCall = append(fake.queryStateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.StateQuery
+	}{arg1, arg2})
+	fake.recordInvocation("QueryState", []interface{}{arg1, arg2})
+	queryStateStubCopy := fake.QueryStateStub
+	fake.queryStateMutex.Unlock()
+	if queryStateStubCopy != nil {
+		return queryStateStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryStateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) QueryStateCallCount() int {
+	fake.queryStateMutex.RLock()
+	defer fake.queryStateMutex.RUnlock()
+	return len(fake.queryStateArgsForCall)
+}
+
+func (fake *FakeSimClient) QueryStateCalls(stub func(lager.Logger, rep.StateQuery) (rep.CellState, error)) {
+	fake.queryStateMutex.Lock()
+	defer fake.queryStateMutex.Unlock()
+	fake.QueryStateStub = stub
+}
+
+func (fake *FakeSimClient) QueryStateArgsForCall(i int) (lager.Logger, rep.StateQuery) {
+	fake.queryStateMutex.RLock()
+	defer fake.queryStateMutex.RUnlock()
+	argsForCall := fake.queryStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) QueryStateReturns(result1 rep.CellState, result2 error) {
+	fake.queryStateMutex.Lock()
+	defer fake.queryStateMutex.Unlock()
+	fake.QueryStateStub = nil
+	fake.queryStateReturns = struct {
+		result1 rep.CellState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) QueryStateReturnsOnCall(i int, result1 rep.CellState, result2 error) {
+	fake.queryStateMutex.Lock()
+	defer fake.queryStateMutex.Unlock()
+	fake.QueryStateStub = nil
+	if fake.queryStateReturnsOnCall == nil {
+		fake.queryStateReturnsOnCall = make(map[int]struct {
+			result1 rep.CellState
+			result2 error
+		})
+	}
+	fake.queryStateReturnsOnCall[i] = struct {
+		result1 rep.CellState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) Reserve(arg1 lager.Logger, arg2 rep.ReservationRequest) (rep.Reservation, error) {
+	fake.reserveMutex.Lock()
+	ret, specificReturn := fake.reserveReturnsOnCall[len(fake.reserveArgsForCall)]
+	fake.reserveArgsForCall = append(fake.reserveArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 rep.ReservationRequest
+	}{arg1, arg2})
+	fake.recordInvocation("Reserve", []interface{}{arg1, arg2})
+	reserveStubCopy := fake.ReserveStub
+	fake.reserveMutex.Unlock()
+	if reserveStubCopy != nil {
+		return reserveStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reserveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) ReserveCallCount() int {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	return len(fake.reserveArgsForCall)
+}
+
+func (fake *FakeSimClient) ReserveCalls(stub func(lager.Logger, rep.ReservationRequest) (rep.Reservation, error)) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = stub
+}
+
+func (fake *FakeSimClient) ReserveArgsForCall(i int) (lager.Logger, rep.ReservationRequest) {
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
+	argsForCall := fake.reserveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) ReserveReturns(result1 rep.Reservation, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	fake.reserveReturns = struct {
+		result1 rep.Reservation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) ReserveReturnsOnCall(i int, result1 rep.Reservation, result2 error) {
+	fake.reserveMutex.Lock()
+	defer fake.reserveMutex.Unlock()
+	fake.ReserveStub = nil
+	if fake.reserveReturnsOnCall == nil {
+		fake.reserveReturnsOnCall = make(map[int]struct {
+			result1 rep.Reservation
+			result2 error
+		})
+	}
+	fake.reserveReturnsOnCall[i] = struct {
+		result1 rep.Reservation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSimClient) Reset() error {
 	fake.resetMutex.Lock()
 	ret, specificReturn := fake.resetReturnsOnCall[len(fake.resetArgsForCall)]
@@ -273,6 +980,69 @@ func (fake *FakeSimClient) ResetReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSimClient) RestartLRPInstance(arg1 lager.Logger, arg2 models.ActualLRPKey, arg3 models.ActualLRPInstanceKey) error {
+	fake.restartLRPInstanceMutex.Lock()
+	ret, specificReturn := fake.restartLRPInstanceReturnsOnCall[len(fake.restartLRPInstanceArgsForCall)]
+	fake.restartLRPInstanceArgsForCall = append(fake.restartLRPInstanceArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 models.ActualLRPKey
+		arg3 models.ActualLRPInstanceKey
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RestartLRPInstance", []interface{}{arg1, arg2, arg3})
+	restartLRPInstanceStubCopy := fake.RestartLRPInstanceStub
+	fake.restartLRPInstanceMutex.Unlock()
+	if restartLRPInstanceStubCopy != nil {
+		return restartLRPInstanceStubCopy(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.restartLRPInstanceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSimClient) RestartLRPInstanceCallCount() int {
+	fake.restartLRPInstanceMutex.RLock()
+	defer fake.restartLRPInstanceMutex.RUnlock()
+	return len(fake.restartLRPInstanceArgsForCall)
+}
+
+func (fake *FakeSimClient) RestartLRPInstanceCalls(stub func(lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey) error) {
+	fake.restartLRPInstanceMutex.Lock()
+	defer fake.restartLRPInstanceMutex.Unlock()
+	fake.RestartLRPInstanceStub = stub
+}
+
+func (fake *FakeSimClient) RestartLRPInstanceArgsForCall(i int) (lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey) {
+	fake.restartLRPInstanceMutex.RLock()
+	defer fake.restartLRPInstanceMutex.RUnlock()
+	argsForCall := fake.restartLRPInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSimClient) RestartLRPInstanceReturns(result1 error) {
+	fake.restartLRPInstanceMutex.Lock()
+	defer fake.restartLRPInstanceMutex.Unlock()
+	fake.RestartLRPInstanceStub = nil
+	fake.restartLRPInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimClient) RestartLRPInstanceReturnsOnCall(i int, result1 error) {
+	fake.restartLRPInstanceMutex.Lock()
+	defer fake.restartLRPInstanceMutex.Unlock()
+	fake.RestartLRPInstanceStub = nil
+	if fake.restartLRPInstanceReturnsOnCall == nil {
+		fake.restartLRPInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restartLRPInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSimClient) SetStateClient(arg1 *http.Client) {
 	fake.setStateClientMutex.Lock()
 	fake.setStateClientArgsForCall = append(fake.setStateClientArgsForCall, struct {
@@ -485,15 +1255,296 @@ func (fake *FakeSimClient) StopLRPInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSimClient) StopLRPInstanceWithOptions(arg1 lager.Logger, arg2 models.ActualLRPKey, arg3 models.ActualLRPInstanceKey, arg4 rep.StopLRPInstanceRequest) error {
+	fake.stopLRPInstanceWithOptionsMutex.Lock()
+	ret, specificReturn := fake.stopLRPInstanceWithOptionsReturnsOnCall[len(fake.stopLRPInstanceWithOptionsArgsForCall)]
+	fake.stopLRPInstanceWithOptionsArgsForCall = append(fake.stopLRPInstanceWithOptionsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 models.ActualLRPKey
+		arg3 models.ActualLRPInstanceKey
+		arg4 rep.StopLRPInstanceRequest
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("StopLRPInstanceWithOptions", []interface{}{arg1, arg2, arg3, arg4})
+	stopLRPInstanceWithOptionsStubCopy := fake.StopLRPInstanceWithOptionsStub
+	fake.stopLRPInstanceWithOptionsMutex.Unlock()
+	if stopLRPInstanceWithOptionsStubCopy != nil {
+		return stopLRPInstanceWithOptionsStubCopy(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.stopLRPInstanceWithOptionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeSimClient) StopLRPInstanceWithOptionsCallCount() int {
+	fake.stopLRPInstanceWithOptionsMutex.RLock()
+	defer fake.stopLRPInstanceWithOptionsMutex.RUnlock()
+	return len(fake.stopLRPInstanceWithOptionsArgsForCall)
+}
+
+func (fake *FakeSimClient) StopLRPInstanceWithOptionsCalls(stub func(lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey, rep.StopLRPInstanceRequest) error) {
+	fake.stopLRPInstanceWithOptionsMutex.Lock()
+	defer fake.stopLRPInstanceWithOptionsMutex.Unlock()
+	fake.StopLRPInstanceWithOptionsStub = stub
+}
+
+func (fake *FakeSimClient) StopLRPInstanceWithOptionsArgsForCall(i int) (lager.Logger, models.ActualLRPKey, models.ActualLRPInstanceKey, rep.StopLRPInstanceRequest) {
+	fake.stopLRPInstanceWithOptionsMutex.RLock()
+	defer fake.stopLRPInstanceWithOptionsMutex.RUnlock()
+	argsForCall := fake.stopLRPInstanceWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeSimClient) StopLRPInstanceWithOptionsReturns(result1 error) {
+	fake.stopLRPInstanceWithOptionsMutex.Lock()
+	defer fake.stopLRPInstanceWithOptionsMutex.Unlock()
+	fake.StopLRPInstanceWithOptionsStub = nil
+	fake.stopLRPInstanceWithOptionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimClient) StopLRPInstanceWithOptionsReturnsOnCall(i int, result1 error) {
+	fake.stopLRPInstanceWithOptionsMutex.Lock()
+	defer fake.stopLRPInstanceWithOptionsMutex.Unlock()
+	fake.StopLRPInstanceWithOptionsStub = nil
+	if fake.stopLRPInstanceWithOptionsReturnsOnCall == nil {
+		fake.stopLRPInstanceWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.stopLRPInstanceWithOptionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSimClient) StopLRPInstances(arg1 lager.Logger, arg2 []rep.LRPInstanceKey) (map[string]rep.BulkResult, error) {
+	var arg2Copy []rep.LRPInstanceKey
+	if arg2 != nil {
+		arg2Copy = make([]rep.LRPInstanceKey, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.stopLRPInstancesMutex.Lock()
+	ret, specificReturn := fake.stopLRPInstancesReturnsOnCall[len(fake.stopLRPInstancesArgsForCall)]
+	fake.stopLRPInstancesArgsForCall = append(fake.stopLRPInstancesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []rep.LRPInstanceKey
+	}{arg1, arg2Copy})
+	fake.recordInvocation("StopLRPInstances", []interface{}{arg1, arg2Copy})
+	stopLRPInstancesStubCopy := fake.StopLRPInstancesStub
+	fake.stopLRPInstancesMutex.Unlock()
+	if stopLRPInstancesStubCopy != nil {
+		return stopLRPInstancesStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.stopLRPInstancesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) StopLRPInstancesCallCount() int {
+	fake.stopLRPInstancesMutex.RLock()
+	defer fake.stopLRPInstancesMutex.RUnlock()
+	return len(fake.stopLRPInstancesArgsForCall)
+}
+
+func (fake *FakeSimClient) StopLRPInstancesCalls(stub func(lager.Logger, []rep.LRPInstanceKey) (map[string]rep.BulkResult, error)) {
+	fake.stopLRPInstancesMutex.Lock()
+	defer fake.stopLRPInstancesMutex.Unlock()
+	fake.StopLRPInstancesStub = stub
+}
+
+func (fake *FakeSimClient) StopLRPInstancesArgsForCall(i int) (lager.Logger, []rep.LRPInstanceKey) {
+	fake.stopLRPInstancesMutex.RLock()
+	defer fake.stopLRPInstancesMutex.RUnlock()
+	argsForCall := fake.stopLRPInstancesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) StopLRPInstancesReturns(result1 map[string]rep.BulkResult, result2 error) {
+	fake.stopLRPInstancesMutex.Lock()
+	defer fake.stopLRPInstancesMutex.Unlock()
+	fake.StopLRPInstancesStub = nil
+	fake.stopLRPInstancesReturns = struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) StopLRPInstancesReturnsOnCall(i int, result1 map[string]rep.BulkResult, result2 error) {
+	fake.stopLRPInstancesMutex.Lock()
+	defer fake.stopLRPInstancesMutex.Unlock()
+	fake.StopLRPInstancesStub = nil
+	if fake.stopLRPInstancesReturnsOnCall == nil {
+		fake.stopLRPInstancesReturnsOnCall = make(map[int]struct {
+			result1 map[string]rep.BulkResult
+			result2 error
+		})
+	}
+	fake.stopLRPInstancesReturnsOnCall[i] = struct {
+		result1 map[string]rep.BulkResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) StreamState(arg1 lager.Logger) (rep.StateEventSource, error) {
+	fake.streamStateMutex.Lock()
+	ret, specificReturn := fake.streamStateReturnsOnCall[len(fake.streamStateArgsForCall)]
+	fake.streamStateArgsForCall = append(fake.streamStateArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("StreamState", []interface{}{arg1})
+	streamStateStubCopy := fake.StreamStateStub
+	fake.streamStateMutex.Unlock()
+	if streamStateStubCopy != nil {
+		return streamStateStubCopy(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.streamStateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) StreamStateCallCount() int {
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
+	return len(fake.streamStateArgsForCall)
+}
+
+func (fake *FakeSimClient) StreamStateCalls(stub func(lager.Logger) (rep.StateEventSource, error)) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = stub
+}
+
+func (fake *FakeSimClient) StreamStateArgsForCall(i int) lager.Logger {
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
+	argsForCall := fake.streamStateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSimClient) StreamStateReturns(result1 rep.StateEventSource, result2 error) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = nil
+	fake.streamStateReturns = struct {
+		result1 rep.StateEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) StreamStateReturnsOnCall(i int, result1 rep.StateEventSource, result2 error) {
+	fake.streamStateMutex.Lock()
+	defer fake.streamStateMutex.Unlock()
+	fake.StreamStateStub = nil
+	if fake.streamStateReturnsOnCall == nil {
+		fake.streamStateReturnsOnCall = make(map[int]struct {
+			result1 rep.StateEventSource
+			result2 error
+		})
+	}
+	fake.streamStateReturnsOnCall[i] = struct {
+		result1 rep.StateEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) TaskDetail(arg1 lager.Logger, arg2 string) (rep.ContainerDetail, error) {
+	fake.taskDetailMutex.Lock()
+	ret, specificReturn := fake.taskDetailReturnsOnCall[len(fake.taskDetailArgsForCall)]
+	fake.taskDetailArgsForCall = append(fake.taskDetailArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("TaskDetail", []interface{}{arg1, arg2})
+	taskDetailStubCopy := fake.TaskDetailStub
+	fake.taskDetailMutex.Unlock()
+	if taskDetailStubCopy != nil {
+		return taskDetailStubCopy(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.taskDetailReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSimClient) TaskDetailCallCount() int {
+	fake.taskDetailMutex.RLock()
+	defer fake.taskDetailMutex.RUnlock()
+	return len(fake.taskDetailArgsForCall)
+}
+
+func (fake *FakeSimClient) TaskDetailCalls(stub func(lager.Logger, string) (rep.ContainerDetail, error)) {
+	fake.taskDetailMutex.Lock()
+	defer fake.taskDetailMutex.Unlock()
+	fake.TaskDetailStub = stub
+}
+
+func (fake *FakeSimClient) TaskDetailArgsForCall(i int) (lager.Logger, string) {
+	fake.taskDetailMutex.RLock()
+	defer fake.taskDetailMutex.RUnlock()
+	argsForCall := fake.taskDetailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSimClient) TaskDetailReturns(result1 rep.ContainerDetail, result2 error) {
+	fake.taskDetailMutex.Lock()
+	defer fake.taskDetailMutex.Unlock()
+	fake.TaskDetailStub = nil
+	fake.taskDetailReturns = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSimClient) TaskDetailReturnsOnCall(i int, result1 rep.ContainerDetail, result2 error) {
+	fake.taskDetailMutex.Lock()
+	defer fake.taskDetailMutex.Unlock()
+	fake.TaskDetailStub = nil
+	if fake.taskDetailReturnsOnCall == nil {
+		fake.taskDetailReturnsOnCall = make(map[int]struct {
+			result1 rep.ContainerDetail
+			result2 error
+		})
+	}
+	fake.taskDetailReturnsOnCall[i] = struct {
+		result1 rep.ContainerDetail
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSimClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.cancelTaskMutex.RLock()
 	defer fake.cancelTaskMutex.RUnlock()
+	fake.cancelTaskWithStatusMutex.RLock()
+	defer fake.cancelTaskWithStatusMutex.RUnlock()
+	fake.cancelTasksMutex.RLock()
+	defer fake.cancelTasksMutex.RUnlock()
+	fake.containerDiagnosticsMutex.RLock()
+	defer fake.containerDiagnosticsMutex.RUnlock()
+	fake.lRPInstanceDetailMutex.RLock()
+	defer fake.lRPInstanceDetailMutex.RUnlock()
 	fake.performMutex.RLock()
 	defer fake.performMutex.RUnlock()
+	fake.performDryRunMutex.RLock()
+	defer fake.performDryRunMutex.RUnlock()
+	fake.prewarmMutex.RLock()
+	defer fake.prewarmMutex.RUnlock()
+	fake.queryStateMutex.RLock()
+	defer fake.queryStateMutex.RUnlock()
+	fake.reserveMutex.RLock()
+	defer fake.reserveMutex.RUnlock()
 	fake.resetMutex.RLock()
 	defer fake.resetMutex.RUnlock()
+	fake.restartLRPInstanceMutex.RLock()
+	defer fake.restartLRPInstanceMutex.RUnlock()
 	fake.setStateClientMutex.RLock()
 	defer fake.setStateClientMutex.RUnlock()
 	fake.stateMutex.RLock()
@@ -502,6 +1553,14 @@ func (fake *FakeSimClient) Invocations() map[string][][]interface{} {
 	defer fake.stateClientTimeoutMutex.RUnlock()
 	fake.stopLRPInstanceMutex.RLock()
 	defer fake.stopLRPInstanceMutex.RUnlock()
+	fake.stopLRPInstanceWithOptionsMutex.RLock()
+	defer fake.stopLRPInstanceWithOptionsMutex.RUnlock()
+	fake.stopLRPInstancesMutex.RLock()
+	defer fake.stopLRPInstancesMutex.RUnlock()
+	fake.streamStateMutex.RLock()
+	defer fake.streamStateMutex.RUnlock()
+	fake.taskDetailMutex.RLock()
+	defer fake.taskDetailMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
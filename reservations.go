@@ -0,0 +1,27 @@
+package rep
+
+import "errors"
+
+// ErrReservationRejected is returned by Client.Reserve when the cell cannot
+// hold the requested capacity, for instance because it is evacuating or does
+// not have enough free resources.
+var ErrReservationRejected = errors.New("cell rejected the reservation")
+
+// ReservationRequest asks a cell to hold memory, disk and container slots
+// for a short time ahead of a Perform.
+type ReservationRequest struct {
+	CellID     string `json:"cell_id,omitempty"`
+	MemoryMB   int32  `json:"memory_mb"`
+	DiskMB     int32  `json:"disk_mb"`
+	Containers int    `json:"containers"`
+}
+
+// Reservation is resource capacity held by a cell until it is consumed by a
+// Perform naming its ID or until ExpiresAt (unix nanoseconds) passes.
+type Reservation struct {
+	ID         string `json:"id"`
+	MemoryMB   int32  `json:"memory_mb"`
+	DiskMB     int32  `json:"disk_mb"`
+	Containers int    `json:"containers"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
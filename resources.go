@@ -29,6 +29,20 @@ type CellState struct {
 	PlacementTags           []string
 	OptionalPlacementTags   []string
 	ProxyMemoryAllocationMB int
+	Scoring                 ScoringConfig
+	PlacementLabels         map[string]string      `json:"placement_labels,omitempty"`
+	DomainQuotas            DomainQuotas           `json:"domain_quotas,omitempty"`
+	DomainUsage             map[string]DomainUsage `json:"domain_usage,omitempty"`
+	StackHealth             map[string]StackHealth `json:"stack_health,omitempty"`
+	WarmRootFSes            []string               `json:"warm_rootfses,omitempty"`
+	WarmCachedDependencies  []string               `json:"warm_cached_dependencies,omitempty"`
+	CachedRootFSes          []string               `json:"cached_rootfses,omitempty"`
+	Generation              uint64                 `json:"generation,omitempty"`
+}
+
+// StateETag returns the entity tag of a state with the given generation.
+func StateETag(generation uint64) string {
+	return fmt.Sprintf(`"%d"`, generation)
 }
 
 func NewCellState(
@@ -91,6 +105,12 @@ func (c *CellState) ResourceMatch(res *Resource) error {
 	if c.AvailableResources.Containers < 1 {
 		problems["containers"] = struct{}{}
 	}
+	if c.TotalResources.CPUWeight > 0 && c.AvailableResources.CPUWeight < res.CPUWeight {
+		problems["cpu"] = struct{}{}
+	}
+	if c.TotalResources.MaxPids > 0 && c.AvailableResources.MaxPids < res.MaxPids {
+		problems["pids"] = struct{}{}
+	}
 	if len(problems) == 0 {
 		return nil
 	}
@@ -115,11 +135,43 @@ func (i InsufficientResourcesError) Error() string {
 	return fmt.Sprintf("insufficient resources: %s", strings.Join(keys, ", "))
 }
 
-func (c CellState) ComputeScore(res *Resource, startingContainerWeight float64) float64 {
+// ComputeScore scores placing the resource on the cell. Any locality bonus
+// whose rootfs is cached on the cell is subtracted from the score.
+func (c CellState) ComputeScore(res *Resource, startingContainerWeight float64, locality ...LocalityBonus) float64 {
 	remainingResources := c.AvailableResources.Copy()
 	remainingResources.Subtract(res)
 	startingContainerScore := float64(c.StartingContainerCount) * startingContainerWeight
-	return remainingResources.ComputeScore(&c.TotalResources) + startingContainerScore
+	score := c.ScoringStrategy().Score(&remainingResources, &c.TotalResources) + startingContainerScore
+	for _, bonus := range locality {
+		if c.HasCachedRootFS(bonus.RootFS) {
+			score -= bonus.Bonus
+		}
+	}
+	return score
+}
+
+// HasCachedRootFS returns true if the rootfs image is cached on the cell.
+func (c CellState) HasCachedRootFS(rootFS string) bool {
+	if rootFS == "" {
+		return false
+	}
+	for _, cached := range c.CachedRootFSes {
+		if cached == rootFS {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoringStrategy returns the strategy advertised by the cell. Cells that
+// advertise no strategy, or one this package does not know, are scored with
+// the balanced strategy.
+func (c CellState) ScoringStrategy() ScoringStrategy {
+	strategy, err := NewScoringStrategy(c.Scoring)
+	if err != nil {
+		return BalancedScoringStrategy{Weights: DefaultScoringWeights()}
+	}
+	return strategy
 }
 
 func (c *CellState) MatchRootFS(rootfs string) bool {
@@ -150,13 +202,46 @@ func (c *CellState) MatchVolumeDrivers(volumeDrivers []string) bool {
 	return true
 }
 
+// InstanceCount returns the number of instances of the process on the cell.
+func (c *CellState) InstanceCount(processGuid string) int {
+	count := 0
+	for i := range c.LRPs {
+		if c.LRPs[i].ProcessGuid == processGuid {
+			count++
+		}
+	}
+	return count
+}
+
+// MatchMaxInstancesPerCell returns false if placing the LRP would exceed the
+// maximum number of instances of its process allowed on a single cell.
+func (c *CellState) MatchMaxInstancesPerCell(lrp *LRP) bool {
+	if lrp.MaxInstancesPerCell <= 0 {
+		return true
+	}
+	return c.InstanceCount(lrp.ProcessGuid) < int(lrp.MaxInstancesPerCell)
+}
+
+// MatchPlacementTags is the flat tag special case of placement matching: the
+// cell's required tags must all be desired, and each desired tag must exist
+// among the cell's required and optional tags.
 func (c *CellState) MatchPlacementTags(desiredPlacementTags []string) bool {
 	desiredTags := toSet(desiredPlacementTags)
 	optionalTags := toSet(c.OptionalPlacementTags)
 	requiredTags := toSet(c.PlacementTags)
 	allTags := requiredTags.union(optionalTags)
 
-	return requiredTags.isSubset(desiredTags) && desiredTags.isSubset(allTags)
+	return requiredTags.isSubset(desiredTags) && placementTagMatcher(desiredPlacementTags).Matches(allTags.labels())
+}
+
+// MatchPlacementExpressions returns true if the cell's placement labels satisfy
+// every expression. Invalid expressions never match.
+func (c *CellState) MatchPlacementExpressions(expressions []string) bool {
+	matcher, err := NewPlacementMatcher(expressions)
+	if err != nil {
+		return false
+	}
+	return matcher.Matches(c.PlacementLabels)
 }
 
 type placementTagSet map[string]struct{}
@@ -172,6 +257,14 @@ func (set placementTagSet) union(other placementTagSet) placementTagSet {
 	return tags
 }
 
+func (set placementTagSet) labels() map[string]string {
+	labels := make(map[string]string, len(set))
+	for k := range set {
+		labels[k] = ""
+	}
+	return labels
+}
+
 func (set placementTagSet) isSubset(other placementTagSet) bool {
 	for k := range set {
 		if _, ok := other[k]; !ok {
@@ -193,10 +286,16 @@ type Resources struct {
 	MemoryMB   int32
 	DiskMB     int32
 	Containers int
+	// CPUWeight is the cell's CPU capacity in executor CPU weight units. A
+	// zero total means the cell does not track CPU.
+	CPUWeight int32
+	// MaxPids is the cell's PID budget shared by all containers. A zero
+	// total means the cell does not track PIDs.
+	MaxPids int32
 }
 
 func NewResources(memoryMb, diskMb int32, containerCount int) Resources {
-	return Resources{memoryMb, diskMb, containerCount}
+	return Resources{MemoryMB: memoryMb, DiskMB: diskMb, Containers: containerCount}
 }
 
 func (r *Resources) Copy() Resources {
@@ -207,19 +306,21 @@ func (r *Resources) Subtract(res *Resource) {
 	r.MemoryMB -= res.MemoryMB
 	r.DiskMB -= res.DiskMB
 	r.Containers -= 1
+	r.CPUWeight -= res.CPUWeight
+	r.MaxPids -= res.MaxPids
 }
 
+// ComputeScore scores the resources with the balanced strategy and default
+// weights.
 func (r *Resources) ComputeScore(total *Resources) float64 {
-	fractionUsedMemory := 1.0 - float64(r.MemoryMB)/float64(total.MemoryMB)
-	fractionUsedDisk := 1.0 - float64(r.DiskMB)/float64(total.DiskMB)
-	fractionUsedContainers := 1.0 - float64(r.Containers)/float64(total.Containers)
-	return (fractionUsedMemory + fractionUsedDisk + fractionUsedContainers) / 3.0
+	return BalancedScoringStrategy{Weights: DefaultScoringWeights()}.Score(r, total)
 }
 
 type Resource struct {
-	MemoryMB int32
-	DiskMB   int32
-	MaxPids  int32
+	MemoryMB  int32
+	DiskMB    int32
+	MaxPids   int32
+	CPUWeight int32
 }
 
 func NewResource(memoryMb, diskMb int32, maxPids int32) Resource {
@@ -231,13 +332,23 @@ func (r *Resource) Valid() bool {
 }
 
 func (r *Resource) Copy() Resource {
-	return NewResource(r.MemoryMB, r.DiskMB, r.MaxPids)
+	res := NewResource(r.MemoryMB, r.DiskMB, r.MaxPids)
+	res.CPUWeight = r.CPUWeight
+	return res
 }
 
 type PlacementConstraint struct {
 	PlacementTags []string
 	VolumeDrivers []string
 	RootFs        string
+
+	// PlacementExpressions are evaluated against the placement labels of the
+	// cell, see ParsePlacementExpression for the syntax.
+	PlacementExpressions []string `json:"placement_expressions,omitempty"`
+
+	// MaxInstancesPerCell limits how many instances of an LRP's process may
+	// run on one cell. Zero means no limit. It is ignored for tasks.
+	MaxInstancesPerCell int32 `json:"max_instances_per_cell,omitempty"`
 }
 
 func NewPlacementConstraint(rootFs string, placementTags, volumeDrivers []string) PlacementConstraint {
@@ -253,11 +364,13 @@ type LRP struct {
 	models.ActualLRPKey
 	PlacementConstraint
 	Resource
-	State string `json:"state"`
+	State            string            `json:"state"`
+	Priority         int32             `json:"priority,omitempty"`
+	PlacementFailure *PlacementFailure `json:"placement_failure,omitempty"`
 }
 
 func NewLRP(instanceGUID string, key models.ActualLRPKey, res Resource, pc PlacementConstraint) LRP {
-	return LRP{instanceGUID, key, pc, res, ""}
+	return LRP{instanceGUID, key, pc, res, "", 0, nil}
 }
 
 func (lrp *LRP) Identifier() string {
@@ -265,7 +378,9 @@ func (lrp *LRP) Identifier() string {
 }
 
 func (lrp *LRP) Copy() LRP {
-	return NewLRP(lrp.InstanceGUID, lrp.ActualLRPKey, lrp.Resource, lrp.PlacementConstraint)
+	copied := NewLRP(lrp.InstanceGUID, lrp.ActualLRPKey, lrp.Resource, lrp.PlacementConstraint)
+	copied.Priority = lrp.Priority
+	return copied
 }
 
 type Task struct {
@@ -273,12 +388,14 @@ type Task struct {
 	Domain   string
 	PlacementConstraint
 	Resource
-	State  models.Task_State `json:"state"`
-	Failed bool              `json:"failed"`
+	State            models.Task_State `json:"state"`
+	Failed           bool              `json:"failed"`
+	Priority         int32             `json:"priority,omitempty"`
+	PlacementFailure *PlacementFailure `json:"placement_failure,omitempty"`
 }
 
 func NewTask(guid string, domain string, res Resource, pc PlacementConstraint) Task {
-	return Task{guid, domain, pc, res, models.Task_Invalid, false}
+	return Task{guid, domain, pc, res, models.Task_Invalid, false, 0, nil}
 }
 
 func (task *Task) Identifier() string {
@@ -290,14 +407,101 @@ func (task Task) Copy() Task {
 }
 
 type Work struct {
-	LRPs   []LRP
-	Tasks  []Task
-	CellID string `json:"cell_id,omitempty"`
+	LRPs          []LRP
+	Tasks         []Task
+	CellID        string `json:"cell_id,omitempty"`
+	ReservationID string `json:"reservation_id,omitempty"`
+
+	// Evictions is only set on the work returned by Perform and PerformDryRun.
+	// It lists the lower priority containers stopped, or that would be
+	// stopped, to make room for the given work.
+	Evictions []Eviction `json:"evictions,omitempty"`
+}
+
+// Eviction records a container that was stopped so that higher priority work
+// could be placed on the cell. Its LRP instance or task is handed back to the
+// BBS to be placed elsewhere.
+type Eviction struct {
+	ContainerGuid string `json:"container_guid"`
+	ProcessGuid   string `json:"process_guid,omitempty"`
+	InstanceGuid  string `json:"instance_guid,omitempty"`
+	Index         int32  `json:"index,omitempty"`
+	TaskGuid      string `json:"task_guid,omitempty"`
+	Domain        string `json:"domain"`
+	Priority      int32  `json:"priority"`
+	MemoryMB      int32  `json:"memory_mb"`
+	PreemptedBy   string `json:"preempted_by"`
+}
+
+type PlacementFailureReason string
+
+const (
+	PlacementFailureReasonEvacuating                   PlacementFailureReason = "evacuating"
+	PlacementFailureReasonInsufficientResources        PlacementFailureReason = "insufficient_resources"
+	PlacementFailureReasonRootFSNotFound               PlacementFailureReason = "rootfs_not_found"
+	PlacementFailureReasonInstanceGuidGenerationFailed PlacementFailureReason = "instance_guid_generation_failed"
+	PlacementFailureReasonAllocationFailed             PlacementFailureReason = "allocation_failed"
+	PlacementFailureReasonDomainQuotaExceeded          PlacementFailureReason = "domain_quota_exceeded"
+	PlacementFailureReasonMaxInstancesPerCell          PlacementFailureReason = "max_instances_per_cell"
+)
+
+// PlacementFailure explains why an LRP or Task could not be placed on a cell.
+type PlacementFailure struct {
+	Reason  PlacementFailureReason `json:"reason"`
+	Message string                 `json:"message,omitempty"`
+}
+
+func NewPlacementFailure(reason PlacementFailureReason, message string) *PlacementFailure {
+	return &PlacementFailure{Reason: reason, Message: message}
 }
 
 // StackPathMap maps aliases to rootFS paths on the system.
 type StackPathMap map[string]string
 
+// StackSource provides the current preloaded stacks of the cell. Consumers
+// that hold a StackSource rather than a StackPathMap see stacks that are
+// reloaded while the rep is running.
+type StackSource interface {
+	Stacks() StackPathMap
+}
+
+// Stacks returns the map itself, so that a fixed StackPathMap is a StackSource.
+func (m StackPathMap) Stacks() StackPathMap {
+	return m
+}
+
+// StackHealth reports whether the path of a preloaded stack could be read the
+// last time it was verified. Unhealthy stacks are not advertised.
+type StackHealth struct {
+	Path    string `json:"path"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// StackHealthReporter is implemented by StackSources that verify the paths of
+// their stacks.
+type StackHealthReporter interface {
+	StackHealth() map[string]StackHealth
+}
+
+// StackDiskReporter is implemented by StackSources that provide stacks the
+// executor was not started with. The executor only counts the size of the
+// rootfses it was started with towards the disk limit of a container, so the
+// disk of a container on any other stack is increased by StackDiskMB.
+type StackDiskReporter interface {
+	StackDiskMB(path string) int
+}
+
+// Names returns the sorted stack names.
+func (m StackPathMap) Names() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ErrPreloadedRootFSNotFound is returned when the given hostname of the
 // rootFS could not be resolved if the scheme is the PreloadedRootFSScheme
 // or the PreloadedOCIRootFSScheme. This isn't the error for when the actual
@@ -305,7 +509,9 @@ type StackPathMap map[string]string
 var ErrPreloadedRootFSNotFound = errors.New("preloaded rootfs path not found")
 
 // PathForRootFS resolves the hostname portion of the RootFS URL to the actual
-// path to the preloaded rootFS on the system according to the StackPathMap
+// path to the preloaded rootFS on the system according to the StackPathMap.
+// A version in the stack name, as in preloaded:cflinuxfs4@1.52.0, does not
+// change the path; VerifyStackVersion checks it against the stack's range.
 func (m StackPathMap) PathForRootFS(rootFS string) (string, error) {
 	if rootFS == "" {
 		return rootFS, nil
@@ -316,14 +522,15 @@ func (m StackPathMap) PathForRootFS(rootFS string) (string, error) {
 		return "", err
 	}
 
+	stack, _ := SplitStackVersion(url.Opaque)
 	if url.Scheme == models.PreloadedRootFSScheme {
-		path, ok := m[url.Opaque]
+		path, ok := m[stack]
 		if !ok {
 			return "", ErrPreloadedRootFSNotFound
 		}
 		return path, nil
 	} else if url.Scheme == models.PreloadedOCIRootFSScheme {
-		path, ok := m[url.Opaque]
+		path, ok := m[stack]
 		if !ok {
 			return "", ErrPreloadedRootFSNotFound
 		}
@@ -334,6 +541,36 @@ func (m StackPathMap) PathForRootFS(rootFS string) (string, error) {
 	return rootFS, nil
 }
 
+// ErrPreloadedRootFSVersionUnsupported is returned by VerifyStackVersion when
+// a preloaded rootFS names a version outside the range of its stack.
+var ErrPreloadedRootFSVersionUnsupported = errors.New("preloaded rootfs version not supported")
+
+// VerifyStackVersion checks the version in the stack name of a preloaded
+// rootFS, as in preloaded:cflinuxfs4@1.52.0, against the stack's range in
+// versionRanges. A rootFS without a version, or a stack without a range,
+// is not checked.
+func VerifyStackVersion(rootFS string, versionRanges map[string]string) error {
+	rootFSURL, err := url.Parse(rootFS)
+	if err != nil {
+		return err
+	}
+	if rootFSURL.Scheme != models.PreloadedRootFSScheme && rootFSURL.Scheme != models.PreloadedOCIRootFSScheme {
+		return nil
+	}
+
+	stack, version := SplitStackVersion(rootFSURL.Opaque)
+	versionRange, ok := versionRanges[stack]
+	if version == "" || !ok {
+		return nil
+	}
+
+	provider := VersionedSetRootFSProvider{VersionedSet: map[string]string{stack: versionRange}}
+	if !provider.Match(url.URL{Opaque: rootFSURL.Opaque}) {
+		return ErrPreloadedRootFSVersionUnsupported
+	}
+	return nil
+}
+
 //go:generate counterfeiter -o auctioncellrep/auctioncellrepfakes/fake_container_metrics_provider.go . ContainerMetricsProvider
 type ContainerMetricsProvider interface {
 	Metrics() map[string]*containermetrics.CachedContainerMetrics
@@ -111,6 +111,57 @@ var _ = Describe("Resources", func() {
 		})
 	})
 
+	Describe("MatchPlacementExpressions", func() {
+		var state rep.CellState
+
+		BeforeEach(func() {
+			state = rep.CellState{PlacementLabels: map[string]string{"gpu": "false", "tier": "edge"}}
+		})
+
+		It("matches when there are no expressions", func() {
+			Expect(state.MatchPlacementExpressions(nil)).To(BeTrue())
+		})
+
+		It("evaluates the expressions against the placement labels", func() {
+			Expect(state.MatchPlacementExpressions([]string{"tier in (edge)", "!zone"})).To(BeTrue())
+			Expect(state.MatchPlacementExpressions([]string{"tier in (edge)", "gpu notin (false)"})).To(BeFalse())
+		})
+
+		It("does not match invalid expressions", func() {
+			Expect(state.MatchPlacementExpressions([]string{"tier in edge"})).To(BeFalse())
+		})
+	})
+
+	Describe("MatchMaxInstancesPerCell", func() {
+		It("counts the instances of each process on the cell", func() {
+			Expect(cellState.InstanceCount("pg-1")).To(Equal(2))
+			Expect(cellState.InstanceCount("pg-2")).To(Equal(1))
+			Expect(cellState.InstanceCount("pg-missing")).To(Equal(0))
+		})
+
+		It("allows any number of instances when the LRP does not set a limit", func() {
+			lrp := buildLRP("ig-6", "pg-1", "domain", 2, linuxRootFSURL, 10, 20, 30, []string{}, []string{}, models.ActualLRPStateClaimed)
+			Expect(cellState.MatchMaxInstancesPerCell(lrp)).To(BeTrue())
+		})
+
+		It("rejects LRPs whose process already has the maximum instances on the cell", func() {
+			lrp := buildLRP("ig-6", "pg-1", "domain", 2, linuxRootFSURL, 10, 20, 30, []string{}, []string{}, models.ActualLRPStateClaimed)
+			lrp.MaxInstancesPerCell = 2
+			Expect(cellState.MatchMaxInstancesPerCell(lrp)).To(BeFalse())
+
+			lrp.MaxInstancesPerCell = 3
+			Expect(cellState.MatchMaxInstancesPerCell(lrp)).To(BeTrue())
+		})
+	})
+
+	Describe("Subtract", func() {
+		It("subtracts the resource from the available resources", func() {
+			resources := rep.Resources{MemoryMB: 100, DiskMB: 200, Containers: 3, CPUWeight: 300, MaxPids: 400}
+			resources.Subtract(&rep.Resource{MemoryMB: 10, DiskMB: 20, CPUWeight: 30, MaxPids: 40})
+			Expect(resources).To(Equal(rep.Resources{MemoryMB: 90, DiskMB: 180, Containers: 2, CPUWeight: 270, MaxPids: 360}))
+		})
+	})
+
 	Describe("Resource Matching", func() {
 		var requiredResource rep.Resource
 		var err error
@@ -180,6 +231,54 @@ var _ = Describe("Resources", func() {
 			})
 		})
 
+		Context("when the cell tracks cpu", func() {
+			BeforeEach(func() {
+				cellState.TotalResources.CPUWeight = 400
+				cellState.AvailableResources.CPUWeight = 50
+			})
+
+			Context("and there is insufficient cpu", func() {
+				BeforeEach(func() {
+					requiredResource.CPUWeight = 100
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(MatchError("insufficient resources: cpu"))
+				})
+			})
+
+			Context("and there is sufficient cpu", func() {
+				BeforeEach(func() {
+					requiredResource.CPUWeight = 50
+				})
+
+				It("does not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the cell does not track cpu", func() {
+			BeforeEach(func() {
+				requiredResource.CPUWeight = 100
+			})
+
+			It("ignores the requested cpu weight", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the cell tracks pids", func() {
+			BeforeEach(func() {
+				cellState.TotalResources.MaxPids = 1000
+				cellState.AvailableResources.MaxPids = 5
+			})
+
+			It("returns an error when the pid budget would be exceeded", func() {
+				Expect(err).To(MatchError("insufficient resources: pids"))
+			})
+		})
+
 		Context("when there is sufficient room", func() {
 			It("does not return an error", func() {
 				Expect(err).NotTo(HaveOccurred())
@@ -187,6 +286,78 @@ var _ = Describe("Resources", func() {
 		})
 	})
 
+	Describe("ComputeScore", func() {
+		var resource rep.Resource
+
+		BeforeEach(func() {
+			cellState.AvailableResources = rep.NewResources(500, 1000, 5)
+			cellState.StartingContainerCount = 0
+			resource = rep.NewResource(0, 0, 0)
+		})
+
+		It("averages the used fractions of memory, disk and containers", func() {
+			Expect(cellState.ComputeScore(&resource, 0)).To(BeNumerically("~", (0.5+0.5+0.6)/3.0, 0.0001))
+		})
+
+		Context("when the cell tracks cpu", func() {
+			BeforeEach(func() {
+				cellState.TotalResources.CPUWeight = 400
+				cellState.AvailableResources.CPUWeight = 100
+				resource.CPUWeight = 100
+			})
+
+			It("includes the used fraction of cpu", func() {
+				Expect(cellState.ComputeScore(&resource, 0)).To(BeNumerically("~", (0.5+0.5+0.6+1.0)/4.0, 0.0001))
+			})
+		})
+
+		Context("when the cell tracks pids", func() {
+			BeforeEach(func() {
+				cellState.TotalResources.MaxPids = 1000
+				cellState.AvailableResources.MaxPids = 500
+				resource.MaxPids = 250
+			})
+
+			It("includes the used fraction of the pid budget", func() {
+				Expect(cellState.ComputeScore(&resource, 0)).To(BeNumerically("~", (0.5+0.5+0.6+0.75)/4.0, 0.0001))
+			})
+		})
+
+		Context("with a locality bonus", func() {
+			var bonus rep.LocalityBonus
+
+			BeforeEach(func() {
+				cellState.CachedRootFSes = []string{"docker:///cloudfoundry/grace"}
+				bonus = rep.LocalityBonus{RootFS: "docker:///cloudfoundry/grace", Bonus: 0.25}
+			})
+
+			It("subtracts the bonus when the rootfs is cached on the cell", func() {
+				Expect(cellState.ComputeScore(&resource, 0, bonus)).To(BeNumerically("~", (0.5+0.5+0.6)/3.0-0.25, 0.0001))
+			})
+
+			It("does not subtract the bonus when the rootfs is not cached on the cell", func() {
+				bonus.RootFS = "docker:///cloudfoundry/other"
+				Expect(cellState.ComputeScore(&resource, 0, bonus)).To(BeNumerically("~", (0.5+0.5+0.6)/3.0, 0.0001))
+			})
+		})
+	})
+
+	Describe("HasCachedRootFS", func() {
+		BeforeEach(func() {
+			cellState.CachedRootFSes = []string{"docker:///cloudfoundry/grace", "preloaded+layer:linux?layer=https://example.com/layer.tgz"}
+		})
+
+		It("returns true for cached rootfses", func() {
+			Expect(cellState.HasCachedRootFS("docker:///cloudfoundry/grace")).To(BeTrue())
+			Expect(cellState.HasCachedRootFS("preloaded+layer:linux?layer=https://example.com/layer.tgz")).To(BeTrue())
+		})
+
+		It("returns false for other rootfses", func() {
+			Expect(cellState.HasCachedRootFS("docker:///cloudfoundry/other")).To(BeFalse())
+			Expect(cellState.HasCachedRootFS("")).To(BeFalse())
+		})
+	})
+
 	Describe("StackPathMap", func() {
 		Describe("PathForRootFS", func() {
 			var stackPathMap rep.StackPathMap
@@ -206,6 +377,11 @@ var _ = Describe("Resources", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(p).To(Equal(fmt.Sprintf("preloaded+layer:cflinuxfs3:/var/vcap/packages/cflinuxfs3/rootfs.tar%s", queryString)))
 			})
+			It("ignores the version of a versioned stack", func() {
+				p, err := stackPathMap.PathForRootFS("preloaded:cflinuxfs3@1.52.0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(p).To(Equal("cflinuxfs3:/var/vcap/packages/cflinuxfs3/rootfs.tar"))
+			})
 			It("returns a blank string and no error if the RootFS URL is blank", func() {
 				p, err := stackPathMap.PathForRootFS("")
 				Expect(err).NotTo(HaveOccurred())
@@ -225,6 +401,37 @@ var _ = Describe("Resources", func() {
 				Expect(err).To(MatchError(rep.ErrPreloadedRootFSNotFound))
 			})
 		})
+
+		Describe("VerifyStackVersion", func() {
+			var versionRanges map[string]string
+
+			BeforeEach(func() {
+				versionRanges = map[string]string{"cflinuxfs3": ">=1.50, <2"}
+			})
+
+			It("accepts a version inside the stack's range", func() {
+				Expect(rep.VerifyStackVersion("preloaded:cflinuxfs3@1.52.0", versionRanges)).To(Succeed())
+				Expect(rep.VerifyStackVersion("preloaded+layer:cflinuxfs3@1.52.0?layer=x", versionRanges)).To(Succeed())
+			})
+
+			It("rejects a version outside the stack's range", func() {
+				Expect(rep.VerifyStackVersion("preloaded:cflinuxfs3@2.0.0", versionRanges)).To(MatchError(rep.ErrPreloadedRootFSVersionUnsupported))
+				Expect(rep.VerifyStackVersion("preloaded+layer:cflinuxfs3@1.49.9", versionRanges)).To(MatchError(rep.ErrPreloadedRootFSVersionUnsupported))
+				Expect(rep.VerifyStackVersion("preloaded:cflinuxfs3@not-a-version", versionRanges)).To(MatchError(rep.ErrPreloadedRootFSVersionUnsupported))
+			})
+
+			It("accepts a rootfs without a version", func() {
+				Expect(rep.VerifyStackVersion("preloaded:cflinuxfs3", versionRanges)).To(Succeed())
+			})
+
+			It("accepts any version of a stack without a range", func() {
+				Expect(rep.VerifyStackVersion("preloaded:cflinuxfs4@9.0.0", versionRanges)).To(Succeed())
+			})
+
+			It("ignores rootfses that are not preloaded", func() {
+				Expect(rep.VerifyStackVersion("docker:///cfdiegodocker/grace@2.0.0", versionRanges)).To(Succeed())
+			})
+		})
 	})
 })
 
@@ -2,7 +2,12 @@ package rep
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
 )
 
 type RootFSProvider interface {
@@ -15,8 +20,56 @@ type RootFSProviderType string
 const (
 	RootFSProviderTypeArbitrary RootFSProviderType = "arbitrary"
 	RootFSProviderTypeFixedSet  RootFSProviderType = "fixed_set"
+
+	RootFSProviderTypePattern      RootFSProviderType = "pattern"
+	RootFSProviderTypeVersionedSet RootFSProviderType = "versioned_set"
 )
 
+// UnknownRootFSProviderTypeError is returned when deserializing a provider
+// whose type has not been registered.
+type UnknownRootFSProviderTypeError struct {
+	Type RootFSProviderType
+}
+
+func (e UnknownRootFSProviderTypeError) Error() string {
+	return fmt.Sprintf("unknown rootfs provider type: %q", string(e.Type))
+}
+
+// RootFSProviderUnmarshaler deserializes the JSON payload of a provider,
+// including its type field, into a RootFSProvider.
+type RootFSProviderUnmarshaler func(payload []byte) (RootFSProvider, error)
+
+var rootFSProviderTypesLock sync.RWMutex
+var rootFSProviderTypes = map[RootFSProviderType]RootFSProviderUnmarshaler{
+	RootFSProviderTypeArbitrary: func([]byte) (RootFSProvider, error) {
+		return ArbitraryRootFSProvider{}, nil
+	},
+	RootFSProviderTypeFixedSet: func(payload []byte) (RootFSProvider, error) {
+		var provider FixedSetRootFSProvider
+		err := provider.UnmarshalJSON(payload)
+		return provider, err
+	},
+	RootFSProviderTypePattern: func(payload []byte) (RootFSProvider, error) {
+		var provider PatternRootFSProvider
+		err := provider.UnmarshalJSON(payload)
+		return provider, err
+	},
+	RootFSProviderTypeVersionedSet: func(payload []byte) (RootFSProvider, error) {
+		var provider VersionedSetRootFSProvider
+		err := provider.UnmarshalJSON(payload)
+		return provider, err
+	},
+}
+
+// RegisterRootFSProviderType makes a provider type available to
+// RootFSProviders deserialization. Registering an existing type replaces it.
+func RegisterRootFSProviderType(providerType RootFSProviderType, unmarshal RootFSProviderUnmarshaler) {
+	rootFSProviderTypesLock.Lock()
+	defer rootFSProviderTypesLock.Unlock()
+
+	rootFSProviderTypes[providerType] = unmarshal
+}
+
 type RootFSProviders map[string]RootFSProvider
 
 func (p RootFSProviders) Copy() RootFSProviders {
@@ -67,17 +120,15 @@ func unmarshalRootFSProvider(payload []byte) (RootFSProvider, error) {
 		return nil, err
 	}
 
-	switch envelope.Type {
-	case RootFSProviderTypeArbitrary:
-		return ArbitraryRootFSProvider{}, nil
-	case RootFSProviderTypeFixedSet:
-		var provider FixedSetRootFSProvider
-		err := provider.UnmarshalJSON(payload)
-		return provider, err
-	}
+	rootFSProviderTypesLock.RLock()
+	unmarshal, ok := rootFSProviderTypes[envelope.Type]
+	rootFSProviderTypesLock.RUnlock()
 
-	return nil, nil
+	if !ok {
+		return nil, UnknownRootFSProviderTypeError{Type: envelope.Type}
+	}
 
+	return unmarshal(payload)
 }
 
 type ArbitraryRootFSProvider struct{}
@@ -143,6 +194,172 @@ func (provider *FixedSetRootFSProvider) UnmarshalJSON(payload []byte) error {
 	return nil
 }
 
+// PatternRootFSProvider matches rootfs URLs whose location, the opaque part of
+// the URL or its host and path, matches either a glob or a regular expression.
+// NewRegexRootFSProvider compiles the regular expression once; a provider
+// built as a literal compiles it on every match.
+type PatternRootFSProvider struct {
+	Glob  string
+	Regex string
+
+	regex *regexp.Regexp
+}
+
+func NewGlobRootFSProvider(glob string) (PatternRootFSProvider, error) {
+	if _, err := path.Match(glob, ""); err != nil {
+		return PatternRootFSProvider{}, err
+	}
+	return PatternRootFSProvider{Glob: glob}, nil
+}
+
+func NewRegexRootFSProvider(expression string) (PatternRootFSProvider, error) {
+	regex, err := regexp.Compile(expression)
+	if err != nil {
+		return PatternRootFSProvider{}, err
+	}
+	return PatternRootFSProvider{Regex: expression, regex: regex}, nil
+}
+
+func (PatternRootFSProvider) Type() RootFSProviderType { return RootFSProviderTypePattern }
+
+func (provider PatternRootFSProvider) Match(rootfs url.URL) bool {
+	location := rootFSLocation(rootfs)
+	if provider.Glob != "" {
+		matched, err := path.Match(provider.Glob, location)
+		return err == nil && matched
+	}
+	if provider.regex != nil {
+		return provider.regex.MatchString(location)
+	}
+	if provider.Regex != "" {
+		matched, err := regexp.MatchString(provider.Regex, location)
+		return err == nil && matched
+	}
+	return false
+}
+
+func (provider PatternRootFSProvider) MarshalJSON() ([]byte, error) {
+	payload := map[string]string{"type": string(provider.Type())}
+	if provider.Glob != "" {
+		payload["glob"] = provider.Glob
+	}
+	if provider.Regex != "" {
+		payload["regex"] = provider.Regex
+	}
+	return json.Marshal(payload)
+}
+
+func (provider *PatternRootFSProvider) UnmarshalJSON(payload []byte) error {
+	var p struct {
+		Glob  string `json:"glob"`
+		Regex string `json:"regex"`
+	}
+	err := json.Unmarshal(payload, &p)
+	if err != nil {
+		return err
+	}
+
+	var parsed PatternRootFSProvider
+	switch {
+	case p.Glob != "" && p.Regex != "":
+		return fmt.Errorf("pattern rootfs provider must not set both a glob and a regex")
+	case p.Glob != "":
+		parsed, err = NewGlobRootFSProvider(p.Glob)
+	case p.Regex != "":
+		parsed, err = NewRegexRootFSProvider(p.Regex)
+	default:
+		return fmt.Errorf("pattern rootfs provider must set a glob or a regex")
+	}
+	if err != nil {
+		return err
+	}
+
+	*provider = parsed
+	return nil
+}
+
+func rootFSLocation(rootfs url.URL) string {
+	if rootfs.Opaque != "" {
+		return rootfs.Opaque
+	}
+	return rootfs.Host + rootfs.Path
+}
+
+// VersionedSetRootFSProvider matches rootfses named by stack and version, for
+// example preloaded:cflinuxfs4@1.52.0, when the stack is in the set and the
+// version satisfies the stack's range, for example ">=1.50". A rootfs without
+// a version matches any stack in the set.
+type VersionedSetRootFSProvider struct {
+	VersionedSet map[string]string
+}
+
+func NewVersionedSetRootFSProvider(versionedSet map[string]string) (VersionedSetRootFSProvider, error) {
+	for stack, versionRange := range versionedSet {
+		if _, err := parseVersionRange(versionRange); err != nil {
+			return VersionedSetRootFSProvider{}, fmt.Errorf("invalid version range for stack %s: %s", stack, err)
+		}
+	}
+	return VersionedSetRootFSProvider{VersionedSet: versionedSet}, nil
+}
+
+func (VersionedSetRootFSProvider) Type() RootFSProviderType { return RootFSProviderTypeVersionedSet }
+
+func (provider VersionedSetRootFSProvider) Match(rootfs url.URL) bool {
+	stack, version := SplitStackVersion(rootfs.Opaque)
+
+	versionRange, ok := provider.VersionedSet[stack]
+	if !ok {
+		return false
+	}
+	if version == "" {
+		return true
+	}
+
+	constraints, err := parseVersionRange(versionRange)
+	if err != nil {
+		return false
+	}
+	v, err := parseVersion(version)
+	if err != nil {
+		return false
+	}
+	return constraints.contains(v)
+}
+
+func (provider VersionedSetRootFSProvider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type RootFSProviderType `json:"type"`
+		Set  map[string]string  `json:"set"`
+	}{provider.Type(), provider.VersionedSet})
+}
+
+func (provider *VersionedSetRootFSProvider) UnmarshalJSON(payload []byte) error {
+	var p struct {
+		Set map[string]string `json:"set"`
+	}
+	err := json.Unmarshal(payload, &p)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewVersionedSetRootFSProvider(p.Set)
+	if err != nil {
+		return err
+	}
+
+	*provider = parsed
+	return nil
+}
+
+// SplitStackVersion splits a versioned stack name such as cflinuxfs4@1.52.0
+// into the stack and its version. The version is empty if there is none.
+func SplitStackVersion(name string) (string, string) {
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
 type StringSet map[string]struct{}
 
 func NewStringSet(entries ...string) StringSet {
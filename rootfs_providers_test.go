@@ -53,6 +53,97 @@ var _ = Describe("RootFSProviders", func() {
 		Expect(providersResult).To(Equal(providers))
 	})
 
+	Context("when a provider type is unknown", func() {
+		It("returns an error", func() {
+			var providersResult rep.RootFSProviders
+			err := json.Unmarshal([]byte(`{"foo": {"type": "mystery"}}`), &providersResult)
+			Expect(err).To(Equal(rep.UnknownRootFSProviderTypeError{Type: "mystery"}))
+		})
+	})
+
+	Context("when a provider type has been registered", func() {
+		const customType rep.RootFSProviderType = "custom"
+
+		BeforeEach(func() {
+			rep.RegisterRootFSProviderType(customType, func([]byte) (rep.RootFSProvider, error) {
+				return arbitrary, nil
+			})
+		})
+
+		It("deserializes providers of that type", func() {
+			var providersResult rep.RootFSProviders
+			err := json.Unmarshal([]byte(`{"foo": {"type": "custom"}}`), &providersResult)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providersResult).To(Equal(rep.RootFSProviders{"foo": arbitrary}))
+		})
+	})
+
+	Describe("PatternRootFSProvider", func() {
+		It("round trips a glob through JSON", func() {
+			glob, err := rep.NewGlobRootFSProvider("cflinuxfs*")
+			Expect(err).NotTo(HaveOccurred())
+
+			payload, err := json.Marshal(rep.RootFSProviders{"preloaded": glob})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"preloaded": {"type": "pattern", "glob": "cflinuxfs*"}}`))
+
+			var providersResult rep.RootFSProviders
+			err = json.Unmarshal(payload, &providersResult)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providersResult).To(Equal(rep.RootFSProviders{"preloaded": glob}))
+		})
+
+		It("deserializes a regex", func() {
+			var providersResult rep.RootFSProviders
+			err := json.Unmarshal([]byte(`{"docker": {"type": "pattern", "regex": "^registry\\.example\\.com/"}}`), &providersResult)
+			Expect(err).NotTo(HaveOccurred())
+
+			rootFS, err := url.Parse("docker://registry.example.com/team/image")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providersResult.Match(*rootFS)).To(BeTrue())
+
+			rootFS, err = url.Parse("docker://docker.io/team/image")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providersResult.Match(*rootFS)).To(BeFalse())
+		})
+
+		It("rejects invalid patterns", func() {
+			_, err := rep.NewGlobRootFSProvider("[")
+			Expect(err).To(HaveOccurred())
+
+			_, err = rep.NewRegexRootFSProvider("(")
+			Expect(err).To(HaveOccurred())
+
+			var providersResult rep.RootFSProviders
+			err = json.Unmarshal([]byte(`{"foo": {"type": "pattern"}}`), &providersResult)
+			Expect(err).To(HaveOccurred())
+
+			err = json.Unmarshal([]byte(`{"foo": {"type": "pattern", "glob": "a", "regex": "b"}}`), &providersResult)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("VersionedSetRootFSProvider", func() {
+		It("round trips through JSON", func() {
+			versionedSet, err := rep.NewVersionedSetRootFSProvider(map[string]string{"cflinuxfs4": ">= 1.50"})
+			Expect(err).NotTo(HaveOccurred())
+
+			payload, err := json.Marshal(rep.RootFSProviders{"preloaded": versionedSet})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{"preloaded": {"type": "versioned_set", "set": {"cflinuxfs4": ">= 1.50"}}}`))
+
+			var providersResult rep.RootFSProviders
+			err = json.Unmarshal(payload, &providersResult)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providersResult).To(Equal(rep.RootFSProviders{"preloaded": versionedSet}))
+		})
+
+		It("rejects invalid version ranges", func() {
+			_, err := rep.NewVersionedSetRootFSProvider(map[string]string{"cflinuxfs4": ">= one"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Match", func() {
 		Describe("ArbitraryRootFSProvider", func() {
 			It("matches any URL", func() {
@@ -79,6 +170,104 @@ var _ = Describe("RootFSProviders", func() {
 			})
 		})
 
+		Describe("PatternRootFSProvider", func() {
+			It("matches the location of the URL against a glob", func() {
+				glob, err := rep.NewGlobRootFSProvider("cflinuxfs*")
+				Expect(err).NotTo(HaveOccurred())
+
+				rootFS, err := url.Parse("preloaded:cflinuxfs4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(glob.Match(*rootFS)).To(BeTrue())
+
+				rootFS, err = url.Parse("preloaded:windows2016")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(glob.Match(*rootFS)).To(BeFalse())
+			})
+
+			It("matches the location of the URL against a regex", func() {
+				regex, err := rep.NewRegexRootFSProvider("^cflinuxfs[0-9]+$")
+				Expect(err).NotTo(HaveOccurred())
+
+				rootFS, err := url.Parse("preloaded:cflinuxfs4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regex.Match(*rootFS)).To(BeTrue())
+
+				rootFS, err = url.Parse("preloaded:windows2016")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regex.Match(*rootFS)).To(BeFalse())
+			})
+
+			It("matches against a regex set on a literal provider", func() {
+				regex := rep.PatternRootFSProvider{Regex: "^cflinuxfs[0-9]+$"}
+
+				rootFS, err := url.Parse("preloaded:cflinuxfs4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regex.Match(*rootFS)).To(BeTrue())
+			})
+		})
+
+		Describe("VersionedSetRootFSProvider", func() {
+			var versionedSet rep.VersionedSetRootFSProvider
+
+			BeforeEach(func() {
+				var err error
+				versionedSet, err = rep.NewVersionedSetRootFSProvider(map[string]string{
+					"cflinuxfs4": ">=1.50, <2",
+					"cflinuxfs3": "*",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("matches versions of a stack within its range", func() {
+				for _, rootFSURL := range []string{"preloaded:cflinuxfs4@1.50", "preloaded:cflinuxfs4@1.52.3", "preloaded:cflinuxfs3@0.1.0"} {
+					rootFS, err := url.Parse(rootFSURL)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionedSet.Match(*rootFS)).To(BeTrue(), rootFSURL)
+				}
+			})
+
+			It("does not match versions outside the range", func() {
+				for _, rootFSURL := range []string{"preloaded:cflinuxfs4@1.49.9", "preloaded:cflinuxfs4@2.0.0", "preloaded:cflinuxfs4@1.50.0-rc.1", "preloaded:cflinuxfs4@latest"} {
+					rootFS, err := url.Parse(rootFSURL)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionedSet.Match(*rootFS)).To(BeFalse(), rootFSURL)
+				}
+			})
+
+			It("orders pre-releases by their identifiers", func() {
+				prereleases, err := rep.NewVersionedSetRootFSProvider(map[string]string{
+					"cflinuxfs4": ">1.0.0-rc.9, <1.0.0",
+					"cflinuxfs3": ">=1.0.0-alpha",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				for rootFSURL, matches := range map[string]bool{
+					"preloaded:cflinuxfs4@1.0.0-rc.10":  true,
+					"preloaded:cflinuxfs4@1.0.0-rc.9.1": true,
+					"preloaded:cflinuxfs4@1.0.0-rc.9":   false,
+					"preloaded:cflinuxfs4@1.0.0-rc.2":   false,
+					"preloaded:cflinuxfs3@1.0.0-beta":   true,
+					"preloaded:cflinuxfs3@1.0.0-1":      false,
+				} {
+					rootFS, err := url.Parse(rootFSURL)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(prereleases.Match(*rootFS)).To(Equal(matches), rootFSURL)
+				}
+			})
+
+			It("matches a stack in the set without a version", func() {
+				rootFS, err := url.Parse("preloaded:cflinuxfs4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionedSet.Match(*rootFS)).To(BeTrue())
+			})
+
+			It("does not match a stack not in the set", func() {
+				rootFS, err := url.Parse("preloaded:windows2016@1.60")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionedSet.Match(*rootFS)).To(BeFalse())
+			})
+		})
+
 		Describe("RootFSProviders", func() {
 			Context("for a scheme with an arbitrary provider", func() {
 				It("matches any url", func() {
@@ -4,11 +4,22 @@ import "github.com/tedsuo/rata"
 
 const (
 	StateRoute            = "STATE"
+	StateEventsRoute      = "StateEvents"
 	ContainerMetricsRoute = "ContainerMetrics"
 	PerformRoute          = "PERFORM"
+	PerformDryRunRoute    = "PerformDryRun"
+	ReserveRoute          = "Reserve"
+	PrewarmRoute          = "Prewarm"
 
-	StopLRPInstanceRoute = "StopLRPInstance"
-	CancelTaskRoute      = "CancelTask"
+	StopLRPInstanceRoute    = "StopLRPInstance"
+	RestartLRPInstanceRoute = "RestartLRPInstance"
+	CancelTaskRoute         = "CancelTask"
+	StopLRPInstancesRoute   = "StopLRPInstances"
+	CancelTasksRoute        = "CancelTasks"
+	LRPInstanceDetailRoute  = "LRPInstanceDetail"
+	TaskDetailRoute         = "TaskDetail"
+
+	ContainerDiagnosticsRoute = "ContainerDiagnostics"
 
 	SimResetRoute = "RESET"
 
@@ -22,11 +33,21 @@ func NewRoutes(networkAccessible bool) rata.Routes {
 	if networkAccessible {
 		routes = append(routes,
 			rata.Route{Path: "/state", Method: "GET", Name: StateRoute},
+			rata.Route{Path: "/v1/state/events", Method: "GET", Name: StateEventsRoute},
 			rata.Route{Path: "/container_metrics", Method: "GET", Name: ContainerMetricsRoute},
 			rata.Route{Path: "/work", Method: "POST", Name: PerformRoute},
+			rata.Route{Path: "/work/dry_run", Method: "POST", Name: PerformDryRunRoute},
+			rata.Route{Path: "/reservations", Method: "POST", Name: ReserveRoute},
+			rata.Route{Path: "/v1/prewarm", Method: "POST", Name: PrewarmRoute},
 
 			rata.Route{Path: "/v1/lrps/:process_guid/instances/:instance_guid/stop", Method: "POST", Name: StopLRPInstanceRoute},
+			rata.Route{Path: "/v1/lrps/:process_guid/instances/:instance_guid/restart", Method: "POST", Name: RestartLRPInstanceRoute},
 			rata.Route{Path: "/v1/tasks/:task_guid/cancel", Method: "POST", Name: CancelTaskRoute},
+			rata.Route{Path: "/v1/lrps/stop", Method: "POST", Name: StopLRPInstancesRoute},
+			rata.Route{Path: "/v1/tasks/cancel", Method: "POST", Name: CancelTasksRoute},
+			rata.Route{Path: "/v1/lrps/:process_guid/instances/:instance_guid", Method: "GET", Name: LRPInstanceDetailRoute},
+			rata.Route{Path: "/v1/tasks/:task_guid", Method: "GET", Name: TaskDetailRoute},
+			rata.Route{Path: "/v1/containers/:guid/diagnostics", Method: "GET", Name: ContainerDiagnosticsRoute},
 
 			rata.Route{Path: "/sim/reset", Method: "POST", Name: SimResetRoute},
 		)
@@ -0,0 +1,182 @@
+package rep
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	ScoringStrategyBalanced       = "balanced"
+	ScoringStrategyBinPack        = "bin_pack"
+	ScoringStrategySpread         = "spread"
+	ScoringStrategyMemoryDominant = "memory_dominant"
+)
+
+var ErrInvalidScoringWeights = errors.New("scoring weights must be non-negative and at least one must be positive")
+var ErrScoringWeightsNotSupported = errors.New("the memory_dominant scoring strategy does not take weights")
+
+// ScoringStrategy scores a cell given the resources that would remain after
+// placing a workload on it. Lower scores are preferred by the auctioneer.
+type ScoringStrategy interface {
+	Name() string
+	Score(remaining, total *Resources) float64
+}
+
+// ScoringWeights are the relative weights given to each resource when a
+// strategy combines them. CPUWeight and MaxPids only apply when the cell
+// tracks those resources.
+type ScoringWeights struct {
+	MemoryMB   float64 `json:"memory_mb"`
+	DiskMB     float64 `json:"disk_mb"`
+	Containers float64 `json:"containers"`
+	CPUWeight  float64 `json:"cpu_weight"`
+	MaxPids    float64 `json:"max_pids"`
+}
+
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{MemoryMB: 1, DiskMB: 1, Containers: 1, CPUWeight: 1, MaxPids: 1}
+}
+
+func (w ScoringWeights) Validate() error {
+	values := []float64{w.MemoryMB, w.DiskMB, w.Containers, w.CPUWeight, w.MaxPids}
+	positive := false
+	for _, v := range values {
+		if v < 0 {
+			return ErrInvalidScoringWeights
+		}
+		if v > 0 {
+			positive = true
+		}
+	}
+	if !positive {
+		return ErrInvalidScoringWeights
+	}
+	return nil
+}
+
+// LocalityBonus is subtracted from the score of cells that already have
+// RootFS cached, so that workloads are preferably placed where their image
+// does not need to be pulled. The bonus is on the same scale as the scores
+// returned by the strategies, which range from 0 to 1.
+type LocalityBonus struct {
+	RootFS string
+	Bonus  float64
+}
+
+// ScoringConfig selects a scoring strategy. It is advertised in the CellState
+// so that the auctioneer can reproduce the cell's scoring.
+type ScoringConfig struct {
+	Strategy string          `json:"strategy,omitempty"`
+	Weights  *ScoringWeights `json:"weights,omitempty"`
+}
+
+// NewScoringStrategy builds the strategy described by the config. An empty
+// strategy name selects the balanced strategy and nil weights select the
+// default weights. The memory dominant strategy only looks at memory, so it
+// is an error to give it weights.
+func NewScoringStrategy(config ScoringConfig) (ScoringStrategy, error) {
+	weights := DefaultScoringWeights()
+	if config.Weights != nil {
+		weights = *config.Weights
+	}
+
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch config.Strategy {
+	case "", ScoringStrategyBalanced:
+		return BalancedScoringStrategy{Weights: weights}, nil
+	case ScoringStrategyBinPack:
+		return BinPackScoringStrategy{Weights: weights}, nil
+	case ScoringStrategySpread:
+		return SpreadScoringStrategy{Weights: weights}, nil
+	case ScoringStrategyMemoryDominant:
+		if config.Weights != nil {
+			return nil, ErrScoringWeightsNotSupported
+		}
+		return MemoryDominantScoringStrategy{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown scoring strategy: %s", config.Strategy)
+}
+
+// BalancedScoringStrategy prefers the cell with the lowest weighted average
+// utilization. With the default weights this is the historical rep scoring.
+type BalancedScoringStrategy struct {
+	Weights ScoringWeights
+}
+
+func (BalancedScoringStrategy) Name() string { return ScoringStrategyBalanced }
+
+func (s BalancedScoringStrategy) Score(remaining, total *Resources) float64 {
+	sum, weight := 0.0, 0.0
+	for _, f := range usedFractions(remaining, total, s.Weights) {
+		sum += f.weight * f.used
+		weight += f.weight
+	}
+	if weight == 0 {
+		return 0
+	}
+	return sum / weight
+}
+
+// BinPackScoringStrategy prefers the cell with the highest weighted average
+// utilization, filling cells before moving on to emptier ones.
+type BinPackScoringStrategy struct {
+	Weights ScoringWeights
+}
+
+func (BinPackScoringStrategy) Name() string { return ScoringStrategyBinPack }
+
+func (s BinPackScoringStrategy) Score(remaining, total *Resources) float64 {
+	return 1.0 - BalancedScoringStrategy{Weights: s.Weights}.Score(remaining, total)
+}
+
+// SpreadScoringStrategy prefers the cell whose most utilized resource is the
+// least utilized. Resources with a zero weight are ignored.
+type SpreadScoringStrategy struct {
+	Weights ScoringWeights
+}
+
+func (SpreadScoringStrategy) Name() string { return ScoringStrategySpread }
+
+func (s SpreadScoringStrategy) Score(remaining, total *Resources) float64 {
+	max := 0.0
+	for _, f := range usedFractions(remaining, total, s.Weights) {
+		if f.weight > 0 && f.used > max {
+			max = f.used
+		}
+	}
+	return max
+}
+
+// MemoryDominantScoringStrategy prefers the cell with the lowest memory
+// utilization and ignores every other resource.
+type MemoryDominantScoringStrategy struct{}
+
+func (MemoryDominantScoringStrategy) Name() string { return ScoringStrategyMemoryDominant }
+
+func (MemoryDominantScoringStrategy) Score(remaining, total *Resources) float64 {
+	return 1.0 - float64(remaining.MemoryMB)/float64(total.MemoryMB)
+}
+
+type usedFraction struct {
+	used   float64
+	weight float64
+}
+
+func usedFractions(remaining, total *Resources, weights ScoringWeights) []usedFraction {
+	fractions := []usedFraction{
+		{1.0 - float64(remaining.MemoryMB)/float64(total.MemoryMB), weights.MemoryMB},
+		{1.0 - float64(remaining.DiskMB)/float64(total.DiskMB), weights.DiskMB},
+		{1.0 - float64(remaining.Containers)/float64(total.Containers), weights.Containers},
+	}
+	if total.CPUWeight > 0 {
+		fractions = append(fractions, usedFraction{1.0 - float64(remaining.CPUWeight)/float64(total.CPUWeight), weights.CPUWeight})
+	}
+	if total.MaxPids > 0 {
+		fractions = append(fractions, usedFraction{1.0 - float64(remaining.MaxPids)/float64(total.MaxPids), weights.MaxPids})
+	}
+	return fractions
+}
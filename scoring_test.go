@@ -0,0 +1,121 @@
+package rep_test
+
+import (
+	"code.cloudfoundry.org/rep"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScoringStrategy", func() {
+	var remaining, total rep.Resources
+
+	BeforeEach(func() {
+		total = rep.NewResources(1000, 2000, 10)
+		remaining = rep.NewResources(250, 1000, 8)
+	})
+
+	Describe("NewScoringStrategy", func() {
+		It("defaults to the balanced strategy", func() {
+			strategy, err := rep.NewScoringStrategy(rep.ScoringConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategy.Name()).To(Equal(rep.ScoringStrategyBalanced))
+		})
+
+		It("builds each of the built-in strategies", func() {
+			for _, name := range []string{
+				rep.ScoringStrategyBalanced,
+				rep.ScoringStrategyBinPack,
+				rep.ScoringStrategySpread,
+				rep.ScoringStrategyMemoryDominant,
+			} {
+				strategy, err := rep.NewScoringStrategy(rep.ScoringConfig{Strategy: name})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strategy.Name()).To(Equal(name))
+			}
+		})
+
+		It("errors on an unknown strategy", func() {
+			_, err := rep.NewScoringStrategy(rep.ScoringConfig{Strategy: "random"})
+			Expect(err).To(MatchError("unknown scoring strategy: random"))
+		})
+
+		It("errors on negative weights", func() {
+			weights := rep.DefaultScoringWeights()
+			weights.DiskMB = -1
+			_, err := rep.NewScoringStrategy(rep.ScoringConfig{Weights: &weights})
+			Expect(err).To(MatchError(rep.ErrInvalidScoringWeights))
+		})
+
+		It("errors when every weight is zero", func() {
+			_, err := rep.NewScoringStrategy(rep.ScoringConfig{Weights: &rep.ScoringWeights{}})
+			Expect(err).To(MatchError(rep.ErrInvalidScoringWeights))
+		})
+
+		It("errors on weights for the memory dominant strategy", func() {
+			weights := rep.DefaultScoringWeights()
+			_, err := rep.NewScoringStrategy(rep.ScoringConfig{Strategy: rep.ScoringStrategyMemoryDominant, Weights: &weights})
+			Expect(err).To(MatchError(rep.ErrScoringWeightsNotSupported))
+		})
+	})
+
+	Describe("BalancedScoringStrategy", func() {
+		It("matches the default resource score", func() {
+			strategy := rep.BalancedScoringStrategy{Weights: rep.DefaultScoringWeights()}
+			Expect(strategy.Score(&remaining, &total)).To(Equal(remaining.ComputeScore(&total)))
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("~", (0.75+0.5+0.2)/3.0, 0.0001))
+		})
+
+		It("applies the weights", func() {
+			strategy := rep.BalancedScoringStrategy{Weights: rep.ScoringWeights{MemoryMB: 3, DiskMB: 1}}
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("~", (3*0.75+0.5)/4.0, 0.0001))
+		})
+	})
+
+	Describe("BinPackScoringStrategy", func() {
+		It("prefers fuller cells", func() {
+			strategy := rep.BinPackScoringStrategy{Weights: rep.DefaultScoringWeights()}
+			emptier := rep.NewResources(900, 1900, 9)
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("<", strategy.Score(&emptier, &total)))
+		})
+	})
+
+	Describe("SpreadScoringStrategy", func() {
+		It("scores by the most utilized resource", func() {
+			strategy := rep.SpreadScoringStrategy{Weights: rep.DefaultScoringWeights()}
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("~", 0.75, 0.0001))
+		})
+
+		It("ignores resources with a zero weight", func() {
+			strategy := rep.SpreadScoringStrategy{Weights: rep.ScoringWeights{DiskMB: 1, Containers: 1}}
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("~", 0.5, 0.0001))
+		})
+	})
+
+	Describe("MemoryDominantScoringStrategy", func() {
+		It("scores by memory alone", func() {
+			strategy := rep.MemoryDominantScoringStrategy{}
+			Expect(strategy.Score(&remaining, &total)).To(BeNumerically("~", 0.75, 0.0001))
+		})
+	})
+
+	Describe("CellState", func() {
+		It("scores with the advertised strategy", func() {
+			state := rep.CellState{
+				AvailableResources: remaining,
+				TotalResources:     total,
+				Scoring:            rep.ScoringConfig{Strategy: rep.ScoringStrategyMemoryDominant},
+			}
+			resource := rep.NewResource(250, 0, 0)
+			Expect(state.ComputeScore(&resource, 0)).To(BeNumerically("~", 1.0, 0.0001))
+		})
+
+		It("falls back to the balanced strategy for unknown strategies", func() {
+			state := rep.CellState{
+				AvailableResources: remaining,
+				TotalResources:     total,
+				Scoring:            rep.ScoringConfig{Strategy: "from-the-future"},
+			}
+			Expect(state.ScoringStrategy()).To(Equal(rep.BalancedScoringStrategy{Weights: rep.DefaultScoringWeights()}))
+		})
+	})
+})
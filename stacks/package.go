@@ -0,0 +1 @@
+package stacks // import "code.cloudfoundry.org/rep/stacks"
@@ -0,0 +1,76 @@
+package stacks
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/rep"
+)
+
+// LoadFunc reads the current stacks, for example from a stacks manifest.
+type LoadFunc func() (rep.StackPathMap, error)
+
+// Reloader swaps the stacks of a Map whenever a reload signal is received and,
+// if it has a poll interval, whenever the loaded stacks change.
+type Reloader struct {
+	logger        lager.Logger
+	stacks        *Map
+	load          LoadFunc
+	clock         clock.Clock
+	pollInterval  time.Duration
+	reloadSignals <-chan os.Signal
+}
+
+func NewReloader(
+	logger lager.Logger,
+	stacks *Map,
+	load LoadFunc,
+	clock clock.Clock,
+	pollInterval time.Duration,
+	reloadSignals <-chan os.Signal,
+) *Reloader {
+	return &Reloader{
+		logger:        logger.Session("stacks-reloader"),
+		stacks:        stacks,
+		load:          load,
+		clock:         clock,
+		pollInterval:  pollInterval,
+		reloadSignals: reloadSignals,
+	}
+}
+
+func (r *Reloader) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	var poll <-chan time.Time
+	if r.pollInterval > 0 {
+		ticker := r.clock.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+		poll = ticker.C()
+	}
+
+	close(ready)
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case sig := <-r.reloadSignals:
+			r.reload(r.logger.Session("reload", lager.Data{"signal": sig.String()}))
+		case <-poll:
+			r.reload(r.logger.Session("poll"))
+		}
+	}
+}
+
+func (r *Reloader) reload(logger lager.Logger) {
+	stackPathMap, err := r.load()
+	if err != nil {
+		logger.Error("failed-to-load-stacks", err)
+		return
+	}
+
+	if r.stacks.Swap(stackPathMap) {
+		logger.Info("swapped-stacks", lager.Data{"stacks": stackPathMap.Names()})
+	}
+}
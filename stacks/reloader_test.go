@@ -0,0 +1,97 @@
+package stacks_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/stacks"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Reloader", func() {
+	const pollInterval = 10 * time.Second
+
+	var (
+		logger        *lagertest.TestLogger
+		fakeClock     *fakeclock.FakeClock
+		stackMap      *stacks.Map
+		reloadSignals chan os.Signal
+		loadedStacks  chan rep.StackPathMap
+		loadErr       error
+		interval      time.Duration
+		process       ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		stackMap = stacks.NewMap(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}, nil)
+		reloadSignals = make(chan os.Signal)
+		loadedStacks = make(chan rep.StackPathMap, 1)
+		loadErr = nil
+		interval = pollInterval
+	})
+
+	JustBeforeEach(func() {
+		load := func() (rep.StackPathMap, error) {
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			return <-loadedStacks, nil
+		}
+		reloader := stacks.NewReloader(logger, stackMap, load, fakeClock, interval, reloadSignals)
+		process = ginkgomon.Invoke(reloader)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("swaps the stacks when it receives a reload signal", func() {
+		loadedStacks <- rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"}
+		reloadSignals <- syscall.SIGHUP
+
+		Eventually(stackMap.Stacks).Should(Equal(rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"}))
+		Eventually(logger).Should(gbytes.Say("swapped-stacks"))
+	})
+
+	It("swaps the stacks when polling finds a change", func() {
+		loadedStacks <- rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"}
+		fakeClock.WaitForWatcherAndIncrement(pollInterval)
+
+		Eventually(stackMap.Stacks).Should(Equal(rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"}))
+	})
+
+	Context("when loading the stacks fails", func() {
+		BeforeEach(func() {
+			loadErr = errors.New("boom")
+		})
+
+		It("keeps the current stacks", func() {
+			reloadSignals <- syscall.SIGHUP
+
+			Eventually(logger).Should(gbytes.Say("failed-to-load-stacks"))
+			Expect(stackMap.Stacks()).To(Equal(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}))
+		})
+	})
+
+	Context("when there is no poll interval", func() {
+		BeforeEach(func() {
+			interval = 0
+		})
+
+		It("does not poll", func() {
+			Consistently(fakeClock.WatcherCount).Should(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,232 @@
+package stacks
+
+import (
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/rep"
+)
+
+// VerifyFunc returns an error if a stack cannot be used from the given path.
+type VerifyFunc func(path string) error
+
+// VerifyPath checks that the path exists and can be opened for reading.
+func VerifyPath(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// SizeFunc measures the size in bytes of the rootfs at the given path.
+type SizeFunc func(path string) (uint64, error)
+
+// Map is a rep.StackSource whose stacks can be swapped while the rep is
+// running. Every consumer holding the Map sees the new stacks as soon as Swap
+// returns.
+//
+// If the Map has a VerifyFunc, only the stacks whose paths pass verification
+// are provided to consumers, and the health of every configured stack is
+// reported through StackHealth.
+type Map struct {
+	lock          sync.RWMutex
+	verify        VerifyFunc
+	size          SizeFunc
+	executorPaths map[string]bool
+	configured    rep.StackPathMap
+	health        map[string]rep.StackHealth
+	healthy       rep.StackPathMap
+	diskMB        map[string]int
+	subscribers   []chan struct{}
+}
+
+func NewMap(stackPathMap rep.StackPathMap, verify VerifyFunc) *Map {
+	m := &Map{verify: verify, configured: stackPathMap}
+	m.health, m.healthy, m.diskMB = m.check(stackPathMap)
+	return m
+}
+
+// NewExecutorMap returns a Map for the stacks the executor was started with.
+// The executor adds the size of a preloaded rootfs to the disk limit of its
+// containers, but it only measures the rootfses it was started with, so the
+// Map measures the path of every stack added later with size when it is
+// verified, and reports that size through StackDiskMB. A stack whose path
+// cannot be measured is unhealthy.
+func NewExecutorMap(stackPathMap rep.StackPathMap, verify VerifyFunc, size SizeFunc) *Map {
+	executorPaths := make(map[string]bool, len(stackPathMap))
+	for _, path := range stackPathMap {
+		executorPaths[path] = true
+	}
+
+	m := &Map{verify: verify, size: size, executorPaths: executorPaths, configured: stackPathMap}
+	m.health, m.healthy, m.diskMB = m.check(stackPathMap)
+	return m
+}
+
+// Stacks returns the configured stacks that passed their last verification.
+func (m *Map) Stacks() rep.StackPathMap {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.healthy
+}
+
+// StackHealth returns the health of every configured stack, or nil if the Map
+// does not verify its stacks.
+func (m *Map) StackHealth() map[string]rep.StackHealth {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.health
+}
+
+// StackDiskMB returns the disk in MB to add to the disk of a container on the
+// stack at path, which is the size of its rootfs if the executor was not
+// started with it.
+func (m *Map) StackDiskMB(path string) int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.diskMB[path]
+}
+
+// Swap replaces the configured stacks, verifying them, and notifies the
+// subscribers if the provided stacks change. It returns false if the
+// configured stacks are unchanged.
+func (m *Map) Swap(stackPathMap rep.StackPathMap) bool {
+	if equal(m.configuredStacks(), stackPathMap) {
+		return false
+	}
+
+	health, healthy, diskMB := m.check(stackPathMap)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.configured = stackPathMap
+	m.update(health, healthy, diskMB)
+	return true
+}
+
+// Verify re-verifies the configured stacks and notifies the subscribers if
+// the provided stacks change. It returns the stacks that failed verification.
+func (m *Map) Verify() map[string]rep.StackHealth {
+	configured := m.configuredStacks()
+	health, healthy, diskMB := m.check(configured)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !equal(m.configured, configured) {
+		// the stacks were swapped while they were being verified
+		return nil
+	}
+	m.update(health, healthy, diskMB)
+
+	unhealthy := map[string]rep.StackHealth{}
+	for name, stackHealth := range health {
+		if !stackHealth.Healthy {
+			unhealthy[name] = stackHealth
+		}
+	}
+	return unhealthy
+}
+
+// Subscribe returns a channel that receives a value after the provided stacks
+// change. Changes that happen before the previous value is received are
+// coalesced.
+func (m *Map) Subscribe() <-chan struct{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	subscriber := make(chan struct{}, 1)
+	m.subscribers = append(m.subscribers, subscriber)
+	return subscriber
+}
+
+func (m *Map) configuredStacks() rep.StackPathMap {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.configured
+}
+
+// check verifies the paths of the given stacks without holding the lock, as
+// reading the paths may be slow. The paths the executor was not started with
+// are measured once, and keep their size for as long as they are configured.
+func (m *Map) check(stackPathMap rep.StackPathMap) (map[string]rep.StackHealth, rep.StackPathMap, map[string]int) {
+	if m.verify == nil {
+		return nil, stackPathMap, nil
+	}
+
+	measured := m.measuredDiskMB()
+	health := make(map[string]rep.StackHealth, len(stackPathMap))
+	healthy := make(rep.StackPathMap, len(stackPathMap))
+	diskMB := map[string]int{}
+	for name, path := range stackPathMap {
+		stackHealth := rep.StackHealth{Path: path, Healthy: true}
+		err := m.verify(path)
+		if err == nil && m.size != nil && !m.executorPaths[path] {
+			size, ok := measured[path]
+			if !ok {
+				size, err = m.measure(path)
+			}
+			if err == nil {
+				diskMB[path] = size
+			}
+		}
+		if err != nil {
+			stackHealth.Healthy = false
+			stackHealth.Error = err.Error()
+		} else {
+			healthy[name] = path
+		}
+		health[name] = stackHealth
+	}
+	return health, healthy, diskMB
+}
+
+func (m *Map) measuredDiskMB() map[string]int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.diskMB
+}
+
+func (m *Map) measure(path string) (int, error) {
+	size, err := m.size(path)
+	if err != nil {
+		return 0, err
+	}
+	return int((size + 1024*1024 - 1) / (1024 * 1024)), nil
+}
+
+// update must be called with the lock held.
+func (m *Map) update(health map[string]rep.StackHealth, healthy rep.StackPathMap, diskMB map[string]int) {
+	m.health = health
+	m.diskMB = diskMB
+	if equal(m.healthy, healthy) {
+		return
+	}
+	m.healthy = healthy
+
+	for _, subscriber := range m.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func equal(a, b rep.StackPathMap) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, path := range a {
+		if otherPath, ok := b[name]; !ok || otherPath != path {
+			return false
+		}
+	}
+	return true
+}
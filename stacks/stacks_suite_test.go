@@ -0,0 +1,13 @@
+package stacks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestStacks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Stacks Suite")
+}
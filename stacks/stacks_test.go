@@ -0,0 +1,230 @@
+package stacks_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/stacks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Map", func() {
+	var stackMap *stacks.Map
+
+	BeforeEach(func() {
+		stackMap = stacks.NewMap(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}, nil)
+	})
+
+	It("provides the initial stacks", func() {
+		Expect(stackMap.Stacks()).To(Equal(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}))
+	})
+
+	Describe("Swap", func() {
+		var changed <-chan struct{}
+
+		BeforeEach(func() {
+			changed = stackMap.Subscribe()
+		})
+
+		It("replaces the stacks and notifies subscribers", func() {
+			newStacks := rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3", "cflinuxfs4": "/path/to/cflinuxfs4"}
+			Expect(stackMap.Swap(newStacks)).To(BeTrue())
+
+			Expect(stackMap.Stacks()).To(Equal(newStacks))
+			Expect(changed).To(Receive())
+		})
+
+		It("coalesces notifications that have not been received", func() {
+			Expect(stackMap.Swap(rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"})).To(BeTrue())
+			Expect(stackMap.Swap(rep.StackPathMap{"cflinuxfs5": "/path/to/cflinuxfs5"})).To(BeTrue())
+
+			Expect(changed).To(Receive())
+			Expect(changed).NotTo(Receive())
+		})
+
+		It("does nothing when the stacks are unchanged", func() {
+			Expect(stackMap.Swap(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"})).To(BeFalse())
+			Expect(changed).NotTo(Receive())
+		})
+
+		It("detects a changed path", func() {
+			Expect(stackMap.Swap(rep.StackPathMap{"cflinuxfs3": "/new/path"})).To(BeTrue())
+			Expect(changed).To(Receive())
+		})
+	})
+
+	Describe("verification", func() {
+		var (
+			missing map[string]bool
+			changed <-chan struct{}
+		)
+
+		BeforeEach(func() {
+			missing = map[string]bool{"/path/to/cflinuxfs4": true}
+			verify := func(path string) error {
+				if missing[path] {
+					return errors.New("no such file or directory")
+				}
+				return nil
+			}
+			stackMap = stacks.NewMap(rep.StackPathMap{
+				"cflinuxfs3": "/path/to/cflinuxfs3",
+				"cflinuxfs4": "/path/to/cflinuxfs4",
+			}, verify)
+			changed = stackMap.Subscribe()
+		})
+
+		It("only provides the stacks that pass verification", func() {
+			Expect(stackMap.Stacks()).To(Equal(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}))
+		})
+
+		It("reports the health of every stack", func() {
+			Expect(stackMap.StackHealth()).To(Equal(map[string]rep.StackHealth{
+				"cflinuxfs3": {Path: "/path/to/cflinuxfs3", Healthy: true},
+				"cflinuxfs4": {Path: "/path/to/cflinuxfs4", Healthy: false, Error: "no such file or directory"},
+			}))
+		})
+
+		It("verifies swapped stacks", func() {
+			stackMap.Swap(rep.StackPathMap{"cflinuxfs4": "/path/to/cflinuxfs4"})
+			Expect(stackMap.Stacks()).To(BeEmpty())
+			Expect(changed).To(Receive())
+		})
+
+		Describe("Verify", func() {
+			It("provides stacks whose paths reappear", func() {
+				missing = map[string]bool{}
+
+				Expect(stackMap.Verify()).To(BeEmpty())
+				Expect(stackMap.Stacks()).To(HaveLen(2))
+				Expect(changed).To(Receive())
+			})
+
+			It("stops providing stacks whose paths disappear", func() {
+				missing["/path/to/cflinuxfs3"] = true
+
+				Expect(stackMap.Verify()).To(HaveKey("cflinuxfs3"))
+				Expect(stackMap.Stacks()).To(BeEmpty())
+				Expect(changed).To(Receive())
+			})
+
+			It("does not notify subscribers when nothing changes", func() {
+				Expect(stackMap.Verify()).To(HaveKey("cflinuxfs4"))
+				Expect(changed).NotTo(Receive())
+			})
+		})
+	})
+
+	Describe("VerifyPath", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "stacks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("succeeds for a readable path", func() {
+			Expect(stacks.VerifyPath(dir)).To(Succeed())
+		})
+
+		It("fails for a missing path", func() {
+			Expect(stacks.VerifyPath(filepath.Join(dir, "missing"))).NotTo(Succeed())
+		})
+	})
+
+	Describe("NewExecutorMap", func() {
+		var (
+			measured []string
+			sizeErr  error
+			changed  <-chan struct{}
+		)
+
+		BeforeEach(func() {
+			measured = nil
+			sizeErr = nil
+			stackMap = stacks.NewExecutorMap(
+				rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"},
+				func(string) error { return nil },
+				func(path string) (uint64, error) {
+					measured = append(measured, path)
+					return 200*1024*1024 + 1, sizeErr
+				},
+			)
+			changed = stackMap.Subscribe()
+		})
+
+		It("does not measure the paths the executor was started with", func() {
+			Expect(measured).To(BeEmpty())
+			Expect(stackMap.StackDiskMB("/path/to/cflinuxfs3")).To(Equal(0))
+		})
+
+		Context("when a stack with a new path is swapped in", func() {
+			BeforeEach(func() {
+				stackMap.Swap(rep.StackPathMap{
+					"cflinuxfs3": "/path/to/cflinuxfs3",
+					"cflinuxfs4": "/path/to/cflinuxfs4",
+				})
+			})
+
+			It("provides the new stack", func() {
+				Expect(stackMap.Stacks()).To(HaveKeyWithValue("cflinuxfs4", "/path/to/cflinuxfs4"))
+				Expect(changed).To(Receive())
+			})
+
+			It("reports the size of its rootfs in whole MB", func() {
+				Expect(measured).To(ConsistOf("/path/to/cflinuxfs4"))
+				Expect(stackMap.StackDiskMB("/path/to/cflinuxfs4")).To(Equal(201))
+			})
+
+			It("measures the path only once", func() {
+				stackMap.Verify()
+				Expect(measured).To(HaveLen(1))
+				Expect(stackMap.StackDiskMB("/path/to/cflinuxfs4")).To(Equal(201))
+			})
+
+			Context("and then removed", func() {
+				BeforeEach(func() {
+					stackMap.Swap(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"})
+				})
+
+				It("forgets its size", func() {
+					Expect(stackMap.StackDiskMB("/path/to/cflinuxfs4")).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the new path cannot be measured", func() {
+			BeforeEach(func() {
+				sizeErr = errors.New("garden is down")
+				stackMap.Swap(rep.StackPathMap{
+					"cflinuxfs3": "/path/to/cflinuxfs3",
+					"cflinuxfs4": "/path/to/cflinuxfs4",
+				})
+			})
+
+			It("does not provide the stack", func() {
+				Expect(stackMap.Stacks()).To(Equal(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}))
+				Expect(stackMap.StackHealth()["cflinuxfs4"]).To(Equal(rep.StackHealth{
+					Path:  "/path/to/cflinuxfs4",
+					Error: "garden is down",
+				}))
+			})
+
+			It("measures it again on the next verification", func() {
+				sizeErr = nil
+				Expect(stackMap.Verify()).To(BeEmpty())
+				Expect(stackMap.Stacks()).To(HaveKey("cflinuxfs4"))
+				Expect(stackMap.StackDiskMB("/path/to/cflinuxfs4")).To(Equal(201))
+			})
+		})
+	})
+})
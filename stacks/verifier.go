@@ -0,0 +1,50 @@
+package stacks
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+// DefaultVerifyInterval is how often the paths of the stacks are verified when
+// the rep is not configured with a verification interval.
+const DefaultVerifyInterval = time.Minute
+
+// Verifier periodically re-verifies the stacks of a Map, so that stacks whose
+// paths disappear stop being advertised and stacks whose paths reappear are
+// advertised again.
+type Verifier struct {
+	logger   lager.Logger
+	stacks   *Map
+	clock    clock.Clock
+	interval time.Duration
+}
+
+func NewVerifier(logger lager.Logger, stacks *Map, clock clock.Clock, interval time.Duration) *Verifier {
+	return &Verifier{
+		logger:   logger.Session("stacks-verifier"),
+		stacks:   stacks,
+		clock:    clock,
+		interval: interval,
+	}
+}
+
+func (v *Verifier) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ticker := v.clock.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C():
+			for name, health := range v.stacks.Verify() {
+				v.logger.Error("unhealthy-stack", nil, lager.Data{"stack": name, "path": health.Path, "error": health.Error})
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package stacks_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/rep"
+	"code.cloudfoundry.org/rep/stacks"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Verifier", func() {
+	const interval = time.Minute
+
+	var (
+		logger    *lagertest.TestLogger
+		fakeClock *fakeclock.FakeClock
+		missing   chan bool
+		stackMap  *stacks.Map
+		process   ifrit.Process
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		missing = make(chan bool, 1)
+
+		verify := func(string) error {
+			select {
+			case m := <-missing:
+				if m {
+					return errors.New("no such file or directory")
+				}
+			default:
+			}
+			return nil
+		}
+		stackMap = stacks.NewMap(rep.StackPathMap{"cflinuxfs3": "/path/to/cflinuxfs3"}, verify)
+
+		process = ginkgomon.Invoke(stacks.NewVerifier(logger, stackMap, fakeClock, interval))
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("re-verifies the stacks on every interval", func() {
+		missing <- true
+		fakeClock.WaitForWatcherAndIncrement(interval)
+
+		Eventually(stackMap.Stacks).Should(BeEmpty())
+		Eventually(logger).Should(gbytes.Say("unhealthy-stack.*cflinuxfs3"))
+
+		fakeClock.WaitForWatcherAndIncrement(interval)
+		Eventually(stackMap.Stacks).Should(HaveKey("cflinuxfs3"))
+	})
+})
@@ -0,0 +1,267 @@
+package rep
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+type StateEventType string
+
+const (
+	StateEventSnapshot          StateEventType = "snapshot"
+	StateEventLRPAdded          StateEventType = "lrp_added"
+	StateEventLRPChanged        StateEventType = "lrp_changed"
+	StateEventLRPRemoved        StateEventType = "lrp_removed"
+	StateEventTaskAdded         StateEventType = "task_added"
+	StateEventTaskChanged       StateEventType = "task_changed"
+	StateEventTaskRemoved       StateEventType = "task_removed"
+	StateEventResourcesChanged  StateEventType = "resources_changed"
+	StateEventEvacuatingChanged StateEventType = "evacuating_changed"
+)
+
+// StateEvent is an entry in a cell's state stream. The stream starts with a
+// snapshot of the whole CellState, followed by the changes to it. A snapshot
+// is sent again whenever a part of the state that has no event of its own
+// changes.
+type StateEvent struct {
+	Type       StateEventType  `json:"type"`
+	State      *CellState      `json:"state,omitempty"`
+	LRP        *LRP            `json:"lrp,omitempty"`
+	Task       *Task           `json:"task,omitempty"`
+	Resources  *StateResources `json:"resources,omitempty"`
+	Evacuating bool            `json:"evacuating,omitempty"`
+}
+
+// StateResources is the part of the CellState that changes whenever
+// containers are created or destroyed.
+type StateResources struct {
+	AvailableResources     Resources              `json:"available_resources"`
+	TotalResources         Resources              `json:"total_resources"`
+	StartingContainerCount int                    `json:"starting_container_count"`
+	DomainUsage            map[string]DomainUsage `json:"domain_usage,omitempty"`
+}
+
+func NewStateSnapshotEvent(state CellState) StateEvent {
+	return StateEvent{Type: StateEventSnapshot, State: &state}
+}
+
+// DiffCellStates returns the events that turn the previous state into the
+// current one when applied in order.
+func DiffCellStates(previous, current CellState) []StateEvent {
+	if !reflect.DeepEqual(withoutStreamedFields(previous), withoutStreamedFields(current)) {
+		return []StateEvent{NewStateSnapshotEvent(current)}
+	}
+
+	events := []StateEvent{}
+
+	if previous.Evacuating != current.Evacuating {
+		events = append(events, StateEvent{Type: StateEventEvacuatingChanged, Evacuating: current.Evacuating})
+	}
+
+	previousResources, currentResources := stateResources(previous), stateResources(current)
+	if !reflect.DeepEqual(previousResources, currentResources) {
+		events = append(events, StateEvent{Type: StateEventResourcesChanged, Resources: &currentResources})
+	}
+
+	previousLRPs := make(map[string]LRP, len(previous.LRPs))
+	for _, lrp := range previous.LRPs {
+		previousLRPs[lrp.InstanceGUID] = lrp
+	}
+	currentLRPs := make(map[string]struct{}, len(current.LRPs))
+	for i := range current.LRPs {
+		lrp := current.LRPs[i]
+		currentLRPs[lrp.InstanceGUID] = struct{}{}
+		previousLRP, found := previousLRPs[lrp.InstanceGUID]
+		if !found {
+			events = append(events, StateEvent{Type: StateEventLRPAdded, LRP: &lrp})
+		} else if !reflect.DeepEqual(previousLRP, lrp) {
+			events = append(events, StateEvent{Type: StateEventLRPChanged, LRP: &lrp})
+		}
+	}
+	for i := range previous.LRPs {
+		lrp := previous.LRPs[i]
+		if _, found := currentLRPs[lrp.InstanceGUID]; !found {
+			events = append(events, StateEvent{Type: StateEventLRPRemoved, LRP: &lrp})
+		}
+	}
+
+	previousTasks := make(map[string]Task, len(previous.Tasks))
+	for _, task := range previous.Tasks {
+		previousTasks[task.TaskGuid] = task
+	}
+	currentTasks := make(map[string]struct{}, len(current.Tasks))
+	for i := range current.Tasks {
+		task := current.Tasks[i]
+		currentTasks[task.TaskGuid] = struct{}{}
+		previousTask, found := previousTasks[task.TaskGuid]
+		if !found {
+			events = append(events, StateEvent{Type: StateEventTaskAdded, Task: &task})
+		} else if !reflect.DeepEqual(previousTask, task) {
+			events = append(events, StateEvent{Type: StateEventTaskChanged, Task: &task})
+		}
+	}
+	for i := range previous.Tasks {
+		task := previous.Tasks[i]
+		if _, found := currentTasks[task.TaskGuid]; !found {
+			events = append(events, StateEvent{Type: StateEventTaskRemoved, Task: &task})
+		}
+	}
+
+	return events
+}
+
+// Apply updates the state with an event from a cell's state stream. Events
+// of unknown types are ignored.
+func (c *CellState) Apply(event StateEvent) {
+	switch event.Type {
+	case StateEventSnapshot:
+		if event.State != nil {
+			*c = *event.State
+		}
+	case StateEventEvacuatingChanged:
+		c.Evacuating = event.Evacuating
+	case StateEventResourcesChanged:
+		if event.Resources != nil {
+			c.AvailableResources = event.Resources.AvailableResources
+			c.TotalResources = event.Resources.TotalResources
+			c.StartingContainerCount = event.Resources.StartingContainerCount
+			c.DomainUsage = event.Resources.DomainUsage
+		}
+	case StateEventLRPAdded, StateEventLRPChanged, StateEventLRPRemoved:
+		if event.LRP == nil {
+			return
+		}
+		lrps := make([]LRP, 0, len(c.LRPs)+1)
+		for _, lrp := range c.LRPs {
+			if lrp.InstanceGUID != event.LRP.InstanceGUID {
+				lrps = append(lrps, lrp)
+			}
+		}
+		if event.Type != StateEventLRPRemoved {
+			lrps = append(lrps, *event.LRP)
+		}
+		c.LRPs = lrps
+	case StateEventTaskAdded, StateEventTaskChanged, StateEventTaskRemoved:
+		if event.Task == nil {
+			return
+		}
+		tasks := make([]Task, 0, len(c.Tasks)+1)
+		for _, task := range c.Tasks {
+			if task.TaskGuid != event.Task.TaskGuid {
+				tasks = append(tasks, task)
+			}
+		}
+		if event.Type != StateEventTaskRemoved {
+			tasks = append(tasks, *event.Task)
+		}
+		c.Tasks = tasks
+	}
+}
+
+func stateResources(state CellState) StateResources {
+	return StateResources{
+		AvailableResources:     state.AvailableResources,
+		TotalResources:         state.TotalResources,
+		StartingContainerCount: state.StartingContainerCount,
+		DomainUsage:            state.DomainUsage,
+	}
+}
+
+// withoutStreamedFields clears the fields of the state that have events of
+// their own, along with the generation, which changes with every event.
+func withoutStreamedFields(state CellState) CellState {
+	state.Generation = 0
+	state.Evacuating = false
+	state.AvailableResources = Resources{}
+	state.TotalResources = Resources{}
+	state.StartingContainerCount = 0
+	state.DomainUsage = nil
+	state.LRPs = nil
+	state.Tasks = nil
+	return state
+}
+
+// WriteStateEvent writes the event to a server-sent event stream.
+func WriteStateEvent(w io.Writer, event StateEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+	return err
+}
+
+// StateEventSource reads the events of a cell's state stream. Next returns
+// io.EOF once the cell has closed the stream.
+type StateEventSource interface {
+	Next() (StateEvent, error)
+	Close() error
+}
+
+type stateEventSource struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+func NewStateEventSource(body io.ReadCloser) StateEventSource {
+	return &stateEventSource{body: body, reader: bufio.NewReader(body)}
+}
+
+func (s *stateEventSource) Next() (StateEvent, error) {
+	var eventType string
+	var data bytes.Buffer
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line != "" {
+				err = io.ErrUnexpectedEOF
+			}
+			return StateEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if data.Len() == 0 {
+				continue
+			}
+			break
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+
+	var event StateEvent
+	err := json.Unmarshal(data.Bytes(), &event)
+	if err != nil {
+		return StateEvent{}, err
+	}
+	if event.Type == "" {
+		event.Type = StateEventType(eventType)
+	}
+
+	return event, nil
+}
+
+func (s *stateEventSource) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,179 @@
+package rep_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateEvents", func() {
+	var previous, current rep.CellState
+
+	newLRP := func(instanceGuid, state string) rep.LRP {
+		lrp := rep.NewLRP(instanceGuid, models.NewActualLRPKey("pg-"+instanceGuid, 0, "domain"), rep.NewResource(10, 20, 0), rep.PlacementConstraint{})
+		lrp.State = state
+		return lrp
+	}
+
+	newTask := func(taskGuid string, state models.Task_State) rep.Task {
+		task := rep.NewTask(taskGuid, "domain", rep.NewResource(10, 20, 0), rep.PlacementConstraint{})
+		task.State = state
+		return task
+	}
+
+	BeforeEach(func() {
+		previous = rep.CellState{
+			CellID:             "cell-id",
+			AvailableResources: rep.NewResources(100, 200, 10),
+			TotalResources:     rep.NewResources(100, 200, 10),
+			LRPs: []rep.LRP{
+				newLRP("ig-1", models.ActualLRPStateClaimed),
+				newLRP("ig-2", models.ActualLRPStateRunning),
+			},
+			Tasks: []rep.Task{
+				newTask("task-1", models.Task_Running),
+			},
+		}
+
+		current = previous
+		current.LRPs = append([]rep.LRP{}, previous.LRPs...)
+		current.Tasks = append([]rep.Task{}, previous.Tasks...)
+	})
+
+	Describe("DiffCellStates", func() {
+		It("returns no events when nothing changed", func() {
+			Expect(rep.DiffCellStates(previous, current)).To(BeEmpty())
+		})
+
+		It("returns events for added, changed and removed LRPs", func() {
+			current.LRPs = []rep.LRP{
+				newLRP("ig-1", models.ActualLRPStateRunning),
+				newLRP("ig-3", models.ActualLRPStateClaimed),
+			}
+
+			events := rep.DiffCellStates(previous, current)
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Type).To(Equal(rep.StateEventLRPChanged))
+			Expect(events[0].LRP.InstanceGUID).To(Equal("ig-1"))
+			Expect(events[0].LRP.State).To(Equal(models.ActualLRPStateRunning))
+			Expect(events[1].Type).To(Equal(rep.StateEventLRPAdded))
+			Expect(events[1].LRP.InstanceGUID).To(Equal("ig-3"))
+			Expect(events[2].Type).To(Equal(rep.StateEventLRPRemoved))
+			Expect(events[2].LRP.InstanceGUID).To(Equal("ig-2"))
+		})
+
+		It("returns events for added, changed and removed tasks", func() {
+			current.Tasks = []rep.Task{newTask("task-2", models.Task_Running)}
+			events := rep.DiffCellStates(previous, current)
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Type).To(Equal(rep.StateEventTaskAdded))
+			Expect(events[1].Type).To(Equal(rep.StateEventTaskRemoved))
+
+			current.Tasks = []rep.Task{newTask("task-1", models.Task_Completed)}
+			events = rep.DiffCellStates(previous, current)
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Type).To(Equal(rep.StateEventTaskChanged))
+			Expect(events[0].Task.State).To(Equal(models.Task_Completed))
+		})
+
+		It("returns an event when the resources change", func() {
+			current.AvailableResources = rep.NewResources(50, 100, 9)
+			current.StartingContainerCount = 1
+
+			events := rep.DiffCellStates(previous, current)
+			Expect(events).To(ConsistOf(rep.StateEvent{
+				Type: rep.StateEventResourcesChanged,
+				Resources: &rep.StateResources{
+					AvailableResources:     rep.NewResources(50, 100, 9),
+					TotalResources:         rep.NewResources(100, 200, 10),
+					StartingContainerCount: 1,
+				},
+			}))
+		})
+
+		It("returns an event when the cell starts evacuating", func() {
+			current.Evacuating = true
+			Expect(rep.DiffCellStates(previous, current)).To(ConsistOf(rep.StateEvent{
+				Type:       rep.StateEventEvacuatingChanged,
+				Evacuating: true,
+			}))
+		})
+
+		It("returns a snapshot when anything else changes", func() {
+			current.PlacementTags = []string{"new-tag"}
+			current.Evacuating = true
+			Expect(rep.DiffCellStates(previous, current)).To(ConsistOf(rep.NewStateSnapshotEvent(current)))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("turns the previous state into the current one", func() {
+			current.LRPs = []rep.LRP{
+				newLRP("ig-3", models.ActualLRPStateClaimed),
+				newLRP("ig-1", models.ActualLRPStateRunning),
+			}
+			current.Tasks = nil
+			current.AvailableResources = rep.NewResources(50, 100, 9)
+			current.Evacuating = true
+
+			state := previous
+			for _, event := range rep.DiffCellStates(previous, current) {
+				state.Apply(event)
+			}
+
+			Expect(state.LRPs).To(ConsistOf(current.LRPs))
+			Expect(state.Tasks).To(BeEmpty())
+			Expect(state.AvailableResources).To(Equal(current.AvailableResources))
+			Expect(state.Evacuating).To(BeTrue())
+		})
+
+		It("replaces the state with a snapshot", func() {
+			state := rep.CellState{}
+			state.Apply(rep.NewStateSnapshotEvent(previous))
+			Expect(state).To(Equal(previous))
+		})
+	})
+
+	Describe("the event stream", func() {
+		It("reads the events that were written", func() {
+			events := []rep.StateEvent{
+				rep.NewStateSnapshotEvent(previous),
+				{Type: rep.StateEventEvacuatingChanged, Evacuating: true},
+			}
+
+			buffer := &bytes.Buffer{}
+			for _, event := range events {
+				Expect(rep.WriteStateEvent(buffer, event)).To(Succeed())
+			}
+			Expect(buffer.String()).To(ContainSubstring("event: evacuating_changed\ndata: {\"type\":\"evacuating_changed\",\"evacuating\":true}\n\n"))
+
+			source := rep.NewStateEventSource(ioutil.NopCloser(buffer))
+			event, err := source.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Type).To(Equal(rep.StateEventSnapshot))
+			Expect(event.State.LRPs).To(ConsistOf(previous.LRPs))
+
+			event, err = source.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event).To(Equal(events[1]))
+
+			_, err = source.Next()
+			Expect(err).To(Equal(io.EOF))
+		})
+
+		It("ignores comments and takes the type from the event field", func() {
+			body := ": keepalive\n\nevent: evacuating_changed\ndata: {\"evacuating\":true}\n\n"
+			source := rep.NewStateEventSource(ioutil.NopCloser(bytes.NewBufferString(body)))
+
+			event, err := source.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event).To(Equal(rep.StateEvent{Type: rep.StateEventEvacuatingChanged, Evacuating: true}))
+		})
+	})
+})
@@ -0,0 +1,191 @@
+package rep
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	StateIncludeLRPs  = "lrps"
+	StateIncludeTasks = "tasks"
+)
+
+// StateQuery narrows down the CellState returned by a cell. The zero query
+// returns the whole state.
+type StateQuery struct {
+	// Summary returns the state without any LRPs or tasks unless they are
+	// included.
+	Summary bool
+	// Include lists the collections to return, among StateIncludeLRPs and
+	// StateIncludeTasks. Nil includes both, or neither in a summary.
+	Include []string
+	// Domain only returns the LRPs and tasks of the given domain.
+	Domain string
+	// ProcessGuid only returns the LRPs of the given process. Tasks have no
+	// process guid, so none are returned.
+	ProcessGuid string
+	// Offset and Limit page through the LRPs and the tasks, which are each
+	// sorted by guid. A zero limit returns everything after the offset.
+	Offset int
+	Limit  int
+}
+
+// ParseStateQuery parses the query parameters of a state request.
+func ParseStateQuery(values url.Values) (StateQuery, error) {
+	var query StateQuery
+	var err error
+
+	if summary := values.Get("summary"); summary != "" {
+		query.Summary, err = strconv.ParseBool(summary)
+		if err != nil {
+			return StateQuery{}, fmt.Errorf("invalid summary: %q", summary)
+		}
+	}
+
+	if include, ok := values["include"]; ok {
+		query.Include = []string{}
+		for _, value := range include {
+			for _, collection := range strings.Split(value, ",") {
+				switch collection {
+				case StateIncludeLRPs, StateIncludeTasks:
+					query.Include = append(query.Include, collection)
+				case "":
+				default:
+					return StateQuery{}, fmt.Errorf("invalid include: %q", collection)
+				}
+			}
+		}
+	}
+
+	query.Domain = values.Get("domain")
+	query.ProcessGuid = values.Get("process_guid")
+
+	query.Offset, err = parseNonNegative(values, "offset")
+	if err != nil {
+		return StateQuery{}, err
+	}
+	query.Limit, err = parseNonNegative(values, "limit")
+	if err != nil {
+		return StateQuery{}, err
+	}
+
+	return query, nil
+}
+
+func parseNonNegative(values url.Values, name string) (int, error) {
+	value := values.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return n, nil
+}
+
+// Values returns the query parameters of the query.
+func (q StateQuery) Values() url.Values {
+	values := url.Values{}
+	if q.Summary {
+		values.Set("summary", "true")
+	}
+	if q.Include != nil {
+		values.Set("include", strings.Join(q.Include, ","))
+	}
+	if q.Domain != "" {
+		values.Set("domain", q.Domain)
+	}
+	if q.ProcessGuid != "" {
+		values.Set("process_guid", q.ProcessGuid)
+	}
+	if q.Offset != 0 {
+		values.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Limit != 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return values
+}
+
+func (q StateQuery) IsZero() bool {
+	return len(q.Values()) == 0
+}
+
+// ETag returns the entity tag of the state filtered by the query. It differs
+// from the tag of the whole state, so that cached responses to different
+// queries are not confused.
+func (q StateQuery) ETag(generation uint64) string {
+	if q.IsZero() {
+		return StateETag(generation)
+	}
+	return fmt.Sprintf(`"%d-%s"`, generation, q.Values().Encode())
+}
+
+func (q StateQuery) includes(collection string) bool {
+	if q.Include == nil {
+		return !q.Summary
+	}
+	for _, included := range q.Include {
+		if included == collection {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the part of the state selected by the query. A summary only
+// leaves out the LRPs and tasks: the auctioneer still needs the rest of the
+// state to match and score the cell.
+func (c CellState) Filter(query StateQuery) CellState {
+	filtered := c
+
+	filtered.LRPs = nil
+	if query.includes(StateIncludeLRPs) {
+		lrps := []LRP{}
+		for _, lrp := range c.LRPs {
+			if query.Domain != "" && lrp.Domain != query.Domain {
+				continue
+			}
+			if query.ProcessGuid != "" && lrp.ProcessGuid != query.ProcessGuid {
+				continue
+			}
+			lrps = append(lrps, lrp)
+		}
+		start, end := query.page(len(lrps))
+		filtered.LRPs = lrps[start:end]
+	}
+
+	filtered.Tasks = nil
+	if query.includes(StateIncludeTasks) {
+		tasks := []Task{}
+		for _, task := range c.Tasks {
+			if query.Domain != "" && task.Domain != query.Domain {
+				continue
+			}
+			if query.ProcessGuid != "" {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		start, end := query.page(len(tasks))
+		filtered.Tasks = tasks[start:end]
+	}
+
+	return filtered
+}
+
+func (q StateQuery) page(n int) (int, int) {
+	start := q.Offset
+	if start > n {
+		start = n
+	}
+	end := n
+	if q.Limit > 0 && start+q.Limit < n {
+		end = start + q.Limit
+	}
+	return start, end
+}
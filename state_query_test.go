@@ -0,0 +1,184 @@
+package rep_test
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateQuery", func() {
+	Describe("ParseStateQuery", func() {
+		It("parses every parameter", func() {
+			query, err := rep.ParseStateQuery(url.Values{
+				"summary":      []string{"true"},
+				"include":      []string{"lrps,tasks"},
+				"domain":       []string{"cf-apps"},
+				"process_guid": []string{"pg-1"},
+				"offset":       []string{"10"},
+				"limit":        []string{"5"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query).To(Equal(rep.StateQuery{
+				Summary:     true,
+				Include:     []string{rep.StateIncludeLRPs, rep.StateIncludeTasks},
+				Domain:      "cf-apps",
+				ProcessGuid: "pg-1",
+				Offset:      10,
+				Limit:       5,
+			}))
+		})
+
+		It("returns the zero query without parameters", func() {
+			query, err := rep.ParseStateQuery(url.Values{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query.IsZero()).To(BeTrue())
+		})
+
+		It("round-trips through Values", func() {
+			query := rep.StateQuery{Summary: true, Include: []string{}, Domain: "cf-apps", Limit: 5}
+
+			parsed, err := rep.ParseStateQuery(query.Values())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(query))
+		})
+
+		It("rejects invalid parameters", func() {
+			invalid := map[string]string{
+				"summary": `invalid summary: "maybe"`,
+				"include": `invalid include: "volumes"`,
+				"offset":  `invalid offset: "-1"`,
+				"limit":   `invalid limit: "ten"`,
+			}
+			values := map[string]string{
+				"summary": "maybe",
+				"include": "lrps,volumes",
+				"offset":  "-1",
+				"limit":   "ten",
+			}
+			for name, message := range invalid {
+				_, err := rep.ParseStateQuery(url.Values{name: []string{values[name]}})
+				Expect(err).To(MatchError(message))
+			}
+		})
+	})
+
+	Describe("ETag", func() {
+		It("is the state's tag for the zero query", func() {
+			Expect(rep.StateQuery{}.ETag(42)).To(Equal(rep.StateETag(42)))
+		})
+
+		It("differs for other queries", func() {
+			summaryTag := rep.StateQuery{Summary: true}.ETag(42)
+			Expect(summaryTag).NotTo(Equal(rep.StateETag(42)))
+			Expect(summaryTag).NotTo(Equal(rep.StateQuery{Domain: "cf-apps"}.ETag(42)))
+			Expect(summaryTag).To(HavePrefix(`"42`))
+			Expect(summaryTag).To(HaveSuffix(`"`))
+		})
+	})
+
+	Describe("Filter", func() {
+		var state rep.CellState
+
+		newLRP := func(instanceGuid, processGuid, domain string) rep.LRP {
+			return rep.NewLRP(instanceGuid, models.NewActualLRPKey(processGuid, 0, domain), rep.NewResource(10, 20, 0), rep.PlacementConstraint{})
+		}
+
+		newTask := func(taskGuid, domain string) rep.Task {
+			return rep.NewTask(taskGuid, domain, rep.NewResource(10, 20, 0), rep.PlacementConstraint{})
+		}
+
+		BeforeEach(func() {
+			state = rep.CellState{
+				RepURL:                  "https://cell-1:1801",
+				CellID:                  "cell-1",
+				CellIndex:               1,
+				RootFSProviders:         rep.RootFSProviders{"docker": rep.ArbitraryRootFSProvider{}},
+				AvailableResources:      rep.NewResources(100, 200, 10),
+				TotalResources:          rep.NewResources(200, 400, 20),
+				StartingContainerCount:  1,
+				Zone:                    "z1",
+				Evacuating:              true,
+				VolumeDrivers:           []string{"driver"},
+				PlacementTags:           []string{"tag"},
+				OptionalPlacementTags:   []string{"optional-tag"},
+				PlacementLabels:         map[string]string{"rack": "r1"},
+				DomainUsage:             map[string]rep.DomainUsage{"cf-apps": {MemoryMB: 20}},
+				Generation:              42,
+				ProxyMemoryAllocationMB: 5,
+				Scoring:                 rep.ScoringConfig{Strategy: rep.ScoringStrategyBinPack},
+				CachedRootFSes:          []string{"docker:///cached"},
+				LRPs: []rep.LRP{
+					newLRP("ig-1", "pg-1", "cf-apps"),
+					newLRP("ig-2", "pg-1", "cf-apps"),
+					newLRP("ig-3", "pg-2", "cf-apps"),
+					newLRP("ig-4", "pg-3", "other"),
+				},
+				Tasks: []rep.Task{
+					newTask("task-1", "cf-tasks"),
+					newTask("task-2", "other"),
+				},
+			}
+		})
+
+		It("leaves only the LRPs and tasks out of a summary", func() {
+			expected := state
+			expected.LRPs = nil
+			expected.Tasks = nil
+			Expect(state.Filter(rep.StateQuery{Summary: true})).To(Equal(expected))
+		})
+
+		It("keeps what scoring the cell needs in a summary", func() {
+			filtered := state.Filter(rep.StateQuery{Summary: true})
+			Expect(filtered.ProxyMemoryAllocationMB).To(Equal(5))
+			Expect(filtered.Scoring).To(Equal(state.Scoring))
+			Expect(filtered.ComputeScore(&rep.Resource{MemoryMB: 10}, 0.25)).To(Equal(state.ComputeScore(&rep.Resource{MemoryMB: 10}, 0.25)))
+		})
+
+		It("adds the included collections to a summary", func() {
+			filtered := state.Filter(rep.StateQuery{Summary: true, Include: []string{rep.StateIncludeTasks}})
+			Expect(filtered.LRPs).To(BeNil())
+			Expect(filtered.Tasks).To(Equal(state.Tasks))
+			Expect(filtered.RootFSProviders).To(Equal(state.RootFSProviders))
+		})
+
+		It("returns only the included collections", func() {
+			filtered := state.Filter(rep.StateQuery{Include: []string{rep.StateIncludeLRPs}})
+			Expect(filtered.LRPs).To(Equal(state.LRPs))
+			Expect(filtered.Tasks).To(BeNil())
+			Expect(filtered.RootFSProviders).To(Equal(state.RootFSProviders))
+			Expect(filtered.DomainUsage).To(Equal(state.DomainUsage))
+		})
+
+		It("filters by domain", func() {
+			filtered := state.Filter(rep.StateQuery{Domain: "other"})
+			Expect(filtered.LRPs).To(Equal([]rep.LRP{state.LRPs[3]}))
+			Expect(filtered.Tasks).To(Equal([]rep.Task{state.Tasks[1]}))
+		})
+
+		It("filters by process guid, returning no tasks", func() {
+			filtered := state.Filter(rep.StateQuery{ProcessGuid: "pg-1"})
+			Expect(filtered.LRPs).To(Equal(state.LRPs[:2]))
+			Expect(filtered.Tasks).To(BeEmpty())
+		})
+
+		It("pages through the LRPs and the tasks", func() {
+			filtered := state.Filter(rep.StateQuery{Offset: 1, Limit: 2})
+			Expect(filtered.LRPs).To(Equal(state.LRPs[1:3]))
+			Expect(filtered.Tasks).To(Equal(state.Tasks[1:]))
+
+			filtered = state.Filter(rep.StateQuery{Offset: 10, Limit: 2})
+			Expect(filtered.LRPs).To(BeEmpty())
+			Expect(filtered.Tasks).To(BeEmpty())
+		})
+
+		It("does not modify the state", func() {
+			state.Filter(rep.StateQuery{Summary: true, Domain: "other"})
+			Expect(state.LRPs).To(HaveLen(4))
+			Expect(state.RootFSProviders).NotTo(BeNil())
+		})
+	})
+})
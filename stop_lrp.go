@@ -0,0 +1,63 @@
+package rep
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	StopSignalTerm = "TERM"
+	StopSignalInt  = "INT"
+	StopSignalQuit = "QUIT"
+	StopSignalKill = "KILL"
+)
+
+// ErrLRPInstanceNotRunning is returned by Client.RestartLRPInstance when the
+// instance's container is not running, for instance because it is still
+// starting.
+var ErrLRPInstanceNotRunning = errors.New("lrp instance is not running")
+
+// ErrRestartRejected is returned by Client.RestartLRPInstance when the cell is
+// evacuating.
+var ErrRestartRejected = errors.New("cell is evacuating")
+
+// ErrStopOptionsUnsupported is returned by Client.StopLRPInstanceWithOptions
+// when the request has a grace period or signal. The executor stops every
+// container with its own signal and graceful shutdown interval, so the cell
+// rejects these options rather than ignore them, and does not stop the
+// instance.
+var ErrStopOptionsUnsupported = errors.New("the cell's executor cannot stop an instance with a grace period or signal")
+
+// StopLRPInstanceRequest is the optional body of a stop request. The zero
+// request stops the instance with the executor's default signal and grace
+// period.
+type StopLRPInstanceRequest struct {
+	// GracePeriodInSeconds is how long the instance is given to exit after
+	// being signalled, before it is killed.
+	GracePeriodInSeconds int `json:"grace_period_in_seconds,omitempty"`
+	// Signal is the first signal sent to the instance, among StopSignalTerm,
+	// StopSignalInt, StopSignalQuit and StopSignalKill.
+	Signal string `json:"signal,omitempty"`
+	// Reason is written to the instance's log stream once it is stopped.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (r StopLRPInstanceRequest) Validate() error {
+	if r.GracePeriodInSeconds < 0 {
+		return fmt.Errorf("invalid grace period: %d", r.GracePeriodInSeconds)
+	}
+
+	switch r.Signal {
+	case "", StopSignalTerm, StopSignalInt, StopSignalQuit, StopSignalKill:
+	default:
+		return fmt.Errorf("invalid signal: %q", r.Signal)
+	}
+
+	return nil
+}
+
+// HasStopOptions returns true if the request asks for a grace period or a
+// signal other than the executor's defaults.
+func (r StopLRPInstanceRequest) HasStopOptions() bool {
+	return r.GracePeriodInSeconds != 0 || r.Signal != ""
+}
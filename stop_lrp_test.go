@@ -0,0 +1,42 @@
+package rep_test
+
+import (
+	"code.cloudfoundry.org/rep"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StopLRPInstanceRequest", func() {
+	Describe("Validate", func() {
+		It("accepts the zero request", func() {
+			Expect(rep.StopLRPInstanceRequest{}.Validate()).To(Succeed())
+		})
+
+		It("accepts a grace period, a known signal and a reason", func() {
+			request := rep.StopLRPInstanceRequest{GracePeriodInSeconds: 120, Signal: rep.StopSignalQuit, Reason: "draining"}
+			Expect(request.Validate()).To(Succeed())
+		})
+
+		It("rejects a negative grace period", func() {
+			request := rep.StopLRPInstanceRequest{GracePeriodInSeconds: -1}
+			Expect(request.Validate()).To(MatchError("invalid grace period: -1"))
+		})
+
+		It("rejects an unknown signal", func() {
+			request := rep.StopLRPInstanceRequest{Signal: "SIGTERM"}
+			Expect(request.Validate()).To(MatchError(`invalid signal: "SIGTERM"`))
+		})
+	})
+
+	Describe("HasStopOptions", func() {
+		It("is false for a reason alone", func() {
+			Expect(rep.StopLRPInstanceRequest{Reason: "draining"}.HasStopOptions()).To(BeFalse())
+		})
+
+		It("is true for a grace period or a signal", func() {
+			Expect(rep.StopLRPInstanceRequest{GracePeriodInSeconds: 30}.HasStopOptions()).To(BeTrue())
+			Expect(rep.StopLRPInstanceRequest{Signal: rep.StopSignalKill}.HasStopOptions()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package rep
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a semantic version. Missing minor and patch components are zero,
+// and build metadata is ignored.
+type version struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+func parseVersion(s string) (version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+
+	var v version
+	if i := strings.Index(s, "-"); i >= 0 {
+		s, v.prerelease = s[:i], s[i+1:]
+		if v.prerelease == "" {
+			return version{}, fmt.Errorf("invalid version: %q", s)
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return version{}, fmt.Errorf("invalid version: %q", s)
+	}
+
+	components := []*int{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("invalid version: %q", s)
+		}
+		*components[i] = n
+	}
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 when v is lower than, equal to or greater than
+// other. A pre-release is lower than the release it precedes.
+func (v version) compare(other version) int {
+	for _, pair := range [][2]int{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] < pair[1] {
+			return -1
+		}
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return comparePrerelease(v.prerelease, other.prerelease)
+}
+
+// comparePrerelease compares pre-releases identifier by identifier, as
+// semantic versioning does: numeric identifiers compare numerically and are
+// lower than alphanumeric ones, which compare lexically, and a pre-release
+// whose identifiers all equal the start of another one's is lower.
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		aNumeric, bNumeric := aErr == nil, bErr == nil
+
+		switch {
+		case aNumeric && bNumeric:
+			if an != bn {
+				return compareOrder(an < bn)
+			}
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		case as[i] != bs[i]:
+			return compareOrder(as[i] < bs[i])
+		}
+	}
+
+	if len(as) == len(bs) {
+		return 0
+	}
+	return compareOrder(len(as) < len(bs))
+}
+
+func compareOrder(less bool) int {
+	if less {
+		return -1
+	}
+	return 1
+}
+
+type versionConstraint struct {
+	operator string
+	version  version
+}
+
+// versionRange is satisfied by versions that satisfy all of its constraints.
+type versionRange []versionConstraint
+
+var versionOperators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// parseVersionRange parses constraints such as ">=1.50, <2" separated by
+// commas or whitespace. An empty range or "*" matches every version.
+func parseVersionRange(s string) (versionRange, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	constraints := versionRange{}
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field == "*" {
+			continue
+		}
+
+		operator := "="
+		for _, op := range versionOperators {
+			if strings.HasPrefix(field, op) {
+				operator = op
+				field = strings.TrimPrefix(field, op)
+				break
+			}
+		}
+
+		// allow whitespace between the operator and the version, as in ">= 1.50"
+		if field == "" && i+1 < len(fields) {
+			i++
+			field = fields[i]
+		}
+
+		v, err := parseVersion(field)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, versionConstraint{operator: operator, version: v})
+	}
+
+	return constraints, nil
+}
+
+func (r versionRange) contains(v version) bool {
+	for _, constraint := range r {
+		c := v.compare(constraint.version)
+		var ok bool
+		switch constraint.operator {
+		case ">=":
+			ok = c >= 0
+		case ">":
+			ok = c > 0
+		case "<=":
+			ok = c <= 0
+		case "<":
+			ok = c < 0
+		case "!=":
+			ok = c != 0
+		default:
+			ok = c == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}